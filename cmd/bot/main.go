@@ -12,15 +12,24 @@ import (
 	"github.com/go-playground/validator/v10"
 	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
 	postgresConfig "github.com/grandminingpool/telegram-bot/configs/postgres"
-	"github.com/grandminingpool/telegram-bot/internal/blockchains"
-	poolBot "github.com/grandminingpool/telegram-bot/internal/bot"
-	"github.com/grandminingpool/telegram-bot/internal/bot/handlers"
-	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	serverConfig "github.com/grandminingpool/telegram-bot/configs/server"
+	tracingConfig "github.com/grandminingpool/telegram-bot/configs/tracing"
+	"github.com/grandminingpool/telegram-bot/internal/app"
 	"github.com/grandminingpool/telegram-bot/internal/common/flags"
 	"github.com/grandminingpool/telegram-bot/internal/common/languages"
 	"github.com/grandminingpool/telegram-bot/internal/common/logger"
-	botNotify "github.com/grandminingpool/telegram-bot/internal/notify"
+	"github.com/grandminingpool/telegram-bot/internal/health"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
 	postgresProvider "github.com/grandminingpool/telegram-bot/internal/providers/postgres"
+	sqliteProvider "github.com/grandminingpool/telegram-bot/internal/providers/sqlite"
+	"github.com/grandminingpool/telegram-bot/internal/server"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	memoryStorage "github.com/grandminingpool/telegram-bot/internal/storage/memory"
+	sqlStorage "github.com/grandminingpool/telegram-bot/internal/storage/sql"
+	"github.com/grandminingpool/telegram-bot/internal/tracing"
+	"github.com/grandminingpool/telegram-bot/migrations"
+	sqliteMigrations "github.com/grandminingpool/telegram-bot/migrations/sqlite"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
@@ -50,79 +59,134 @@ func main() {
 	//	Init validator
 	validate := validator.New()
 
+	//	Run migrate subcommand instead of bot
+	if len(flagsConf.Command) > 0 {
+		if flagsConf.Command[0] != MIGRATE_COMMAND {
+			zap.L().Fatal("unknown command", zap.String("command", flagsConf.Command[0]))
+		}
+
+		if err := runMigrate(ctx, flagsConf.Command[1:], flagsConf.ConfigsPath, validate); err != nil {
+			zap.L().Fatal("failed to run migrate command", zap.Error(err))
+		}
+
+		cancel()
+
+		return
+	}
+
 	//	Load languages
 	languages, err := languages.LoadLanguages(flagsConf.LocalesPath, flagsConf.Locales)
 	if err != nil {
 		zap.L().Fatal("failed to load languages", zap.Error(err))
 	}
 
+	//	Init bot config
+	botConf, err := botConfig.New(flagsConf.ConfigsPath, validate)
+	if err != nil {
+		zap.L().Fatal("failed to load bot config", zap.Error(err))
+	}
+
+	//	Keep recent errors in memory for admins
+	errorsBuffer := logger.NewErrorsBuffer(botConf.Admin.RecentErrorsLimit)
+	zap.ReplaceGlobals(zapLogger.WithOptions(errorsBuffer.WrapCore()))
+
 	//	Init postgres config
 	postgresConf, err := postgresConfig.New(flagsConf.ConfigsPath, validate)
 	if err != nil {
 		zap.L().Fatal("failed to load postgres config", zap.Error(err))
 	}
 
-	//	Init postgres connection
-	pgConn, err := postgresProvider.NewConnection(ctx, postgresConf)
+	//	Init server config
+	serverConf, err := serverConfig.New(flagsConf.ConfigsPath, validate)
 	if err != nil {
-		zap.L().Fatal("failed to create postgres connection", zap.Error(err))
+		zap.L().Fatal("failed to load server config", zap.Error(err))
 	}
 
-	zap.L().Info("successfully connected to postgres database")
-
-	//	Init blockchains service and start
-	blockchainsService := blockchains.NewService(pgConn)
-	if err := blockchainsService.Start(ctx, flagsConf.CertsPath); err != nil {
-		zap.L().Fatal("failed to start blockchains service", zap.Error(err))
+	//	Init tracing config
+	tracingConf, err := tracingConfig.New(flagsConf.ConfigsPath, validate)
+	if err != nil {
+		zap.L().Fatal("failed to load tracing config", zap.Error(err))
 	}
 
-	//	Init bot services
-	userService := services.NewUserService(pgConn)
-	userActionService := services.NewUserActionService(pgConn)
-	userWalletService := services.NewUserWalletService(pgConn, blockchainsService)
-	feedbackService := services.NewFeedbackService(pgConn)
-
-	//	Init bot config
-	botConf, err := botConfig.New(flagsConf.ConfigsPath, validate)
+	//	Setup tracing
+	tracingProvider, err := tracing.Setup(ctx, tracingConf)
 	if err != nil {
-		zap.L().Fatal("failed to load bot config", zap.Error(err))
+		zap.L().Fatal("failed to setup tracing", zap.Error(err))
 	}
 
-	//	Create bot
-	defaultHandler := handlers.NewDefaultHandler(languages)
-	botOptions := poolBot.CreateBotOptions(
-		flagsConf.Mode,
-		blockchainsService,
-		userService,
-		userActionService,
-		userWalletService,
-		languages,
-		defaultHandler,
-		botConf,
+	//	Init storage
+	var (
+		dbConn *sqlx.DB
+		store  *storage.Storage
 	)
-	b, err := poolBot.CreateBot(botOptions, botConf.BotToken)
-	if err != nil {
-		zap.L().Fatal("failed to create bot", zap.Error(err))
+	switch postgresConf.Driver {
+	case postgresConfig.MEMORY_DRIVER:
+		store = memoryStorage.New().Storage()
+
+		zap.L().Warn("using in-memory storage, all data is lost on stop")
+	case postgresConfig.SQLITE_DRIVER:
+		//	Init sqlite connection
+		dbConn, err = sqliteProvider.NewConnection(ctx, postgresConf, &metrics.DBHooks{}, tracing.NewDBHooks("sqlite"))
+		if err != nil {
+			zap.L().Fatal("failed to create sqlite connection", zap.Error(err))
+		}
+
+		zap.L().Info("successfully connected to sqlite database", zap.String("path", postgresConf.Path))
+
+		//	Check database schema version
+		if err := checkSchemaVersion(ctx, dbConn, sqliteMigrations.FS); err != nil {
+			zap.L().Fatal("failed to check database schema version", zap.Error(err))
+		}
+
+		store = sqlStorage.New(dbConn)
+	default:
+		//	Init postgres connection
+		dbConn, err = postgresProvider.NewConnection(ctx, postgresConf, &metrics.DBHooks{}, tracing.NewDBHooks("postgresql"))
+		if err != nil {
+			zap.L().Fatal("failed to create postgres connection", zap.Error(err))
+		}
+
+		zap.L().Info("successfully connected to postgres database")
+
+		//	Check database schema version
+		if err := checkSchemaVersion(ctx, dbConn, migrations.FS); err != nil {
+			zap.L().Fatal("failed to check database schema version", zap.Error(err))
+		}
+
+		store = sqlStorage.New(dbConn)
 	}
 
-	poolBotHandlerMatcher := poolBot.NewHandlerMatcher(ctx, userActionService)
-	poolBot.RegisterHandlers(
-		b,
-		poolBotHandlerMatcher,
-		defaultHandler,
-		userActionService,
-		userWalletService,
-		feedbackService,
-		blockchainsService,
+	//	Create app
+	a, err := app.New(
+		ctx,
 		botConf,
+		languages,
+		store,
+		app.WithMode(flagsConf.Mode),
+		app.WithCertsPath(flagsConf.CertsPath),
+		app.WithErrorsBuffer(errorsBuffer),
 	)
+	if err != nil {
+		zap.L().Fatal("failed to create app", zap.Error(err))
+	}
 
-	if err := poolBot.SetBotDescription(ctx, b, languages.GetLocalizers()); err != nil {
-		zap.L().Warn("failed to set bot description", zap.Error(err))
+	//	Init health checker
+	healthChecker := health.NewChecker(serverConf.ReadinessTimeoutDuration())
+	if dbConn != nil {
+		healthChecker.Add(postgresConf.Driver, health.DatabaseCheck(postgresConf.Driver, dbConn))
 	}
 
-	//	Create botify service
-	notifyService := botNotify.NewService(pgConn, blockchainsService, b, languages, &botConf.Notify)
+	healthChecker.Add("poolAPI", health.PoolAPICheck(a.Blockchains))
+	healthChecker.Add("telegram", health.TelegramCheck(a.Bot))
+	healthChecker.Add("notify", health.NotifyCheck(a.Notify))
+
+	//	Start metrics and health server
+	httpServer := server.NewServer(serverConf)
+	httpServer.Handle(serverConf.LivenessPath, healthChecker.LivenessHandler())
+	httpServer.Handle(serverConf.ReadinessPath, healthChecker.ReadinessHandler())
+	httpServer.Start()
+
+	zap.L().Info("started http server", zap.String("address", serverConf.Address()))
 
 	//	Subscribe to system signals
 	signalChan := make(chan os.Signal, 1)
@@ -144,11 +208,15 @@ func main() {
 		zap.L().Info("waiting for all processes to stop", zap.String("signal", stop.String()))
 
 		var stopErr error
-		if stopErr = notifyService.Stop(); stopErr != nil {
+		if stopErr = a.Notify.Stop(); stopErr != nil {
 			zap.L().Fatal("failed to stop notify service", zap.Error(stopErr))
 		}
 
-		ok, stopErr := b.Close(ctx)
+		if stopErr = httpServer.Shutdown(ctx); stopErr != nil {
+			zap.L().Error("failed to shutdown http server", zap.Error(stopErr))
+		}
+
+		ok, stopErr := a.Bot.Close(ctx)
 		if stopErr != nil {
 			zap.L().Fatal("failed to close bot instance", zap.Error(stopErr))
 		} else if !ok {
@@ -159,25 +227,37 @@ func main() {
 
 		cancel()
 
-		blockchainsService.Close()
+		a.Broadcasts.Wait()
+		zap.L().Info("stopped broadcasts delivery")
+
+		a.Privacy.Wait()
+		zap.L().Info("stopped inactive users cleanup")
+
+		a.Blockchains.Close()
 		zap.L().Info("closed blockchains pool api connections")
 
-		if stopErr = pgConn.Close(); stopErr != nil {
-			zap.L().Fatal("failed to close postgres connection", zap.Error(stopErr))
+		if dbConn != nil {
+			if stopErr = dbConn.Close(); stopErr != nil {
+				zap.L().Fatal("failed to close database connection", zap.Error(stopErr), zap.String("driver", postgresConf.Driver))
+			}
+
+			zap.L().Info("closed database connection", zap.String("driver", postgresConf.Driver))
 		}
 
-		zap.L().Info("closed postgres connection")
+		if stopErr = tracingProvider.Shutdown(context.Background()); stopErr != nil {
+			zap.L().Error("failed to shutdown tracing", zap.Error(stopErr))
+		}
 	}()
 
+	//	Start app services
+	if err := a.Start(ctx); err != nil {
+		zap.L().Fatal("failed to start app", zap.Error(err))
+	}
+
 	//	Run bot
 	zap.L().Info("starting bot")
 
-	b.Start(ctx)
-
-	//	Start notify service
-	if err := notifyService.Start(ctx); err != nil {
-		zap.L().Fatal("failed to start notify service", zap.Error(err))
-	}
+	a.Bot.Start(ctx)
 
 	wg.Wait()
 	zap.L().Info("bot stopped")
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	postgresConfig "github.com/grandminingpool/telegram-bot/configs/postgres"
+	"github.com/grandminingpool/telegram-bot/internal/migrate"
+	postgresProvider "github.com/grandminingpool/telegram-bot/internal/providers/postgres"
+	sqliteProvider "github.com/grandminingpool/telegram-bot/internal/providers/sqlite"
+	"github.com/grandminingpool/telegram-bot/migrations"
+	sqliteMigrations "github.com/grandminingpool/telegram-bot/migrations/sqlite"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	MIGRATE_COMMAND        = "migrate"
+	MIGRATE_UP_COMMAND     = "up"
+	MIGRATE_DOWN_COMMAND   = "down"
+	MIGRATE_STATUS_COMMAND = "status"
+	MIGRATE_FORCE_COMMAND  = "force"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up | down [steps] | status | force <version>")
+
+func printMigrateStatus(status *migrate.Status) {
+	fmt.Printf("version: %d, latest: %d, dirty: %t\n", status.Version, status.Latest, status.Dirty)
+
+	for _, migration := range status.Applied {
+		fmt.Printf("applied  %06d_%s\n", migration.Version, migration.Name)
+	}
+
+	for _, migration := range status.Pending {
+		fmt.Printf("pending  %06d_%s\n", migration.Version, migration.Name)
+	}
+}
+
+func checkSchemaVersion(ctx context.Context, conn *sqlx.DB, migrationsFS fs.FS) error {
+	migrator, err := migrate.NewMigrator(conn, migrationsFS)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	return migrator.CheckVersion(ctx)
+}
+
+func runMigrate(ctx context.Context, args []string, configsPath string, validate *validator.Validate) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	postgresConf, err := postgresConfig.New(configsPath, validate)
+	if err != nil {
+		return fmt.Errorf("failed to load postgres config: %w", err)
+	}
+
+	var (
+		conn         *sqlx.DB
+		migrationsFS fs.FS
+	)
+	switch postgresConf.Driver {
+	case postgresConfig.POSTGRES_DRIVER:
+		if conn, err = postgresProvider.NewConnection(ctx, postgresConf); err != nil {
+			return fmt.Errorf("failed to create postgres connection: %w", err)
+		}
+
+		migrationsFS = migrations.FS
+	case postgresConfig.SQLITE_DRIVER:
+		if conn, err = sqliteProvider.NewConnection(ctx, postgresConf); err != nil {
+			return fmt.Errorf("failed to create sqlite connection: %w", err)
+		}
+
+		migrationsFS = sqliteMigrations.FS
+	default:
+		return fmt.Errorf("migrations are not supported by %s driver", postgresConf.Driver)
+	}
+
+	defer conn.Close()
+
+	migrator, err := migrate.NewMigrator(conn, migrationsFS)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	switch args[0] {
+	case MIGRATE_UP_COMMAND:
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("applied %d migrations, version: %d\n", len(applied), migrator.Latest())
+	case MIGRATE_DOWN_COMMAND:
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid steps count: %s", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("reverted %d migrations\n", len(reverted))
+	case MIGRATE_STATUS_COMMAND:
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		printMigrateStatus(status)
+	case MIGRATE_FORCE_COMMAND:
+		if len(args) < 2 {
+			return errMigrateUsage
+		}
+
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version: %s", args[1])
+		}
+
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+
+		fmt.Printf("forced version: %d\n", version)
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	LOCALES_PATH_DEFAULT = "locales"
+	SOURCE_PATH_DEFAULT  = "."
+	BASE_LOCALE_DEFAULT  = "en"
+	LOCALE_FILE_PREFIX   = "active."
+	LOCALE_FILE_SUFFIX   = ".toml"
+	MESSAGE_ID_FIELD     = "MessageID"
+	PLURAL_OTHER_FORM    = "other"
+)
+
+var pluralForms = []string{"zero", "one", "two", "few", "many", PLURAL_OTHER_FORM}
+
+var baseTag = BASE_LOCALE_DEFAULT
+
+var templateVariableRegexp = regexp.MustCompile(`{{-?\s*(?:if|with|range)?\s*\.(\w+)`)
+
+type message struct {
+	plural    bool
+	forms     map[string]string
+	variables []string
+}
+
+type locale struct {
+	tag      string
+	messages map[string]*message
+}
+
+type sourceMessages struct {
+	messageIDs map[string]string
+	literals   map[string]bool
+}
+
+type problems []string
+
+func (p *problems) add(format string, args ...any) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func templateVariables(forms map[string]string) []string {
+	variables := []string{}
+	for _, text := range forms {
+		for _, match := range templateVariableRegexp.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(variables, match[1]) {
+				variables = append(variables, match[1])
+			}
+		}
+	}
+
+	sort.Strings(variables)
+
+	return variables
+}
+
+func parseMessage(value any) (*message, error) {
+	msg := &message{
+		forms: make(map[string]string),
+	}
+
+	switch v := value.(type) {
+	case string:
+		msg.forms[PLURAL_OTHER_FORM] = v
+	case map[string]any:
+		msg.plural = true
+		for form, text := range v {
+			str, ok := text.(string)
+			if !ok {
+				return nil, fmt.Errorf("plural form %s is not a string", form)
+			}
+
+			msg.forms[form] = str
+		}
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+
+	msg.variables = templateVariables(msg.forms)
+
+	return msg, nil
+}
+
+func loadLocale(path, tag string) (*locale, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locale file (path: %s): %w", path, err)
+	}
+
+	values := make(map[string]any)
+	if err := toml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse locale file (path: %s): %w", path, err)
+	}
+
+	l := &locale{
+		tag:      tag,
+		messages: make(map[string]*message, len(values)),
+	}
+	for id, value := range values {
+		msg, err := parseMessage(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message (path: %s, id: %s): %w", path, id, err)
+		}
+
+		l.messages[id] = msg
+	}
+
+	return l, nil
+}
+
+func loadLocales(localesPath string) ([]*locale, error) {
+	files, err := filepath.Glob(filepath.Join(localesPath, LOCALE_FILE_PREFIX+"*"+LOCALE_FILE_SUFFIX))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find locale files (path: %s): %w", localesPath, err)
+	}
+
+	sort.Strings(files)
+
+	locales := make([]*locale, 0, len(files))
+	for _, file := range files {
+		tag := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), LOCALE_FILE_PREFIX), LOCALE_FILE_SUFFIX)
+		l, err := loadLocale(file, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		locales = append(locales, l)
+	}
+
+	return locales, nil
+}
+
+func scanSource(sourcePath string) (*sourceMessages, error) {
+	source := &sourceMessages{
+		messageIDs: make(map[string]string),
+		literals:   make(map[string]bool),
+	}
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(sourcePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != sourcePath && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("failed to parse source file (path: %s): %w", path, err)
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.BasicLit:
+				if n.Kind == token.STRING {
+					if value, err := strconv.Unquote(n.Value); err == nil {
+						source.literals[value] = true
+					}
+				}
+			case *ast.KeyValueExpr:
+				key, ok := n.Key.(*ast.Ident)
+				if !ok || key.Name != MESSAGE_ID_FIELD {
+					return true
+				}
+
+				//	Message IDs passed through variables can't be resolved statically
+				lit, ok := n.Value.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+
+				if value, err := strconv.Unquote(lit.Value); err == nil {
+					source.messageIDs[value] = fset.Position(lit.Pos()).String()
+				}
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func checkLocale(base, l *locale, p *problems) {
+	for id, baseMsg := range base.messages {
+		msg, ok := l.messages[id]
+		if !ok {
+			p.add("%s: missing message %s", l.tag, id)
+			continue
+		}
+
+		for form := range msg.forms {
+			if !slices.Contains(pluralForms, form) {
+				p.add("%s: message %s has unknown plural form %s", l.tag, id, form)
+			}
+		}
+
+		if msg.plural != baseMsg.plural {
+			p.add("%s: message %s plural mismatch: expected %t, got %t", l.tag, id, baseMsg.plural, msg.plural)
+		} else if _, ok := msg.forms[PLURAL_OTHER_FORM]; !ok {
+			p.add("%s: message %s has no \"%s\" plural form", l.tag, id, PLURAL_OTHER_FORM)
+		}
+
+		if !slices.Equal(msg.variables, baseMsg.variables) {
+			p.add("%s: message %s template variables mismatch: expected %v, got %v", l.tag, id, baseMsg.variables, msg.variables)
+		}
+	}
+
+	for id := range l.messages {
+		if _, ok := base.messages[id]; !ok {
+			p.add("%s: extra message %s", l.tag, id)
+		}
+	}
+}
+
+func checkSource(base *locale, source *sourceMessages, p *problems) {
+	for id, position := range source.messageIDs {
+		if _, ok := base.messages[id]; !ok {
+			p.add("%s: undefined message %s", position, id)
+		}
+	}
+
+	//	Message IDs are also kept in keyboards and commands tables, so any string literal counts as usage
+	for id := range base.messages {
+		if !source.literals[id] {
+			p.add("%s: unused message %s", base.tag, id)
+		}
+	}
+}
+
+func run(localesPath, sourcePath string) ([]string, error) {
+	locales, err := loadLocales(localesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	baseIndex := slices.IndexFunc(locales, func(l *locale) bool {
+		return l.tag == baseTag
+	})
+	if baseIndex == -1 {
+		return nil, fmt.Errorf("base locale %s not found in %s", baseTag, localesPath)
+	}
+
+	base := locales[baseIndex]
+
+	source, err := scanSource(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	p := problems{}
+	for _, l := range locales {
+		if l != base {
+			checkLocale(base, l, &p)
+		}
+	}
+
+	checkSource(base, source, &p)
+	sort.Strings(p)
+
+	return p, nil
+}
+
+func main() {
+	localesPath := flag.String("locales_path", LOCALES_PATH_DEFAULT, "locales path")
+	sourcePath := flag.String("source", SOURCE_PATH_DEFAULT, "go source path scanned for message ids")
+	flag.StringVar(&baseTag, "base", BASE_LOCALE_DEFAULT, "base locale other locales are compared with")
+	flag.Parse()
+
+	p, err := run(*localesPath, *sourcePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if len(p) > 0 {
+		for _, problem := range p {
+			fmt.Println(problem)
+		}
+
+		fmt.Fprintf(os.Stderr, "found %d locale problems\n", len(p))
+		os.Exit(1)
+	}
+
+	fmt.Printf("checked locales in %s, no problems found\n", *localesPath)
+}
@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+const (
+	TEST_LOCALES_PATH = "../../locales"
+	TEST_SOURCE_PATH  = "../.."
+)
+
+func TestLocales(t *testing.T) {
+	p, err := run(TEST_LOCALES_PATH, TEST_SOURCE_PATH)
+	if err != nil {
+		t.Fatalf("failed to check locales: %v", err)
+	}
+
+	for _, problem := range p {
+		t.Error(problem)
+	}
+}
@@ -22,9 +22,93 @@ func (c CheckIntervalsConfig) PayoutsDuration() time.Duration {
 	return time.Duration(c.Payouts) * time.Minute
 }
 
+type PoolAPIConfig struct {
+	CallTimeout         int `mapstructure:"callTimeout"`
+	HealthCheckInterval int `mapstructure:"healthCheckInterval"`
+	HealthCheckTimeout  int `mapstructure:"healthCheckTimeout"`
+	FailureThreshold    int `mapstructure:"failureThreshold"`
+	OpenTimeout         int `mapstructure:"openTimeout"`
+}
+
+func (c PoolAPIConfig) CallTimeoutDuration() time.Duration {
+	return time.Duration(c.CallTimeout) * time.Second
+}
+
+func (c PoolAPIConfig) HealthCheckIntervalDuration() time.Duration {
+	return time.Duration(c.HealthCheckInterval) * time.Second
+}
+
+func (c PoolAPIConfig) HealthCheckTimeoutDuration() time.Duration {
+	return time.Duration(c.HealthCheckTimeout) * time.Second
+}
+
+func (c PoolAPIConfig) OpenTimeoutDuration() time.Duration {
+	return time.Duration(c.OpenTimeout) * time.Second
+}
+
 type SupportBotConfig struct {
 	UserID   int64  `mapstructure:"userID" validate:"required"`
 	Username string `mapstructure:"username" validate:"required"`
+	ChatID   int64  `mapstructure:"chatID"`
+}
+
+func (c SupportBotConfig) SupportChatID() int64 {
+	//	Tickets go to support group if configured, otherwise to support user private chat
+	if c.ChatID != 0 {
+		return c.ChatID
+	}
+
+	return c.UserID
+}
+
+type AdminConfig struct {
+	UserIDs           []int64 `mapstructure:"userIDs"`
+	RecentErrorsLimit int     `mapstructure:"recentErrorsLimit"`
+}
+
+type BroadcastConfig struct {
+	MessagesPerSecond int `mapstructure:"messagesPerSecond"`
+	BatchSize         int `mapstructure:"batchSize"`
+}
+
+func (c BroadcastConfig) SendInterval() time.Duration {
+	if c.MessagesPerSecond <= 0 {
+		return time.Second
+	}
+
+	return time.Second / time.Duration(c.MessagesPerSecond)
+}
+
+type DeepLinkConfig struct {
+	Secret string `mapstructure:"secret"`
+}
+
+type InlineQueryConfig struct {
+	CacheTime         int `mapstructure:"cacheTime"`
+	RateLimitInterval int `mapstructure:"rateLimitInterval"`
+}
+
+func (c InlineQueryConfig) CacheTimeDuration() time.Duration {
+	return time.Duration(c.CacheTime) * time.Second
+}
+
+func (c InlineQueryConfig) RateLimitIntervalDuration() time.Duration {
+	return time.Duration(c.RateLimitInterval) * time.Second
+}
+
+type WalletImportConfig struct {
+	MaxLines    int   `mapstructure:"maxLines"`
+	MaxFileSize int64 `mapstructure:"maxFileSize"`
+	Concurrency int   `mapstructure:"concurrency"`
+}
+
+type PrivacyConfig struct {
+	InactiveRetention int `mapstructure:"inactiveRetention"`
+	CleanupInterval   int `mapstructure:"cleanupInterval"`
+}
+
+func (c PrivacyConfig) CleanupIntervalDuration() time.Duration {
+	return time.Duration(c.CleanupInterval) * time.Minute
 }
 
 type NotifyConfig struct {
@@ -36,11 +120,18 @@ type NotifyConfig struct {
 }
 
 type Config struct {
-	BotToken            string           `mapstructure:"botToken" validate:"required"`
-	PoolURL             string           `mapstructure:"poolURL" validate:"required"`
-	SupportBot          SupportBotConfig `mapstructure:"supportBot" validate:"required"`
-	WalletsLimitPerUser int              `mapstructure:"walletsLimitPerUser"`
-	Notify              NotifyConfig     `mapstructure:"notify"`
+	BotToken            string             `mapstructure:"botToken" validate:"required"`
+	PoolURL             string             `mapstructure:"poolURL" validate:"required"`
+	SupportBot          SupportBotConfig   `mapstructure:"supportBot" validate:"required"`
+	WalletsLimitPerUser int                `mapstructure:"walletsLimitPerUser"`
+	Notify              NotifyConfig       `mapstructure:"notify"`
+	PoolAPI             PoolAPIConfig      `mapstructure:"poolAPI"`
+	Admin               AdminConfig        `mapstructure:"admin"`
+	Broadcast           BroadcastConfig    `mapstructure:"broadcast"`
+	DeepLink            DeepLinkConfig     `mapstructure:"deepLink"`
+	InlineQuery         InlineQueryConfig  `mapstructure:"inlineQuery"`
+	WalletImport        WalletImportConfig `mapstructure:"walletImport"`
+	Privacy             PrivacyConfig      `mapstructure:"privacy"`
 }
 
 const configName = "bot"
@@ -59,6 +150,21 @@ func New(configsPath string, validate *validator.Validate) (*Config, error) {
 	botViper.SetDefault("notify.soloPaymentsInterval", 200)
 	botViper.SetDefault("notify.checkIntervals.workers", 5)
 	botViper.SetDefault("notify.checkIntervals.payouts", 60)
+	botViper.SetDefault("poolAPI.callTimeout", 10)
+	botViper.SetDefault("poolAPI.healthCheckInterval", 15)
+	botViper.SetDefault("poolAPI.healthCheckTimeout", 3)
+	botViper.SetDefault("poolAPI.failureThreshold", 3)
+	botViper.SetDefault("poolAPI.openTimeout", 30)
+	botViper.SetDefault("admin.recentErrorsLimit", 50)
+	botViper.SetDefault("broadcast.messagesPerSecond", 20)
+	botViper.SetDefault("broadcast.batchSize", 100)
+	botViper.SetDefault("inlineQuery.cacheTime", 60)
+	botViper.SetDefault("inlineQuery.rateLimitInterval", 2)
+	botViper.SetDefault("walletImport.maxLines", 100)
+	botViper.SetDefault("walletImport.maxFileSize", 262144)
+	botViper.SetDefault("walletImport.concurrency", 8)
+	botViper.SetDefault("privacy.inactiveRetention", 30)
+	botViper.SetDefault("privacy.cleanupInterval", 60)
 
 	if err := configUtils.ReadConfig(botViper, configName); err != nil {
 		return nil, err
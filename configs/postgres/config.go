@@ -8,12 +8,20 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	POSTGRES_DRIVER = "postgres"
+	SQLITE_DRIVER   = "sqlite"
+	MEMORY_DRIVER   = "memory"
+)
+
 type Config struct {
+	Driver   string `mapstructure:"driver" validate:"oneof=postgres sqlite memory"`
 	Host     string `mapstructure:"host"`
 	Port     int16  `mapstructure:"port"`
-	User     string `mapstructure:"user" validate:"required"`
-	Password string `mapstructure:"password" validate:"required"`
-	Database string `mapstructure:"database" validate:"required"`
+	User     string `mapstructure:"user" validate:"required_if=Driver postgres"`
+	Password string `mapstructure:"password" validate:"required_if=Driver postgres"`
+	Database string `mapstructure:"database" validate:"required_if=Driver postgres"`
+	Path     string `mapstructure:"path" validate:"required_if=Driver sqlite"`
 }
 
 const configName = "postgres"
@@ -22,11 +30,17 @@ func (c *Config) DSN() string {
 	return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=disable", c.User, c.Password, c.Host, c.Port, c.Database)
 }
 
+func (c *Config) SQLiteDSN() string {
+	//	Foreign keys are disabled in SQLite by default, but cascade deletes rely on them
+	return fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL", c.Path)
+}
+
 func New(configsPath string, validate *validator.Validate) (*Config, error) {
 	postgresViper := viper.New()
 	postgresViper.AddConfigPath(fmt.Sprintf("%s/postgres", configsPath))
 	postgresViper.SetConfigType("yaml")
 
+	postgresViper.SetDefault("driver", POSTGRES_DRIVER)
 	postgresViper.SetDefault("host", "127.0.0.1")
 	postgresViper.SetDefault("port", 5432)
 
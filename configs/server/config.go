@@ -0,0 +1,65 @@
+package serverConfig
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	configUtils "github.com/grandminingpool/telegram-bot/internal/common/utils/config"
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Host             string `mapstructure:"host"`
+	Port             uint16 `mapstructure:"port" validate:"required"`
+	MetricsPath      string `mapstructure:"metricsPath" validate:"required"`
+	LivenessPath     string `mapstructure:"livenessPath" validate:"required"`
+	ReadinessPath    string `mapstructure:"readinessPath" validate:"required"`
+	ReadinessTimeout int    `mapstructure:"readinessTimeout"`
+	ReadTimeout      int    `mapstructure:"readTimeout"`
+	ShutdownTimeout  int    `mapstructure:"shutdownTimeout"`
+}
+
+const configName = "server"
+
+func (c *Config) Address() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+func (c *Config) ReadinessTimeoutDuration() time.Duration {
+	return time.Duration(c.ReadinessTimeout) * time.Second
+}
+
+func (c *Config) ReadTimeoutDuration() time.Duration {
+	return time.Duration(c.ReadTimeout) * time.Second
+}
+
+func (c *Config) ShutdownTimeoutDuration() time.Duration {
+	return time.Duration(c.ShutdownTimeout) * time.Second
+}
+
+func New(configsPath string, validate *validator.Validate) (*Config, error) {
+	serverViper := viper.New()
+	serverViper.AddConfigPath(fmt.Sprintf("%s/server", configsPath))
+	serverViper.SetConfigType("yaml")
+
+	serverViper.SetDefault("host", "0.0.0.0")
+	serverViper.SetDefault("port", 9100)
+	serverViper.SetDefault("metricsPath", "/metrics")
+	serverViper.SetDefault("livenessPath", "/healthz")
+	serverViper.SetDefault("readinessPath", "/readyz")
+	serverViper.SetDefault("readinessTimeout", 5)
+	serverViper.SetDefault("readTimeout", 10)
+	serverViper.SetDefault("shutdownTimeout", 5)
+
+	if err := configUtils.ReadOptionalConfig(serverViper, configName); err != nil {
+		return nil, err
+	}
+
+	config, err := configUtils.LoadConfig[Config](serverViper, validate, configName)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
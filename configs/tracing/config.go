@@ -0,0 +1,56 @@
+package tracingConfig
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	configUtils "github.com/grandminingpool/telegram-bot/internal/common/utils/config"
+	"github.com/spf13/viper"
+)
+
+type Exporter string
+
+const (
+	EXPORTER_NONE Exporter = "none"
+	EXPORTER_OTLP Exporter = "otlp"
+	EXPORTER_FILE Exporter = "file"
+)
+
+type OTLPConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+	Insecure bool   `mapstructure:"insecure"`
+}
+
+type Config struct {
+	Exporter    Exporter   `mapstructure:"exporter" validate:"oneof=none otlp file"`
+	ServiceName string     `mapstructure:"serviceName" validate:"required"`
+	SampleRatio float64    `mapstructure:"sampleRatio" validate:"gte=0,lte=1"`
+	OTLP        OTLPConfig `mapstructure:"otlp"`
+	FilePath    string     `mapstructure:"filePath" validate:"required_if=Exporter file"`
+}
+
+const configName = "tracing"
+
+func New(configsPath string, validate *validator.Validate) (*Config, error) {
+	tracingViper := viper.New()
+	tracingViper.AddConfigPath(fmt.Sprintf("%s/tracing", configsPath))
+	tracingViper.SetConfigType("yaml")
+
+	tracingViper.SetDefault("exporter", string(EXPORTER_NONE))
+	tracingViper.SetDefault("serviceName", "grandpool-telegram-bot")
+	tracingViper.SetDefault("sampleRatio", 1)
+	tracingViper.SetDefault("otlp.endpoint", "localhost:4317")
+	tracingViper.SetDefault("otlp.insecure", false)
+	tracingViper.SetDefault("filePath", "traces.json")
+
+	if err := configUtils.ReadOptionalConfig(tracingViper, configName); err != nil {
+		return nil, err
+	}
+
+	config, err := configUtils.LoadConfig[Config](tracingViper, validate, configName)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,162 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-telegram/bot"
+	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	poolBot "github.com/grandminingpool/telegram-bot/internal/bot"
+	"github.com/grandminingpool/telegram-bot/internal/bot/handlers"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/broadcasts"
+	"github.com/grandminingpool/telegram-bot/internal/common/flags"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/common/logger"
+	botNotify "github.com/grandminingpool/telegram-bot/internal/notify"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"go.uber.org/zap"
+)
+
+type App struct {
+	Bot         *bot.Bot
+	Blockchains *blockchains.Service
+	Notify      *botNotify.Service
+	Broadcasts  *broadcasts.Service
+	Privacy     *privacy.Service
+}
+
+func (a *App) Start(ctx context.Context) error {
+	//	Start notify service
+	if err := a.Notify.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start notify service: %w", err)
+	}
+
+	//	Resume interrupted broadcasts
+	if err := a.Broadcasts.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start broadcasts service: %w", err)
+	}
+
+	//	Start inactive users cleanup
+	a.Privacy.Start(ctx)
+
+	return nil
+}
+
+func New(
+	ctx context.Context,
+	config *botConfig.Config,
+	languages *languages.Languages,
+	store *storage.Storage,
+	opts ...Option,
+) (*App, error) {
+	o := &options{
+		mode: flags.AppModeDev,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.errorsBuffer == nil {
+		o.errorsBuffer = logger.NewErrorsBuffer(config.Admin.RecentErrorsLimit)
+	}
+
+	//	Init blockchains service and start
+	blockchainsService := blockchains.NewService(store.Blockchains, &config.PoolAPI)
+	if err := blockchainsService.Start(ctx, o.certsPath, o.mode, o.dialOptions...); err != nil {
+		return nil, fmt.Errorf("failed to start blockchains service: %w", err)
+	}
+
+	//	Init bot services
+	userService := services.NewUserService(store.Users)
+	userActionService := services.NewUserActionService(store.UserActions)
+	userWalletService := services.NewUserWalletService(store.Wallets, blockchainsService)
+	supportService := services.NewSupportService(store.Support, store.Users, store.Wallets)
+	chatTargetService := services.NewChatTargetService(store.ChatTargets)
+	walletInviteService := services.NewWalletInviteService(store.WalletInvites, store.Wallets, config.WalletsLimitPerUser)
+	walletLookupService := services.NewWalletLookupService(
+		blockchainsService,
+		userWalletService,
+		config.InlineQuery.CacheTimeDuration(),
+	)
+	walletImportService := services.NewWalletImportService(
+		userWalletService,
+		blockchainsService,
+		config.WalletsLimitPerUser,
+		config.WalletImport.MaxLines,
+		config.WalletImport.Concurrency,
+	)
+	adminService := services.NewAdminService(store.Admins, store.Users, config.Admin.UserIDs)
+	privacyService := privacy.NewService(store.Privacy, &config.Privacy)
+	botMetadataService := services.NewBotMetadataService(store.BotMetadata)
+
+	//	Create bot
+	defaultHandler := handlers.NewDefaultHandler(languages)
+	botOptions := poolBot.CreateBotOptions(
+		o.mode,
+		blockchainsService,
+		userService,
+		userActionService,
+		userWalletService,
+		languages,
+		defaultHandler,
+		config,
+	)
+	b, err := poolBot.CreateBot(append(botOptions, o.botOptions...), config.BotToken)
+	if err != nil {
+		blockchainsService.Close()
+
+		return nil, err
+	}
+
+	//	Create notify service
+	notifyService := botNotify.NewService(
+		store.Notify,
+		store.Wallets,
+		store.ChatTargets,
+		blockchainsService,
+		b,
+		privacyService,
+		languages,
+		&config.Notify,
+	)
+
+	//	Create broadcasts service
+	broadcastsService := broadcasts.NewService(store.Broadcasts, b, privacyService, &config.Broadcast)
+
+	poolBotHandlerMatcher := poolBot.NewHandlerMatcher(ctx, userActionService, supportService)
+	poolBot.RegisterHandlers(
+		b,
+		poolBotHandlerMatcher,
+		defaultHandler,
+		userActionService,
+		userWalletService,
+		supportService,
+		chatTargetService,
+		walletInviteService,
+		walletLookupService,
+		walletImportService,
+		adminService,
+		privacyService,
+		blockchainsService,
+		notifyService,
+		broadcastsService,
+		o.errorsBuffer,
+		languages,
+		config,
+	)
+
+	if err := poolBot.SyncBotMetadata(ctx, b, botMetadataService, adminService, languages); err != nil {
+		zap.L().Warn("failed to sync bot metadata", zap.Error(err))
+	}
+
+	return &App{
+		Bot:         b,
+		Blockchains: blockchainsService,
+		Notify:      notifyService,
+		Broadcasts:  broadcastsService,
+		Privacy:     privacyService,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"github.com/go-telegram/bot"
+	"github.com/grandminingpool/telegram-bot/internal/common/flags"
+	"github.com/grandminingpool/telegram-bot/internal/common/logger"
+	"google.golang.org/grpc"
+)
+
+type options struct {
+	mode         flags.AppMode
+	certsPath    string
+	errorsBuffer *logger.ErrorsBuffer
+	botOptions   []bot.Option
+	dialOptions  []grpc.DialOption
+}
+
+type Option func(o *options)
+
+func WithMode(mode flags.AppMode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+func WithCertsPath(certsPath string) Option {
+	return func(o *options) {
+		o.certsPath = certsPath
+	}
+}
+
+func WithErrorsBuffer(errorsBuffer *logger.ErrorsBuffer) Option {
+	return func(o *options) {
+		o.errorsBuffer = errorsBuffer
+	}
+}
+
+func WithServerURL(serverURL string) Option {
+	return func(o *options) {
+		o.botOptions = append(o.botOptions, bot.WithServerURL(serverURL))
+	}
+}
+
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
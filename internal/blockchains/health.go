@@ -0,0 +1,273 @@
+package blockchains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	poolProto "github.com/grandminingpool/pool-api-proto/generated/pool"
+	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
+	"github.com/grandminingpool/telegram-bot/internal/common/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthProto "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const HEALTH_CHECK_CTX_KEY types.CtxKey = "poolHealthCheck"
+
+var ErrPoolUnavailable = errors.New("pool temporarily unavailable")
+
+type PoolUnavailableError struct {
+	Coin string
+}
+
+func (e *PoolUnavailableError) Error() string {
+	return fmt.Sprintf("blockchain (coin: %s) %s", e.Coin, ErrPoolUnavailable.Error())
+}
+
+func (e *PoolUnavailableError) Unwrap() error {
+	return ErrPoolUnavailable
+}
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	state            CircuitState
+	failures         int
+	failureThreshold int
+	openTimeout      time.Duration
+	openedAt         time.Time
+	probing          bool
+}
+
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.openTimeout {
+			return false
+		}
+
+		//	Open timeout elapsed, let a single probe call through
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+
+		return true
+	case CircuitHalfOpen:
+		if cb.probing {
+			return false
+		}
+
+		cb.probing = true
+
+		return true
+	default:
+		return true
+	}
+}
+
+func (cb *CircuitBreaker) Success() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = CircuitClosed
+	cb.failures = 0
+	cb.probing = false
+}
+
+func (cb *CircuitBreaker) Failure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	cb.probing = false
+
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.failureThreshold {
+		cb.state = CircuitOpen
+		cb.openedAt = time.Now()
+	}
+}
+
+func (cb *CircuitBreaker) Release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probing = false
+}
+
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
+
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return &CircuitBreaker{
+		state:            CircuitClosed,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+type Health struct {
+	coin    string
+	breaker *CircuitBreaker
+	config  *botConfig.PoolAPIConfig
+}
+
+func isPoolFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *Health) IsAvailable() bool {
+	return h.breaker.State() != CircuitOpen
+}
+
+func (h *Health) State() CircuitState {
+	return h.breaker.State()
+}
+
+func (h *Health) record(err error) {
+	switch {
+	case err == nil:
+		h.breaker.Success()
+	case isPoolFailure(err):
+		h.breaker.Failure()
+	case status.Code(err) == codes.Canceled:
+		h.breaker.Release()
+	default:
+		//	Application level errors mean that pool is reachable
+		h.breaker.Success()
+	}
+}
+
+func (h *Health) UnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	//	Health checks are recorded by check, because serving status is known only from response
+	if _, isHealthCheck := ctx.Value(HEALTH_CHECK_CTX_KEY).(bool); isHealthCheck {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	if !h.breaker.Allow() {
+		return &PoolUnavailableError{Coin: h.coin}
+	}
+
+	timeout := h.config.CallTimeoutDuration()
+	if deadline, ok := ctx.Deadline(); timeout > 0 && (!ok || time.Until(deadline) > timeout) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	h.record(err)
+
+	return err
+}
+
+func (h *Health) check(ctx context.Context, conn *grpc.ClientConn) error {
+	checkCtx, cancel := context.WithTimeout(context.WithValue(ctx, HEALTH_CHECK_CTX_KEY, true), h.config.HealthCheckTimeoutDuration())
+	defer cancel()
+
+	response, err := healthProto.NewHealthClient(conn).Check(checkCtx, &healthProto.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		//	Pool API doesn't implement gRPC health protocol, fallback to the cheapest pool RPC
+		_, err = poolProto.NewPoolServiceClient(conn).GetPoolInfo(checkCtx, &emptypb.Empty{})
+		h.record(err)
+
+		return err
+	} else if err != nil {
+		h.record(err)
+
+		return err
+	}
+
+	if response.Status != healthProto.HealthCheckResponse_SERVING {
+		h.breaker.Failure()
+
+		return fmt.Errorf("pool api health status: %s", response.Status.String())
+	}
+
+	h.breaker.Success()
+
+	return nil
+}
+
+func (h *Health) Monitor(ctx context.Context, conn *grpc.ClientConn, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	interval := h.config.HealthCheckIntervalDuration()
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			prevState := h.breaker.State()
+			err := h.check(ctx, conn)
+			if newState := h.breaker.State(); newState != prevState {
+				zap.L().Warn("blockchain pool api circuit state changed",
+					zap.String("coin", h.coin),
+					zap.String("prev_state", prevState.String()),
+					zap.String("state", newState.String()),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+func NewHealth(coin string, config *botConfig.PoolAPIConfig) *Health {
+	return &Health{
+		coin:    coin,
+		breaker: NewCircuitBreaker(config.FailureThreshold, config.OpenTimeoutDuration()),
+		config:  config,
+	}
+}
@@ -0,0 +1,82 @@
+package blockchains
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthProto "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const BUFCONN_SIZE = 1024 * 1024
+
+func newHealthConn(t *testing.T, h *Health, status healthProto.HealthCheckResponse_ServingStatus) (*grpc.ClientConn, *health.Server) {
+	t.Helper()
+
+	listener := bufconn.Listen(BUFCONN_SIZE)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", status)
+	healthProto.RegisterHealthServer(server, healthServer)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("passthrough:///pool",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithUnaryInterceptor(h.UnaryClientInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial pool: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return conn, healthServer
+}
+
+func TestHealthCheckOpensCircuit(t *testing.T) {
+	ctx := context.Background()
+	config := &botConfig.PoolAPIConfig{
+		CallTimeout:        5,
+		HealthCheckTimeout: 3,
+		FailureThreshold:   3,
+		OpenTimeout:        30,
+	}
+	h := NewHealth("btc", config)
+	conn, healthServer := newHealthConn(t, h, healthProto.HealthCheckResponse_NOT_SERVING)
+
+	for i := 1; i <= config.FailureThreshold; i++ {
+		if err := h.check(ctx, conn); err == nil {
+			t.Fatalf("expected error of not serving pool on check %d", i)
+		}
+
+		//	Circuit stays closed until failures reach threshold
+		if expected := i < config.FailureThreshold; h.IsAvailable() != expected {
+			t.Fatalf("expected pool availability %t after %d failed checks, got state %s", expected, i, h.State())
+		}
+	}
+
+	if h.State() != CircuitOpen {
+		t.Fatalf("expected open circuit, got %s", h.State())
+	}
+
+	healthServer.SetServingStatus("", healthProto.HealthCheckResponse_SERVING)
+	if err := h.check(ctx, conn); err != nil {
+		t.Fatalf("expected serving pool to pass check, got %v", err)
+	}
+
+	if h.State() != CircuitClosed {
+		t.Fatalf("expected closed circuit after successful check, got %s", h.State())
+	}
+}
@@ -2,55 +2,81 @@ package blockchains
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"sync"
 
+	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
 	poolAPIClient "github.com/grandminingpool/telegram-bot/internal/clients/pool_api"
-	"github.com/jmoiron/sqlx"
+	"github.com/grandminingpool/telegram-bot/internal/common/flags"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/grandminingpool/telegram-bot/internal/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
-type PoolAPIDB struct {
-	URL        string `db:"pool_api_url"`
-	TLSCA      string `db:"pool_api_tls_ca"`
-	ServerName string `db:"pool_api_server_name"`
+func clientConfig(p *storage.PoolAPIDB, certsPath string) *poolAPIClient.Config {
+	config := &poolAPIClient.Config{
+		Address:    p.URL,
+		CertsPath:  certsPath,
+		TLSCA:      p.TLSCA,
+		ServerName: p.ServerName,
+		Insecure:   p.Insecure,
+	}
+
+	if p.TLSCert != nil {
+		config.TLSCert = *p.TLSCert
+	}
+
+	if p.TLSKey != nil {
+		config.TLSKey = *p.TLSKey
+	}
+
+	if p.Token != nil {
+		config.Token = *p.Token
+	}
+
+	return config
 }
 
-type BlockchainDB struct {
-	Coin          string `db:"coin"`
-	Name          string `db:"name"`
-	Ticker        string `db:"ticker"`
-	AtomicUnit    uint16 `db:"atomic_unit"`
-	ExampleWallet string `db:"example_wallet"`
-	PoolAPIDB
+func displayPrecision(b *storage.BlockchainDB) uint8 {
+	//	Amounts are shown with all decimals unless precision is set for the coin
+	if b.DisplayPrecision == nil || *b.DisplayPrecision > b.Decimals {
+		return b.Decimals
+	}
+
+	return *b.DisplayPrecision
 }
 
 type BlockchainInfo struct {
-	ID            int16
-	Coin          string
-	Name          string
-	Ticker        string
-	AtomicUnit    uint16
-	ExampleWallet string
+	ID               int16
+	Coin             string
+	Name             string
+	Ticker           string
+	Decimals         uint8
+	DisplayPrecision uint8
+	ExampleWallet    string
 }
 
 type Blockchain struct {
-	info *BlockchainInfo
-	conn *grpc.ClientConn
+	info   *BlockchainInfo
+	client *poolAPIClient.Client
+	health *Health
 }
 
-type Service struct {
-	pgConn      *sqlx.DB
-	blockchains map[string]Blockchain
+type PoolState struct {
+	Coin       string `json:"coin"`
+	Connection string `json:"connection"`
+	Circuit    string `json:"circuit"`
+	Available  bool   `json:"available"`
 }
 
-func (s *Service) getBlockchainsFromDB(ctx context.Context) ([]BlockchainDB, error) {
-	blockchains := []BlockchainDB{}
-	if err := s.pgConn.SelectContext(ctx, &blockchains, "SELECT * FROM blockchains"); err != nil && err != sql.ErrNoRows {
-		return nil, fmt.Errorf("failed to query blockchains: %w", err)
-	}
-
-	return blockchains, nil
+type Service struct {
+	repo           storage.BlockchainRepository
+	config         *botConfig.PoolAPIConfig
+	blockchains    map[string]Blockchain
+	monitorsCancel context.CancelFunc
+	monitorsWg     sync.WaitGroup
 }
 
 func (s *Service) GetBlockchainsInfo() []BlockchainInfo {
@@ -78,17 +104,61 @@ func (s *Service) GetConnection(coin string) (*grpc.ClientConn, error) {
 		return nil, fmt.Errorf("failed to get blockchain (coin: %s) poll connection: not found", coin)
 	}
 
-	return blockchain.conn, nil
+	return blockchain.client.Conn(), nil
+}
+
+func (s *Service) GetHealth(coin string) (*Health, error) {
+	blockchain, ok := s.blockchains[coin]
+	if !ok {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) pool health: not found", coin)
+	}
+
+	return blockchain.health, nil
+}
+
+func (s *Service) IsAvailable(coin string) bool {
+	blockchain, ok := s.blockchains[coin]
+
+	return ok && blockchain.health.IsAvailable()
+}
+
+func (s *Service) GetPoolStates() []PoolState {
+	states := make([]PoolState, 0, len(s.blockchains))
+	for coin, b := range s.blockchains {
+		connState := b.client.Conn().GetState()
+		states = append(states, PoolState{
+			Coin:       coin,
+			Connection: connState.String(),
+			Circuit:    b.health.State().String(),
+			Available:  b.health.IsAvailable() && connState != connectivity.TransientFailure && connState != connectivity.Shutdown,
+		})
+	}
+
+	return states
 }
 
-func (s *Service) Start(ctx context.Context, certsPath string) error {
-	blockchains, err := s.getBlockchainsFromDB(ctx)
+func (s *Service) Start(ctx context.Context, certsPath string, appMode flags.AppMode, opts ...grpc.DialOption) error {
+	blockchains, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return err
 	}
 
+	monitorsCtx, cancel := context.WithCancel(ctx)
+	s.monitorsCancel = cancel
+
 	for _, b := range blockchains {
-		conn, err := poolAPIClient.NewClient(b.PoolAPIDB.URL, certsPath, b.PoolAPIDB.TLSCA, b.PoolAPIDB.ServerName)
+		health := NewHealth(b.Coin, s.config)
+		client, err := poolAPIClient.NewClient(
+			clientConfig(&b.PoolAPIDB, certsPath),
+			appMode,
+			append([]grpc.DialOption{
+				grpc.WithChainUnaryInterceptor(
+					tracing.UnaryClientInterceptor(b.Coin),
+					metrics.UnaryClientInterceptor(b.Coin),
+					health.UnaryClientInterceptor,
+				),
+			}, opts...)...,
+		)
 		if err != nil {
 			s.Close()
 
@@ -97,30 +167,42 @@ func (s *Service) Start(ctx context.Context, certsPath string) error {
 
 		s.blockchains[b.Coin] = Blockchain{
 			info: &BlockchainInfo{
-				Coin:          b.Coin,
-				Name:          b.Name,
-				Ticker:        b.Ticker,
-				AtomicUnit:    b.AtomicUnit,
-				ExampleWallet: b.ExampleWallet,
+				Coin:             b.Coin,
+				Name:             b.Name,
+				Ticker:           b.Ticker,
+				Decimals:         b.Decimals,
+				DisplayPrecision: displayPrecision(&b),
+				ExampleWallet:    b.ExampleWallet,
 			},
-			conn: conn,
+			client: client,
+			health: health,
 		}
+
+		s.monitorsWg.Add(1)
+		go health.Monitor(monitorsCtx, client.Conn(), &s.monitorsWg)
 	}
 
 	return nil
 }
 
 func (s *Service) Close() {
+	if s.monitorsCancel != nil {
+		s.monitorsCancel()
+		s.monitorsWg.Wait()
+		s.monitorsCancel = nil
+	}
+
 	for _, b := range s.blockchains {
-		b.conn.Close()
+		b.client.Close()
 	}
 
 	clear(s.blockchains)
 }
 
-func NewService(pgConn *sqlx.DB) *Service {
+func NewService(repo storage.BlockchainRepository, config *botConfig.PoolAPIConfig) *Service {
 	return &Service{
-		pgConn:      pgConn,
+		repo:        repo,
+		config:      config,
 		blockchains: make(map[string]Blockchain),
 	}
 }
@@ -51,11 +51,18 @@ func CreateBotOptions(
 		removeWalletHandler.OnBlockchainSelected,
 		botKeyboards.WithStartKeyboardHandler(removeWalletHandler.Back),
 	)
+	tracingMiddleware := middlewares.CreateTracingMiddleware()
+	metricsMiddleware := middlewares.CreateMetricsMiddleware()
 	userMiddleware := middlewares.CreateUserMiddleware(userService, userActionService, languages)
 	keyboardsMiddleware := keyboardsMiddlewares.CreateKeyboardsMiddleware(addWalletKeyboard, startKeyboard)
 
 	options := []bot.Option{
-		bot.WithDefaultHandler(middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(defaultHandler.Handler))),
+		bot.WithDefaultHandler(middlewares.WithHandlerName(
+			"default",
+			middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(defaultHandler.Handler)),
+		)),
+		bot.WithMiddlewares(tracingMiddleware.Middleware),
+		bot.WithMiddlewares(metricsMiddleware.Middleware),
 		bot.WithMiddlewares(userMiddleware.Middleware),
 		bot.WithMiddlewares(keyboardsMiddleware.Middleware),
 		bot.WithErrorsHandler(handlers.ErrorsHandler),
@@ -2,6 +2,7 @@ package poolBot
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -11,16 +12,23 @@ import (
 	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
 	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
 	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/broadcasts"
 	"github.com/grandminingpool/telegram-bot/internal/common/constants"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/common/logger"
+	botNotify "github.com/grandminingpool/telegram-bot/internal/notify"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
 	"go.uber.org/zap"
 )
 
 type HandlerMatcher struct {
 	userActionService *services.UserActionService
+	supportService    *services.SupportService
 	serviceCtx        context.Context
 }
 
-func (m *HandlerMatcher) MatchUserAction(action services.UserAction) bot.MatchFunc {
+func (m *HandlerMatcher) MatchUserAction(action storage.UserAction) bot.MatchFunc {
 	return func(update *models.Update) bool {
 		if update.Message != nil {
 			userAction, err := m.userActionService.Get(m.serviceCtx, update.Message.From.ID)
@@ -39,12 +47,93 @@ func (m *HandlerMatcher) MatchUserAction(action services.UserAction) bot.MatchFu
 	}
 }
 
+func (m *HandlerMatcher) MatchCommand(command constants.BotCommand) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		if update.Message == nil {
+			return false
+		}
+
+		fields := strings.Fields(update.Message.Text)
+		if len(fields) == 0 {
+			return false
+		}
+
+		//	In groups commands can be addressed to bot as /command@bot_username
+		name, _, _ := strings.Cut(fields[0], "@")
+
+		return name == string(command)
+	}
+}
+
+func (m *HandlerMatcher) MatchInlineQuery() bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.InlineQuery != nil
+	}
+}
+
+func (m *HandlerMatcher) MatchMyChatMember() bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.MyChatMember != nil
+	}
+}
+
+func (m *HandlerMatcher) MatchSupportReply(supportChatID int64) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.Message != nil &&
+			update.Message.Chat.ID == supportChatID &&
+			update.Message.ReplyToMessage != nil
+	}
+}
+
+func (m *HandlerMatcher) MatchTicketFollowUp(supportChatID int64) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		if update.Message == nil ||
+			update.Message.Chat.ID == supportChatID ||
+			update.Message.ReplyToMessage == nil ||
+			update.Message.ReplyToMessage.From == nil ||
+			!update.Message.ReplyToMessage.From.IsBot ||
+			strings.HasPrefix(update.Message.Text, "/") {
+			return false
+		}
+
+		//	Reply to any bot message while user enters data belongs to handler of user action
+		userID := update.Message.From.ID
+		userAction, err := m.userActionService.Get(m.serviceCtx, userID)
+		if err != nil {
+			zap.L().Error("get user action error while match ticket follow up",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+
+			return false
+		}
+
+		if userAction != nil {
+			return false
+		}
+
+		ticket, err := m.supportService.FindByUserMessage(m.serviceCtx, userID, int64(update.Message.ReplyToMessage.ID))
+		if err != nil {
+			zap.L().Error("find support ticket error while match ticket follow up",
+				zap.Int64("user_id", userID),
+				zap.Error(err),
+			)
+
+			return false
+		}
+
+		return ticket != nil
+	}
+}
+
 func NewHandlerMatcher(
 	ctx context.Context,
 	userActionService *services.UserActionService,
+	supportService *services.SupportService,
 ) *HandlerMatcher {
 	return &HandlerMatcher{
 		userActionService: userActionService,
+		supportService:    supportService,
 		serviceCtx:        ctx,
 	}
 }
@@ -55,47 +144,194 @@ func RegisterHandlers(
 	defaultHandler *handlers.DefaultHandler,
 	userActionService *services.UserActionService,
 	userWalletService *services.UserWalletService,
-	feedbackService *services.FeedbackService,
+	supportService *services.SupportService,
+	chatTargetService *services.ChatTargetService,
+	walletInviteService *services.WalletInviteService,
+	walletLookupService *services.WalletLookupService,
+	walletImportService *services.WalletImportService,
+	adminService *services.AdminService,
+	privacyService *privacy.Service,
 	blockchainsService *blockchains.Service,
+	notifyService *botNotify.Service,
+	broadcastsService *broadcasts.Service,
+	errorsBuffer *logger.ErrorsBuffer,
+	languages *languages.Languages,
 	config *botConfig.Config,
 ) {
 	//	init handlers
 	faqHandler := handlers.NewFAQHandler(config.PoolURL, config.Notify.CheckIntervals.Workers, config.SupportBot.Username)
-	reportBugHandler := handlers.NewReportBugHandler(feedbackService, userActionService, config.SupportBot.Username)
+	reportBugHandler := handlers.NewReportBugHandler(userActionService)
+	supportHandler := handlers.NewSupportHandler(
+		supportService,
+		userActionService,
+		languages,
+		config.SupportBot.SupportChatID(),
+		config.SupportBot.Username,
+	)
 	addWalletHandler := handlers.NewAddWalletHandler(
 		userActionService,
 		userWalletService,
 		blockchainsService,
 		config.Notify.CheckIntervals.Workers,
+		config.WalletsLimitPerUser,
+	)
+	chatTargetsHandler := handlers.NewChatTargetsHandler(chatTargetService)
+	walletInviteHandler := handlers.NewWalletInviteHandler(walletInviteService, languages)
+	addWalletLinkHandler := handlers.NewAddWalletLinkHandler(
+		userWalletService,
+		blockchainsService,
+		config.DeepLink.Secret,
+		config.Notify.CheckIntervals.Workers,
+		config.WalletsLimitPerUser,
+	)
+	manageWalletHandler := handlers.NewManageWalletHandler(userWalletService, userActionService, blockchainsService)
+	walletImportHandler := handlers.NewWalletImportHandler(
+		walletImportService,
+		userActionService,
+		config.WalletImport.MaxLines,
+		config.WalletImport.MaxFileSize,
+	)
+	userDataHandler := handlers.NewUserDataHandler(privacyService)
+	chatMemberHandler := handlers.NewChatMemberHandler(privacyService)
+	inlineQueryHandler := handlers.NewInlineQueryHandler(
+		walletLookupService,
+		languages,
+		config.InlineQuery.CacheTimeDuration(),
+		config.InlineQuery.RateLimitIntervalDuration(),
+	)
+	adminHandler := handlers.NewAdminHandler(adminService, blockchainsService, notifyService, errorsBuffer)
+
+	//	init middlewares
+	adminMiddleware := middlewares.CreateAdminMiddleware(adminService)
+
+	broadcastHandler := handlers.NewBroadcastHandler(
+		broadcastsService,
+		userActionService,
+		blockchainsService,
+		languages,
+		adminMiddleware,
 	)
 
+	//	deep link handlers
+	defaultHandler.RegisterDeepLink(handlers.JOIN_WALLET_DEEP_LINK, walletInviteHandler.Join)
+	defaultHandler.RegisterDeepLink(handlers.ADD_WALLET_DEEP_LINK, addWalletLinkHandler.Handler)
+
 	//	command handlers
-	b.RegisterHandler(
-		bot.HandlerTypeMessageText,
-		string(constants.StartCommand),
-		bot.MatchTypeExact,
-		middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(defaultHandler.Handler)),
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.StartCommand),
+		middlewares.WithHandlerName("start", middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(defaultHandler.Handler))),
 	)
 	b.RegisterHandler(
 		bot.HandlerTypeMessageText,
 		string(constants.FAQCommand),
 		bot.MatchTypeExact,
-		middlewares.WithUserHandler(faqHandler.Handler),
+		middlewares.WithHandlerName("faq", middlewares.WithUserHandler(faqHandler.Handler)),
 	)
 	b.RegisterHandler(
 		bot.HandlerTypeMessageText,
 		string(constants.ReportBugCommand),
 		bot.MatchTypeExact,
-		middlewares.WithUserHandler(reportBugHandler.Enter),
+		middlewares.WithHandlerName("report_bug", middlewares.WithUserHandler(reportBugHandler.Enter)),
+	)
+
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.LinkChatCommand),
+		middlewares.WithHandlerName("link_chat", middlewares.WithChatUserHandler(chatTargetsHandler.Link)),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.InviteCommand),
+		middlewares.WithHandlerName("invite", middlewares.WithUserHandler(walletInviteHandler.Invite)),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.ManageWalletsCommand),
+		middlewares.WithHandlerName("manage_wallets", middlewares.WithUserHandler(manageWalletHandler.List)),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.ImportWalletsCommand),
+		middlewares.WithHandlerName("import_wallets", middlewares.WithUserHandler(walletImportHandler.Start)),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.ExportWalletsCommand),
+		middlewares.WithHandlerName("export_wallets", middlewares.WithUserHandler(walletImportHandler.Export)),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.MyDataCommand),
+		middlewares.WithHandlerName("my_data", middlewares.WithUserHandler(userDataHandler.Export)),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.DeleteMeCommand),
+		middlewares.WithHandlerName("delete_me", middlewares.WithUserHandler(userDataHandler.Delete)),
 	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchCommand(constants.ChatsCommand),
+		middlewares.WithHandlerName("chats", middlewares.WithUserHandler(chatTargetsHandler.List)),
+	)
+
+	//	admin command handlers
+	adminCommands := []struct {
+		command constants.BotCommand
+		name    string
+		handler middlewares.UserHandlerFunc
+	}{
+		{constants.AdminStatsCommand, "admin_stats", adminHandler.Stats},
+		{constants.AdminUserCommand, "admin_user", adminHandler.LookupUser},
+		{constants.AdminRunJobCommand, "admin_run_job", adminHandler.RunJob},
+		{constants.AdminPauseCommand, "admin_pause", adminHandler.Pause},
+		{constants.AdminResumeCommand, "admin_resume", adminHandler.Resume},
+		{constants.AdminErrorsCommand, "admin_errors", adminHandler.RecentErrors},
+		{constants.BroadcastCommand, "admin_broadcast", broadcastHandler.Start},
+		{constants.BroadcastStatusCommand, "admin_broadcast_status", broadcastHandler.Status},
+	}
+	for _, ac := range adminCommands {
+		b.RegisterHandlerMatchFunc(
+			hm.MatchCommand(ac.command),
+			middlewares.WithHandlerName(ac.name, middlewares.WithUserHandler(adminMiddleware.Handler(ac.handler))),
+		)
+	}
 
 	//	match handlers
 	b.RegisterHandlerMatchFunc(
-		hm.MatchUserAction(services.UserAddWalletAction),
-		middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(addWalletHandler.Handler)),
+		hm.MatchUserAction(storage.UserAddWalletAction),
+		middlewares.WithHandlerName("add_wallet", middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(addWalletHandler.Handler))),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchUserAction(storage.RenameWalletAction),
+		middlewares.WithHandlerName("rename_wallet", middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(manageWalletHandler.Rename))),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchUserAction(storage.AliasWorkerAction),
+		middlewares.WithHandlerName("alias_worker", middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(manageWalletHandler.AliasWorker))),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchUserAction(storage.ImportWalletsAction),
+		middlewares.WithHandlerName("import_wallets_data", middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(walletImportHandler.Handler))),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchUserAction(storage.ReportBugAction),
+		middlewares.WithHandlerName("send_feedback", middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(supportHandler.CreateTicket))),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchTicketFollowUp(config.SupportBot.SupportChatID()),
+		middlewares.WithHandlerName("ticket_follow_up", middlewares.WithUserHandler(supportHandler.FollowUp)),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchInlineQuery(),
+		middlewares.WithHandlerName("inline_query", inlineQueryHandler.Handler),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchMyChatMember(),
+		middlewares.WithHandlerName("my_chat_member", chatMemberHandler.Handler),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchSupportReply(config.SupportBot.SupportChatID()),
+		middlewares.WithHandlerName("support_reply", supportHandler.Reply),
+	)
+	b.RegisterHandlerMatchFunc(
+		hm.MatchUserAction(storage.BroadcastTextAction),
+		middlewares.WithHandlerName("admin_broadcast_text", middlewares.WithUserHandler(adminMiddleware.Handler(broadcastHandler.EnterText))),
 	)
 	b.RegisterHandlerMatchFunc(
-		hm.MatchUserAction(services.ReportBugAction),
-		middlewares.WithUserHandler(botKeyboards.WithStartKeyboardHandler(reportBugHandler.SendFeedback)),
+		hm.MatchUserAction(storage.BroadcastTargetAction),
+		middlewares.WithHandlerName("admin_broadcast_target", middlewares.WithUserHandler(adminMiddleware.Handler(broadcastHandler.Target))),
 	)
 }
@@ -2,11 +2,10 @@ package handlers
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
-	poolMinersProto "github.com/grandminingpool/pool-api-proto/generated/pool_miners"
 	"github.com/grandminingpool/telegram-bot/internal/blockchains"
 	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
 	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
@@ -37,23 +36,21 @@ func (h *AddWalletHandler) Handler(ctx context.Context, user *middlewares.User,
 			return
 		}
 
-		conn, err := h.blockchainsService.GetConnection(coin)
-		if err != nil {
-			zap.L().Error("get blockchain pool connection error",
-				zap.Int64("user_id", user.ID),
-				zap.String("coin", coin),
-				zap.Error(err),
-			)
+		wallet := update.Message.Text
+		valid, err := h.userWalletService.ValidateAddress(ctx, coin, wallet)
+		if errors.Is(err, blockchains.ErrPoolUnavailable) {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: update.Message.Chat.ID,
+				Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "PoolTemporarilyUnavailable",
+					TemplateData: map[string]string{
+						"PoolBlockchainName": blockchain.Name,
+					},
+				}),
+			})
 
 			return
-		}
-
-		wallet := update.Message.Text
-		client := poolMinersProto.NewPoolMinersServiceClient(conn)
-		response, err := client.ValidateAddress(ctx, &poolMinersProto.MinerAddressRequest{
-			Address: wallet,
-		})
-		if err != nil {
+		} else if err != nil {
 			zap.L().Error("wallet address validation error",
 				zap.Int64("user_id", user.ID),
 				zap.String("coin", coin),
@@ -64,7 +61,7 @@ func (h *AddWalletHandler) Handler(ctx context.Context, user *middlewares.User,
 			return
 		}
 
-		if !response.Valid {
+		if !valid {
 			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: update.Message.Chat.ID,
 				Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
@@ -143,7 +140,7 @@ func (h *AddWalletHandler) Handler(ctx context.Context, user *middlewares.User,
 				Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 					MessageID: "WalletAdded",
 					TemplateData: map[string]string{
-						"CheckWorkersInterval": fmt.Sprintf("%d", h.checkWorkersInterval),
+						"CheckWorkersInterval": user.Formatter.Minutes(h.checkWorkersInterval),
 					},
 				}),
 				ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
@@ -157,11 +154,13 @@ func NewAddWalletHandler(
 	userWalletService *services.UserWalletService,
 	blockchainsService *blockchains.Service,
 	checkWorkersInterval int,
+	walletsLimitPerUser int,
 ) *AddWalletHandler {
 	return &AddWalletHandler{
 		userActionService:    userActionService,
 		userWalletService:    userWalletService,
 		blockchainsService:   blockchainsService,
 		checkWorkersInterval: checkWorkersInterval,
+		walletsLimitPerUser:  walletsLimitPerUser,
 	}
 }
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/go-telegram/ui/keyboard/inline"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	deeplinkUtils "github.com/grandminingpool/telegram-bot/internal/utils/deeplink"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const ADD_WALLET_DEEP_LINK = "add_"
+
+type AddWalletLinkHandler struct {
+	userWalletService    *services.UserWalletService
+	blockchainsService   *blockchains.Service
+	secret               string
+	checkWorkersInterval int
+	walletsLimitPerUser  int
+}
+
+func (h *AddWalletLinkHandler) sendText(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	text string,
+	b *bot.Bot,
+) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      user.ChatID,
+		Text:        text,
+		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+	})
+}
+
+func (h *AddWalletLinkHandler) Handler(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	value string,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	payload, err := deeplinkUtils.DecodeWallet(value, h.secret)
+	if err != nil {
+		zap.L().Warn("invalid add wallet deep link",
+			zap.Int64("user_id", user.ID),
+			zap.String("payload", value),
+			zap.Error(err),
+		)
+
+		h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AddWalletLinkInvalid",
+		}), b)
+
+		return
+	}
+
+	blockchain, err := h.blockchainsService.GetInfo(payload.Coin)
+	if err != nil {
+		h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AddWalletLinkInvalid",
+		}), b)
+
+		return
+	}
+
+	valid, err := h.userWalletService.ValidateAddress(ctx, blockchain.Coin, payload.Wallet)
+	if errors.Is(err, blockchains.ErrPoolUnavailable) {
+		h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "PoolTemporarilyUnavailable",
+			TemplateData: map[string]string{
+				"PoolBlockchainName": blockchain.Name,
+			},
+		}), b)
+
+		return
+	} else if err != nil {
+		zap.L().Error("wallet address validation error",
+			zap.Int64("user_id", user.ID),
+			zap.String("coin", blockchain.Coin),
+			zap.String("wallet", payload.Wallet),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if !valid {
+		h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "InvalidWallet",
+		}), b)
+
+		return
+	}
+
+	hasDuplicates, err := h.userWalletService.CheckDuplicates(ctx, user.ID, blockchain.Coin, payload.Wallet)
+	if err != nil {
+		zap.L().Error("check user wallet duplicates error",
+			zap.Int64("user_id", user.ID),
+			zap.String("coin", blockchain.Coin),
+			zap.String("wallet", payload.Wallet),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if hasDuplicates {
+		h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "WalletAlreadyAdded",
+		}), b)
+
+		return
+	}
+
+	//	Link may be opened by anyone, so wallet is added only after explicit confirmation
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AddWalletLinkConfirm",
+			TemplateData: map[string]string{
+				"Wallet":             payload.Wallet,
+				"PoolBlockchainName": blockchain.Name,
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateConfirmInlineKeyboard(
+			b,
+			h.onConfirm(user, startKeyboard, blockchain, payload.Wallet),
+			h.onCancel(user, startKeyboard),
+			user.Localizer,
+		),
+	})
+}
+
+func (h *AddWalletLinkHandler) onConfirm(
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	blockchain *blockchains.BlockchainInfo,
+	wallet string,
+) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		walletsCount, err := h.userWalletService.Count(ctx, user.ID, blockchain.Coin)
+		if err != nil {
+			zap.L().Error("count user wallets error",
+				zap.Int64("user_id", user.ID),
+				zap.String("coin", blockchain.Coin),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		if walletsCount+1 > h.walletsLimitPerUser {
+			h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "ExceededWalletsLimit",
+			}), b)
+
+			return
+		}
+
+		if err := h.userWalletService.Add(ctx, user.ID, blockchain.Coin, wallet); err != nil {
+			zap.L().Error("add user wallet error",
+				zap.Int64("user_id", user.ID),
+				zap.String("coin", blockchain.Coin),
+				zap.String("wallet", wallet),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "WalletAdded",
+			TemplateData: map[string]string{
+				"CheckWorkersInterval": user.Formatter.Minutes(h.checkWorkersInterval),
+			},
+		}), b)
+	}
+}
+
+func (h *AddWalletLinkHandler) onCancel(user *middlewares.User, startKeyboard *botKeyboards.StartKeyboard) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		h.sendText(ctx, user, startKeyboard, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ReturningToMenu",
+		}), b)
+	}
+}
+
+func NewAddWalletLinkHandler(
+	userWalletService *services.UserWalletService,
+	blockchainsService *blockchains.Service,
+	secret string,
+	checkWorkersInterval int,
+	walletsLimitPerUser int,
+) *AddWalletLinkHandler {
+	return &AddWalletLinkHandler{
+		userWalletService:    userWalletService,
+		blockchainsService:   blockchainsService,
+		secret:               secret,
+		checkWorkersInterval: checkWorkersInterval,
+		walletsLimitPerUser:  walletsLimitPerUser,
+	}
+}
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/common/logger"
+	botNotify "github.com/grandminingpool/telegram-bot/internal/notify"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	DEFAULT_RECENT_ERRORS_COUNT = 10
+	MAX_MESSAGE_LENGTH          = 4096
+)
+
+type AdminHandler struct {
+	adminService       *services.AdminService
+	blockchainsService *blockchains.Service
+	notifyService      *botNotify.Service
+	errorsBuffer       *logger.ErrorsBuffer
+}
+
+func commandArgs(update *models.Update) []string {
+	fields := strings.Fields(update.Message.Text)
+	if len(fields) == 0 {
+		return fields
+	}
+
+	return fields[1:]
+}
+
+func truncateMessage(text string) string {
+	runes := []rune(text)
+	if len(runes) <= MAX_MESSAGE_LENGTH {
+		return text
+	}
+
+	return string(runes[:MAX_MESSAGE_LENGTH-1]) + "…"
+}
+
+func (h *AdminHandler) sendText(ctx context.Context, text string, b *bot.Bot, update *models.Update) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   truncateMessage(text),
+	})
+}
+
+func (h *AdminHandler) emptyText(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminStatsEmpty",
+	})
+}
+
+func (h *AdminHandler) coinCountsText(counts []storage.CoinCountDB, l *i18n.Localizer) string {
+	if len(counts) == 0 {
+		return h.emptyText(l)
+	}
+
+	lines := make([]string, 0, len(counts))
+	for _, count := range counts {
+		lines = append(lines, l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminStatsCoinLine",
+			TemplateData: map[string]string{
+				"Coin":  count.Coin,
+				"Count": formatUtils.NewFormatter(l).Number(count.Count),
+			},
+		}))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (h *AdminHandler) pauseScopeText(scope string, l *i18n.Localizer) string {
+	if scope == "" || scope == botNotify.GLOBAL_PAUSE_SCOPE {
+		return l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminAllBlockchains",
+		})
+	}
+
+	return scope
+}
+
+func (h *AdminHandler) Stats(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	stats, err := h.adminService.GetStats(ctx)
+	if err != nil {
+		zap.L().Error("get bot stats error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	pauses := h.notifyService.Pauses().List()
+	pausedScopes := make([]string, 0, len(pauses))
+	for _, pause := range pauses {
+		pausedScopes = append(pausedScopes, h.pauseScopeText(pause.Scope, user.Localizer))
+	}
+
+	sort.Strings(pausedScopes)
+
+	pausedText := h.emptyText(user.Localizer)
+	if len(pausedScopes) > 0 {
+		pausedText = strings.Join(pausedScopes, ", ")
+	}
+
+	h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminStats",
+		TemplateData: map[string]string{
+			"UsersCount":    user.Formatter.Number(stats.UsersCount),
+			"Wallets":       h.coinCountsText(stats.Wallets, user.Localizer),
+			"ActiveWorkers": h.coinCountsText(stats.ActiveWorkers, user.Localizer),
+			"Paused":        pausedText,
+		},
+	}), b, update)
+}
+
+func (h *AdminHandler) LookupUser(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	args := commandArgs(update)
+	if len(args) == 0 {
+		h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminUserUsage",
+		}), b, update)
+
+		return
+	}
+
+	userInfo, err := h.adminService.FindUser(ctx, args[0])
+	if err != nil {
+		zap.L().Error("admin lookup user error",
+			zap.Int64("user_id", user.ID),
+			zap.String("query", args[0]),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if userInfo == nil {
+		h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminUserNotFound",
+		}), b, update)
+
+		return
+	}
+
+	username := h.emptyText(user.Localizer)
+	if userInfo.User.Username != nil {
+		username = "@" + *userInfo.User.Username
+	}
+
+	var msgBuilder strings.Builder
+	msgBuilder.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminUserInfo",
+		TemplateData: map[string]string{
+			"ID":            strconv.FormatInt(userInfo.User.ID, 10),
+			"Username":      username,
+			"ChatID":        strconv.FormatInt(userInfo.User.ChatID, 10),
+			"Lang":          userInfo.User.Lang,
+			"PayoutsNotify": formatUtils.BoolText(userInfo.User.PayoutsNotify, user.Localizer),
+			"BlocksNotify":  formatUtils.BoolText(userInfo.User.BlocksNotify, user.Localizer),
+			"IsAdmin":       formatUtils.BoolText(userInfo.IsAdmin, user.Localizer),
+		},
+	}))
+
+	for _, wallet := range userInfo.Wallets {
+		msgBuilder.WriteString("\n\n")
+		msgBuilder.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminUserWallet",
+			TemplateData: map[string]string{
+				"Coin":         wallet.Coin,
+				"Wallet":       wallet.Wallet,
+				"Role":         wallet.Role,
+				"WorkersCount": user.Formatter.Number(wallet.WorkersCount),
+				"AddedAt":      user.Formatter.DateTime(wallet.AddedAt),
+			},
+		}))
+	}
+
+	h.sendText(ctx, msgBuilder.String(), b, update)
+}
+
+func (h *AdminHandler) RunJob(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	args := commandArgs(update)
+	if len(args) == 0 {
+		h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminRunJobUsage",
+			TemplateData: map[string]string{
+				"Jobs": strings.Join([]string{botNotify.WORKERS_JOB, botNotify.PAYOUTS_JOB}, "|"),
+			},
+		}), b, update)
+
+		return
+	}
+
+	job := args[0]
+	if err := h.notifyService.RunNow(job); err != nil {
+		zap.L().Warn("admin run notify job error",
+			zap.Int64("user_id", user.ID),
+			zap.String("job", job),
+			zap.Error(err),
+		)
+
+		h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminJobFailed",
+			TemplateData: map[string]string{
+				"Job":   job,
+				"Error": err.Error(),
+			},
+		}), b, update)
+
+		return
+	}
+
+	zap.L().Info("admin started notify job",
+		zap.Int64("user_id", user.ID),
+		zap.String("job", job),
+	)
+
+	h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminJobStarted",
+		TemplateData: map[string]string{
+			"Job": job,
+		},
+	}), b, update)
+}
+
+func (h *AdminHandler) parseCoin(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) (string, bool) {
+	args := commandArgs(update)
+	if len(args) == 0 {
+		return "", true
+	}
+
+	coin := strings.ToLower(args[0])
+	if _, err := h.blockchainsService.GetInfo(coin); err != nil {
+		h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminUnknownBlockchain",
+			TemplateData: map[string]string{
+				"Coin": coin,
+			},
+		}), b, update)
+
+		return "", false
+	}
+
+	return coin, true
+}
+
+func (h *AdminHandler) Pause(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	coin, ok := h.parseCoin(ctx, user, b, update)
+	if !ok {
+		return
+	}
+
+	if err := h.notifyService.Pauses().Pause(ctx, coin, user.ID); err != nil {
+		zap.L().Error("admin pause notifications error",
+			zap.Int64("user_id", user.ID),
+			zap.String("coin", coin),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	zap.L().Info("admin paused notifications",
+		zap.Int64("user_id", user.ID),
+		zap.String("coin", coin),
+	)
+
+	h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminNotificationsPaused",
+		TemplateData: map[string]string{
+			"Scope": h.pauseScopeText(coin, user.Localizer),
+		},
+	}), b, update)
+}
+
+func (h *AdminHandler) Resume(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	coin, ok := h.parseCoin(ctx, user, b, update)
+	if !ok {
+		return
+	}
+
+	if err := h.notifyService.Pauses().Resume(ctx, coin); err != nil {
+		zap.L().Error("admin resume notifications error",
+			zap.Int64("user_id", user.ID),
+			zap.String("coin", coin),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	zap.L().Info("admin resumed notifications",
+		zap.Int64("user_id", user.ID),
+		zap.String("coin", coin),
+	)
+
+	h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminNotificationsResumed",
+		TemplateData: map[string]string{
+			"Scope": h.pauseScopeText(coin, user.Localizer),
+		},
+	}), b, update)
+}
+
+func (h *AdminHandler) RecentErrors(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	count := DEFAULT_RECENT_ERRORS_COUNT
+	if args := commandArgs(update); len(args) > 0 {
+		if parsedCount, err := strconv.Atoi(args[0]); err == nil && parsedCount > 0 {
+			count = parsedCount
+		}
+	}
+
+	entries := h.errorsBuffer.Recent(count)
+	if len(entries) == 0 {
+		h.sendText(ctx, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminNoRecentErrors",
+		}), b, update)
+
+		return
+	}
+
+	items := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "AdminRecentError",
+			TemplateData: map[string]string{
+				"Time":    entry.Time.Format(time.RFC3339),
+				"Message": entry.Message,
+				"Fields":  entry.Fields,
+			},
+		}))
+	}
+
+	h.sendText(ctx, strings.Join(items, "\n\n"), b, update)
+}
+
+func NewAdminHandler(
+	adminService *services.AdminService,
+	blockchainsService *blockchains.Service,
+	notifyService *botNotify.Service,
+	errorsBuffer *logger.ErrorsBuffer,
+) *AdminHandler {
+	return &AdminHandler{
+		adminService:       adminService,
+		blockchainsService: blockchainsService,
+		notifyService:      notifyService,
+		errorsBuffer:       errorsBuffer,
+	}
+}
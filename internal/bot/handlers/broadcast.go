@@ -0,0 +1,495 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/broadcasts"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+)
+
+const BROADCAST_ALL_RECIPIENTS = "all"
+
+type BroadcastHandler struct {
+	broadcastsService  *broadcasts.Service
+	userActionService  *services.UserActionService
+	blockchainsService *blockchains.Service
+	languages          *languages.Languages
+	adminMiddleware    *middlewares.AdminMiddleware
+	keyboard           *botKeyboards.BroadcastKeyboard
+}
+
+func (h *BroadcastHandler) languageName(lang string) string {
+	return h.languages.GetLocalizer(lang).MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "Language",
+	})
+}
+
+func (h *BroadcastHandler) findDraft(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) *storage.Broadcast {
+	draft, err := h.broadcastsService.FindDraft(ctx, user.ID)
+	if err != nil {
+		zap.L().Error("find admin broadcast draft error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return nil
+	}
+
+	if draft == nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "BroadcastDraftNotFound",
+			}),
+		})
+	}
+
+	return draft
+}
+
+func (h *BroadcastHandler) recipientsText(broadcast *storage.Broadcast, l *i18n.Localizer) string {
+	if len(broadcast.Coins) == 0 {
+		return l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastAllUsers",
+		})
+	}
+
+	return strings.Join(broadcast.Coins, ", ")
+}
+
+func (h *BroadcastHandler) promptText(ctx context.Context, user *middlewares.User, lang string, backHandler middlewares.UserHandlerFunc, b *bot.Bot, update *models.Update) {
+	if err := h.userActionService.Set(ctx, user.ID, storage.BroadcastTextAction, &lang); err != nil {
+		zap.L().Error("set user broadcast text action error",
+			zap.Int64("user_id", user.ID),
+			zap.String("lang", lang),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastEnterText",
+			TemplateData: map[string]string{
+				"Language": h.languageName(lang),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateBackReplyKeyboard(b, h.adminMiddleware.Handler(backHandler), user.Localizer),
+	})
+}
+
+func (h *BroadcastHandler) showPreview(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	draft := h.findDraft(ctx, user, b, update)
+	if draft == nil {
+		return
+	}
+
+	recipientsCount, err := h.broadcastsService.CountRecipients(ctx, draft.ID)
+	if err != nil {
+		zap.L().Error("count broadcast recipients error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("broadcast_id", draft.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	langs := make([]string, 0, len(draft.Messages))
+	for lang := range draft.Messages {
+		langs = append(langs, lang)
+	}
+
+	slices.Sort(langs)
+
+	langNames := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		langName := h.languageName(lang)
+		langNames = append(langNames, langName)
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "BroadcastPreview",
+				TemplateData: map[string]string{
+					"Language": langName,
+				},
+			}),
+		})
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   draft.Messages[lang],
+		})
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastSummary",
+			TemplateData: map[string]string{
+				"ID":              strconv.FormatInt(draft.ID, 10),
+				"Languages":       strings.Join(langNames, ", "),
+				"Recipients":      h.recipientsText(draft, user.Localizer),
+				"RecipientsCount": user.Formatter.Number(recipientsCount),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateBroadcastReplyKeyboard(b, h.keyboard, draft.Messages, user.Localizer),
+	})
+}
+
+func (h *BroadcastHandler) Start(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	draft, err := h.broadcastsService.CreateDraft(ctx, user.ID)
+	if err != nil {
+		zap.L().Error("create broadcast draft error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	zap.L().Info("admin created broadcast draft",
+		zap.Int64("user_id", user.ID),
+		zap.Int64("broadcast_id", draft.ID),
+	)
+
+	h.promptText(ctx, user, h.languages.FallbackTag().String(), botKeyboards.WithStartKeyboardHandler(h.Cancel), b, update)
+}
+
+func (h *BroadcastHandler) EnterText(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	if user.Action == nil || user.Action.Payload == nil {
+		return
+	}
+
+	draft := h.findDraft(ctx, user, b, update)
+	if draft == nil {
+		return
+	}
+
+	lang := *user.Action.Payload
+	if err := h.broadcastsService.SetMessage(ctx, draft.ID, lang, update.Message.Text); err != nil {
+		zap.L().Error("set broadcast message error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("broadcast_id", draft.ID),
+			zap.String("lang", lang),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.Preview(ctx, user, b, update)
+}
+
+func (h *BroadcastHandler) AddLanguage(tag language.Tag) middlewares.UserHandlerFunc {
+	return func(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+		if draft := h.findDraft(ctx, user, b, update); draft == nil {
+			return
+		}
+
+		h.promptText(ctx, user, tag.String(), h.Preview, b, update)
+	}
+}
+
+func (h *BroadcastHandler) Preview(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action before broadcast preview",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.showPreview(ctx, user, b, update)
+}
+
+func (h *BroadcastHandler) EnterRecipients(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	if draft := h.findDraft(ctx, user, b, update); draft == nil {
+		return
+	}
+
+	if err := h.userActionService.Set(ctx, user.ID, storage.BroadcastTargetAction, nil); err != nil {
+		zap.L().Error("set user broadcast target action error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	coins := []string{}
+	for _, blockchain := range h.blockchainsService.GetBlockchainsInfo() {
+		coins = append(coins, blockchain.Coin)
+	}
+
+	slices.Sort(coins)
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastEnterRecipients",
+			TemplateData: map[string]string{
+				"All":   BROADCAST_ALL_RECIPIENTS,
+				"Coins": strings.Join(coins, ", "),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateBackReplyKeyboard(b, h.adminMiddleware.Handler(h.Preview), user.Localizer),
+	})
+}
+
+func (h *BroadcastHandler) Target(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	draft := h.findDraft(ctx, user, b, update)
+	if draft == nil {
+		return
+	}
+
+	coins := []string{}
+	if text := strings.TrimSpace(update.Message.Text); !strings.EqualFold(text, BROADCAST_ALL_RECIPIENTS) {
+		for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\n'
+		}) {
+			coin := strings.ToLower(field)
+			if _, err := h.blockchainsService.GetInfo(coin); err != nil {
+				b.SendMessage(ctx, &bot.SendMessageParams{
+					ChatID: update.Message.Chat.ID,
+					Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+						MessageID: "AdminUnknownBlockchain",
+						TemplateData: map[string]string{
+							"Coin": coin,
+						},
+					}),
+				})
+
+				return
+			}
+
+			if !slices.Contains(coins, coin) {
+				coins = append(coins, coin)
+			}
+		}
+	}
+
+	if err := h.broadcastsService.SetCoins(ctx, draft.ID, coins); err != nil {
+		zap.L().Error("set broadcast coins error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("broadcast_id", draft.ID),
+			zap.Strings("coins", coins),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.Preview(ctx, user, b, update)
+}
+
+func (h *BroadcastHandler) Send(ctx context.Context, user *middlewares.User, startKeyboard *botKeyboards.StartKeyboard, b *bot.Bot, update *models.Update) {
+	draft := h.findDraft(ctx, user, b, update)
+	if draft == nil {
+		return
+	}
+
+	recipientsCount, err := h.broadcastsService.Send(ctx, draft.ID)
+	if errors.Is(err, broadcasts.ErrEmptyBroadcast) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "BroadcastEmpty",
+			}),
+		})
+
+		return
+	} else if err != nil {
+		zap.L().Error("send broadcast error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("broadcast_id", draft.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastStarted",
+			TemplateData: map[string]string{
+				"ID":              strconv.FormatInt(draft.ID, 10),
+				"RecipientsCount": user.Formatter.Count("User", recipientsCount),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+	})
+}
+
+func (h *BroadcastHandler) Cancel(ctx context.Context, user *middlewares.User, startKeyboard *botKeyboards.StartKeyboard, b *bot.Bot, update *models.Update) {
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action before broadcast cancel",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	draft, err := h.broadcastsService.FindDraft(ctx, user.ID)
+	if err != nil {
+		zap.L().Error("find admin broadcast draft error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if draft != nil {
+		if err := h.broadcastsService.Cancel(ctx, draft.ID); err != nil {
+			zap.L().Error("cancel broadcast error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("broadcast_id", draft.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastCancelled",
+		}),
+		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+	})
+}
+
+func (h *BroadcastHandler) Status(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	var (
+		broadcast *storage.Broadcast
+		err       error
+	)
+
+	if args := commandArgs(update); len(args) > 0 {
+		id, parseErr := strconv.ParseInt(args[0], 10, 64)
+		if parseErr != nil {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: update.Message.Chat.ID,
+				Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "BroadcastStatusUsage",
+				}),
+			})
+
+			return
+		}
+
+		broadcast, err = h.broadcastsService.Find(ctx, id)
+	} else {
+		broadcast, err = h.broadcastsService.FindLatest(ctx, user.ID)
+	}
+
+	if err != nil {
+		zap.L().Error("find broadcast error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if broadcast == nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "BroadcastNotFound",
+			}),
+		})
+
+		return
+	}
+
+	stats, err := h.broadcastsService.Stats(ctx, broadcast.ID)
+	if err != nil {
+		zap.L().Error("get broadcast stats error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("broadcast_id", broadcast.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	emptyText := user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminStatsEmpty",
+	})
+	startedAt, finishedAt := emptyText, emptyText
+	if broadcast.StartedAt != nil {
+		startedAt = user.Formatter.DateTime(*broadcast.StartedAt)
+	}
+
+	if broadcast.FinishedAt != nil {
+		finishedAt = user.Formatter.DateTime(*broadcast.FinishedAt)
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastStatus",
+			TemplateData: map[string]string{
+				"ID":         strconv.FormatInt(broadcast.ID, 10),
+				"Status":     string(broadcast.Status),
+				"Recipients": h.recipientsText(broadcast, user.Localizer),
+				"StartedAt":  startedAt,
+				"FinishedAt": finishedAt,
+				"Pending":    user.Formatter.Number(stats.Pending),
+				"Sent":       user.Formatter.Number(stats.Sent),
+				"Failed":     user.Formatter.Number(stats.Failed),
+				"Skipped":    user.Formatter.Number(stats.Skipped),
+			},
+		}),
+	})
+}
+
+func NewBroadcastHandler(
+	broadcastsService *broadcasts.Service,
+	userActionService *services.UserActionService,
+	blockchainsService *blockchains.Service,
+	languages *languages.Languages,
+	adminMiddleware *middlewares.AdminMiddleware,
+) *BroadcastHandler {
+	h := &BroadcastHandler{
+		broadcastsService:  broadcastsService,
+		userActionService:  userActionService,
+		blockchainsService: blockchainsService,
+		languages:          languages,
+		adminMiddleware:    adminMiddleware,
+	}
+
+	h.keyboard = botKeyboards.CreateBroadcastKeyboard(
+		languages.GetLocalizers(),
+		func(tag language.Tag) middlewares.UserHandlerFunc {
+			return adminMiddleware.Handler(h.AddLanguage(tag))
+		},
+		adminMiddleware.Handler(h.EnterRecipients),
+		adminMiddleware.Handler(botKeyboards.WithStartKeyboardHandler(h.Send)),
+		adminMiddleware.Handler(botKeyboards.WithStartKeyboardHandler(h.Cancel)),
+	)
+
+	return h
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	telegramUtils "github.com/grandminingpool/telegram-bot/internal/utils/telegram"
+	"go.uber.org/zap"
+)
+
+type ChatMemberHandler struct {
+	privacyService *privacy.Service
+}
+
+func (h *ChatMemberHandler) Handler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	member := update.MyChatMember
+	if member.Chat.Type != middlewares.PRIVATE_CHAT_TYPE {
+		return
+	}
+
+	var err error
+	switch member.NewChatMember.Type {
+	case models.ChatMemberTypeBanned:
+		err = h.privacyService.Deactivate(ctx, member.Chat.ID, telegramUtils.SendErrorBlocked)
+	case models.ChatMemberTypeMember:
+		err = h.privacyService.Reactivate(ctx, member.Chat.ID)
+	}
+
+	if err != nil {
+		zap.L().Error("update user activity error",
+			zap.Int64("user_id", member.From.ID),
+			zap.String("status", string(member.NewChatMember.Type)),
+			zap.Error(err),
+		)
+	}
+}
+
+func NewChatMemberHandler(privacyService *privacy.Service) *ChatMemberHandler {
+	return &ChatMemberHandler{
+		privacyService: privacyService,
+	}
+}
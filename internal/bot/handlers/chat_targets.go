@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/go-telegram/ui/keyboard/inline"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const CHANNEL_CHAT_TYPE = "channel"
+
+type ChatTargetsHandler struct {
+	chatTargetService *services.ChatTargetService
+}
+
+func isChatAdmin(member *models.ChatMember, canPost bool) bool {
+	switch member.Type {
+	case models.ChatMemberTypeOwner:
+		return true
+	case models.ChatMemberTypeAdministrator:
+		//	Channel posting is a separate admin right
+		return !canPost || (member.Administrator != nil && member.Administrator.CanPostMessages)
+	default:
+		return false
+	}
+}
+
+func (h *ChatTargetsHandler) sendText(ctx context.Context, chatID int64, threadID int, text string, b *bot.Bot) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:          chatID,
+		MessageThreadID: threadID,
+		Text:            text,
+	})
+}
+
+func (h *ChatTargetsHandler) reply(ctx context.Context, user *middlewares.User, messageID string, b *bot.Bot) {
+	h.sendText(ctx, user.Chat.ID, user.Chat.ThreadID, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: messageID,
+	}), b)
+}
+
+func (h *ChatTargetsHandler) parseChat(user *middlewares.User, update *models.Update) (any, int, bool) {
+	if !user.IsPrivateChat() {
+		return user.Chat.ID, user.Chat.ThreadID, true
+	}
+
+	args := commandArgs(update)
+	if len(args) == 0 || len(args) > 2 {
+		return nil, 0, false
+	}
+
+	var chatID any = args[0]
+	if id, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+		chatID = id
+	}
+
+	threadID := 0
+	if len(args) == 2 {
+		id, err := strconv.Atoi(args[1])
+		if err != nil || id <= 0 {
+			return nil, 0, false
+		}
+
+		threadID = id
+	}
+
+	return chatID, threadID, true
+}
+
+func (h *ChatTargetsHandler) Link(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	chatID, threadID, ok := h.parseChat(user, update)
+	if !ok {
+		h.reply(ctx, user, "ChatTargetUsage", b)
+
+		return
+	}
+
+	chat, err := b.GetChat(ctx, &bot.GetChatParams{
+		ChatID: chatID,
+	})
+	if err != nil || chat.Type == middlewares.PRIVATE_CHAT_TYPE {
+		h.reply(ctx, user, "ChatTargetNotFound", b)
+
+		return
+	}
+
+	me, err := b.GetMe(ctx)
+	if err != nil {
+		zap.L().Error("get bot info error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	isChannel := chat.Type == CHANNEL_CHAT_TYPE
+	botMember, err := b.GetChatMember(ctx, &bot.GetChatMemberParams{
+		ChatID: chat.ID,
+		UserID: me.ID,
+	})
+	if err != nil || !isChatAdmin(botMember, isChannel) {
+		h.reply(ctx, user, "ChatTargetBotNotAdmin", b)
+
+		return
+	}
+
+	userMember, err := b.GetChatMember(ctx, &bot.GetChatMemberParams{
+		ChatID: chat.ID,
+		UserID: user.ID,
+	})
+	if err != nil || !isChatAdmin(userMember, false) {
+		h.reply(ctx, user, "ChatTargetUserNotAdmin", b)
+
+		return
+	}
+
+	//	Topics exist only in forum supergroups
+	if !chat.IsForum {
+		threadID = 0
+	}
+
+	target, err := h.chatTargetService.Link(ctx, &storage.ChatTargetDB{
+		UserID:   user.ID,
+		ChatID:   chat.ID,
+		ThreadID: int64(threadID),
+		ChatType: chat.Type,
+		Title:    chat.Title,
+	})
+	if err != nil {
+		zap.L().Error("link chat target error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("chat_id", chat.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.reply(ctx, user, "ChatTargetLinked", b)
+
+	//	Wallets are selected in private chat to not expose them in group
+	h.sendWallets(ctx, user, target, b)
+}
+
+func (h *ChatTargetsHandler) List(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	targets, err := h.chatTargetService.FindByUser(ctx, user.ID)
+	if err != nil {
+		zap.L().Error("find user chat targets error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if len(targets) == 0 {
+		h.reply(ctx, user, "ChatTargetsEmpty", b)
+
+		return
+	}
+
+	for _, target := range targets {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "ChatTargetInfo",
+				TemplateData: map[string]any{
+					"Title":    target.Title,
+					"Type":     target.ChatType,
+					"ThreadID": target.ThreadID,
+				},
+			}),
+			ReplyMarkup: botKeyboards.CreateChatTargetInlineKeyboard(
+				b,
+				h.onWallets(user, target),
+				h.onUnlink(user, target),
+				user.Localizer,
+			),
+		})
+	}
+}
+
+func (h *ChatTargetsHandler) sendWallets(ctx context.Context, user *middlewares.User, target *storage.ChatTargetDB, b *bot.Bot) {
+	wallets, err := h.chatTargetService.FindWallets(ctx, user.ID, target.ID)
+	if err != nil {
+		zap.L().Error("find chat target wallets error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("target_id", target.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if len(wallets) == 0 {
+		h.sendText(ctx, user.ChatID, 0, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ChatTargetNoWallets",
+		}), b)
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ChatTargetSelectWallets",
+			TemplateData: map[string]string{
+				"Title": target.Title,
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateChatTargetWalletsInlineKeyboard(
+			b,
+			wallets,
+			user.Settings.ShortWallets,
+			h.onToggleWallet(user, target, wallets),
+			h.onWalletsDone(user),
+			user.Localizer,
+		),
+	})
+}
+
+func (h *ChatTargetsHandler) onWallets(user *middlewares.User, target storage.ChatTargetDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		h.sendWallets(ctx, user, &target, b)
+	}
+}
+
+func (h *ChatTargetsHandler) onUnlink(user *middlewares.User, target storage.ChatTargetDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		if err := h.chatTargetService.Unlink(ctx, user.ID, target.ID); err != nil {
+			zap.L().Error("unlink chat target error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("target_id", target.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		h.sendText(ctx, user.ChatID, 0, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ChatTargetUnlinked",
+			TemplateData: map[string]string{
+				"Title": target.Title,
+			},
+		}), b)
+	}
+}
+
+func (h *ChatTargetsHandler) onToggleWallet(
+	user *middlewares.User,
+	target *storage.ChatTargetDB,
+	wallets []storage.ChatTargetWalletDB,
+) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		walletID, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return
+		}
+
+		for _, wallet := range wallets {
+			if wallet.ID != walletID {
+				continue
+			}
+
+			if err := h.chatTargetService.SetWallet(ctx, target.ID, wallet.ID, !wallet.Enabled); err != nil {
+				zap.L().Error("set chat target wallet error",
+					zap.Int64("user_id", user.ID),
+					zap.Int64("target_id", target.ID),
+					zap.Int64("wallet_id", wallet.ID),
+					zap.Error(err),
+				)
+
+				return
+			}
+		}
+
+		//	Keyboard is removed after click, so it is sent again with new state
+		h.sendWallets(ctx, user, target, b)
+	}
+}
+
+func (h *ChatTargetsHandler) onWalletsDone(user *middlewares.User) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		h.sendText(ctx, user.ChatID, 0, user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ChatTargetWalletsSaved",
+		}), b)
+	}
+}
+
+func NewChatTargetsHandler(chatTargetService *services.ChatTargetService) *ChatTargetsHandler {
+	return &ChatTargetsHandler{
+		chatTargetService: chatTargetService,
+	}
+}
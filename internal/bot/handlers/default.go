@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -12,11 +13,38 @@ import (
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
+type DeepLinkHandlerFunc func(context.Context, *middlewares.User, *botKeyboards.StartKeyboard, string, *bot.Bot, *models.Update)
+
 type DefaultHandler struct {
 	languages *languages.Languages
+	deepLinks map[string]DeepLinkHandlerFunc
+}
+
+func startPayload(text string) (string, bool) {
+	fields := strings.Fields(text)
+	if len(fields) != 2 || fields[0] != string(constants.StartCommand) {
+		return "", false
+	}
+
+	return fields[1], true
+}
+
+func (h *DefaultHandler) RegisterDeepLink(prefix string, handler DeepLinkHandlerFunc) {
+	h.deepLinks[prefix] = handler
 }
 
 func (h *DefaultHandler) Handler(ctx context.Context, user *middlewares.User, startKeyboard *botKeyboards.StartKeyboard, b *bot.Bot, update *models.Update) {
+	//	Deep links are opened as /start <payload>
+	if payload, ok := startPayload(update.Message.Text); ok {
+		for prefix, handler := range h.deepLinks {
+			if value, found := strings.CutPrefix(payload, prefix); found {
+				handler(ctx, user, startKeyboard, value, b, update)
+
+				return
+			}
+		}
+	}
+
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
 		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
@@ -39,5 +67,6 @@ func (h *DefaultHandler) Handler(ctx context.Context, user *middlewares.User, st
 func NewDefaultHandler(languages *languages.Languages) *DefaultHandler {
 	return &DefaultHandler{
 		languages: languages,
+		deepLinks: make(map[string]DeepLinkHandlerFunc),
 	}
 }
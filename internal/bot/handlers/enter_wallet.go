@@ -9,6 +9,7 @@ import (
 	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
 	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
 	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"go.uber.org/zap"
 )
@@ -65,7 +66,7 @@ func (h *EnterWalletHandler) Handler(
 	b *bot.Bot,
 	update *models.Update,
 ) {
-	if err := h.userActionService.Set(ctx, user.ID, services.UserAddWalletAction, &blockchain.Coin); err != nil {
+	if err := h.userActionService.Set(ctx, user.ID, storage.UserAddWalletAction, &blockchain.Coin); err != nil {
 		zap.L().Error("set user add wallet action error",
 			zap.Int64("user_id", user.ID),
 			zap.Error(err),
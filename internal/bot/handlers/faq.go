@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -20,10 +19,10 @@ func (h *FAQHandler) Handler(ctx context.Context, user *middlewares.User, b *bot
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
 		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: "FAQText",
+			MessageID: "FAQMessage",
 			TemplateData: map[string]string{
 				"PoolURL":              h.poolURL,
-				"CheckWorkersInterval": fmt.Sprintf("%d", h.checkWorkersInterval),
+				"CheckWorkersInterval": user.Formatter.Minutes(h.checkWorkersInterval),
 				"SupportBotUsername":   h.supportBotUsername,
 			},
 		}),
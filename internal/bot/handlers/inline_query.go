@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	INLINE_QUERY_MAX_WALLET_LENGTH = 128
+	//	Zero cache time is omitted in request and telegram applies its default of 5 minutes
+	INLINE_QUERY_RATE_LIMITED_CACHE_TIME = 1
+)
+
+type InlineQueryHandler struct {
+	walletLookupService *services.WalletLookupService
+	languages           *languages.Languages
+	cacheTime           time.Duration
+	rateLimitInterval   time.Duration
+	mu                  sync.Mutex
+	lastQueries         map[int64]time.Time
+}
+
+func (h *InlineQueryHandler) allow(userID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if lastQuery, ok := h.lastQueries[userID]; ok && now.Sub(lastQuery) < h.rateLimitInterval {
+		return false
+	}
+
+	for id, lastQuery := range h.lastQueries {
+		if now.Sub(lastQuery) >= h.rateLimitInterval {
+			delete(h.lastQueries, id)
+		}
+	}
+
+	h.lastQueries[userID] = now
+
+	return true
+}
+
+func (h *InlineQueryHandler) createArticle(result services.WalletLookupResult, localizer *i18n.Localizer) *models.InlineQueryResultArticle {
+	formatter := formatUtils.NewFormatter(localizer)
+	balance := formatter.Amount(result.Balance, result.Blockchain.Decimals, result.Blockchain.DisplayPrecision)
+	hashrate := formatter.Hashrate(result.Hashrate)
+	workersCount := formatter.Number(int64(result.WorkersCount))
+
+	return &models.InlineQueryResultArticle{
+		ID: result.Blockchain.Coin,
+		Title: localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "InlineWalletTitle",
+			TemplateData: map[string]string{
+				"PoolBlockchainName": result.Blockchain.Name,
+				"Balance":            balance,
+				"Ticker":             result.Blockchain.Ticker,
+			},
+		}),
+		Description: localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "InlineWalletDescription",
+			TemplateData: map[string]string{
+				"Hashrate":     hashrate,
+				"WorkersCount": workersCount,
+			},
+		}),
+		InputMessageContent: &models.InputTextMessageContent{
+			MessageText: localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "InlineWalletInfo",
+				TemplateData: map[string]string{
+					"Wallet":             result.Wallet,
+					"PoolBlockchainName": result.Blockchain.Name,
+					"Balance":            balance,
+					"Ticker":             result.Blockchain.Ticker,
+					"Hashrate":           hashrate,
+					"WorkersCount":       workersCount,
+				},
+			}),
+		},
+	}
+}
+
+func (h *InlineQueryHandler) Handler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	query := update.InlineQuery
+	wallet := strings.TrimSpace(query.Query)
+	if wallet == "" || len(wallet) > INLINE_QUERY_MAX_WALLET_LENGTH || strings.ContainsAny(wallet, " \n\t") {
+		return
+	}
+
+	//	Inline queries fire on every keystroke, so only cached wallets bypass rate limit
+	results, ok := h.walletLookupService.Get(wallet)
+	if !ok {
+		//	Limited query is answered with empty personal result, which telegram keeps only for a second,
+		//	so the same query is looked up again when user types it after interval
+		if !h.allow(query.From.ID) {
+			h.answer(ctx, b, &bot.AnswerInlineQueryParams{
+				InlineQueryID: query.ID,
+				Results:       []models.InlineQueryResult{},
+				CacheTime:     INLINE_QUERY_RATE_LIMITED_CACHE_TIME,
+				IsPersonal:    true,
+			}, query.From.ID)
+
+			return
+		}
+
+		results = h.walletLookupService.Lookup(ctx, wallet)
+	}
+
+	localizer := h.languages.GetLocalizer(query.From.LanguageCode)
+	articles := make([]models.InlineQueryResult, 0, len(results))
+	for _, result := range results {
+		articles = append(articles, h.createArticle(result, localizer))
+	}
+
+	h.answer(ctx, b, &bot.AnswerInlineQueryParams{
+		InlineQueryID: query.ID,
+		Results:       articles,
+		CacheTime:     int(h.cacheTime.Seconds()),
+	}, query.From.ID)
+}
+
+func (h *InlineQueryHandler) answer(ctx context.Context, b *bot.Bot, params *bot.AnswerInlineQueryParams, userID int64) {
+	if _, err := b.AnswerInlineQuery(ctx, params); err != nil {
+		zap.L().Error("answer inline query error",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+	}
+}
+
+func NewInlineQueryHandler(
+	walletLookupService *services.WalletLookupService,
+	languages *languages.Languages,
+	cacheTime time.Duration,
+	rateLimitInterval time.Duration,
+) *InlineQueryHandler {
+	return &InlineQueryHandler{
+		walletLookupService: walletLookupService,
+		languages:           languages,
+		cacheTime:           cacheTime,
+		rateLimitInterval:   rateLimitInterval,
+		lastQueries:         make(map[int64]time.Time),
+	}
+}
@@ -0,0 +1,604 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/go-telegram/ui/keyboard/inline"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	MAX_NAME_LENGTH        = 64
+	REMOVE_NAME_TEXT       = "-"
+	WORKER_ALIAS_SEPARATOR = ":"
+	WALLET_PAYOUTS_LIMIT   = 10
+)
+
+type ManageWalletHandler struct {
+	userWalletService  *services.UserWalletService
+	userActionService  *services.UserActionService
+	blockchainsService *blockchains.Service
+	removal            *walletRemoval
+}
+
+func parseName(text string) (string, bool) {
+	name := strings.TrimSpace(text)
+	if name == REMOVE_NAME_TEXT {
+		return "", true
+	}
+
+	return name, name != "" && utf8.RuneCountInString(name) <= MAX_NAME_LENGTH
+}
+
+func (h *ManageWalletHandler) sendText(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	messageID string,
+	b *bot.Bot,
+) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: messageID,
+		}),
+		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+	})
+}
+
+func (h *ManageWalletHandler) reply(ctx context.Context, user *middlewares.User, messageID string, b *bot.Bot) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: messageID,
+		}),
+	})
+}
+
+func (h *ManageWalletHandler) sendNameTooLong(ctx context.Context, user *middlewares.User, b *bot.Bot) {
+	//	User action is kept, so name can be sent again
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "NameTooLong",
+			TemplateData: map[string]int{
+				"MaxLength": MAX_NAME_LENGTH,
+			},
+		}),
+	})
+}
+
+func (h *ManageWalletHandler) sendPoolUnavailable(ctx context.Context, user *middlewares.User, wallet *storage.UserWalletDB, b *bot.Bot) {
+	poolName := wallet.Coin
+	if blockchain, err := h.blockchainsService.GetInfo(wallet.Coin); err == nil {
+		poolName = blockchain.Name
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "PoolTemporarilyUnavailable",
+			TemplateData: map[string]string{
+				"PoolBlockchainName": poolName,
+			},
+		}),
+	})
+}
+
+func (h *ManageWalletHandler) sendMenu(ctx context.Context, user *middlewares.User, id int64, b *bot.Bot) {
+	//	Wallet is loaded again on every menu to show its current settings
+	wallet, err := h.userWalletService.Find(ctx, user.ID, id)
+	if err != nil {
+		zap.L().Error("find user wallet error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("wallet_id", id),
+			zap.Error(err),
+		)
+
+		return
+	} else if wallet == nil {
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletInfo",
+			TemplateData: map[string]string{
+				"Coin":   wallet.Coin,
+				"Wallet": wallet.Wallet,
+				"Label":  wallet.Label,
+				"Notify": formatUtils.BoolText(wallet.Notify, user.Localizer),
+				"Paused": formatUtils.BoolText(wallet.Paused, user.Localizer),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateManageWalletInlineKeyboard(
+			b,
+			wallet,
+			h.onDetails(user, wallet),
+			h.onRename(user, wallet),
+			h.onWorkers(user, wallet),
+			h.onPayouts(user, wallet),
+			h.onAliases(user, wallet),
+			h.onNotify(user, wallet),
+			h.onPause(user, wallet),
+			h.onRemove(user, wallet),
+			user.Localizer,
+		),
+	})
+}
+
+func (h *ManageWalletHandler) List(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	wallets, err := h.userWalletService.FindAll(ctx, user.ID)
+	if err != nil {
+		zap.L().Error("find user wallets error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if len(wallets) == 0 {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "UserHasNoWallets",
+			}),
+		})
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletsSelect",
+		}),
+		ReplyMarkup: botKeyboards.CreateManageWalletsInlineKeyboard(
+			b,
+			wallets,
+			user.Settings.ShortWallets,
+			h.onWalletSelected(user),
+			user.Localizer,
+		),
+	})
+}
+
+func (h *ManageWalletHandler) onWalletSelected(user *middlewares.User) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		id, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return
+		}
+
+		h.sendMenu(ctx, user, id, b)
+	}
+}
+
+func (h *ManageWalletHandler) onDetails(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		details, err := h.userWalletService.FindWalletDetails(ctx, user.ID, wallet)
+		if err != nil {
+			zap.L().Warn("wallet details are unavailable",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			h.sendPoolUnavailable(ctx, user, wallet, b)
+
+			return
+		}
+
+		lastPayout := user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "WalletNoPayouts",
+		})
+		if details.LastPayout != nil {
+			lastPayout = user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "WalletLastPayout",
+				TemplateData: map[string]string{
+					"Amount": user.Formatter.Amount(details.LastPayout.Amount, details.Blockchain.Decimals, details.Blockchain.DisplayPrecision),
+					"Ticker": details.Blockchain.Ticker,
+					"PaidAt": user.Formatter.DateTime(details.LastPayout.PaidAt),
+				},
+			})
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "ManageWalletDetails",
+				TemplateData: map[string]string{
+					"Wallet":             formatUtils.WalletName(wallet.Wallet, wallet.Label, user.Settings.ShortWallets),
+					"PoolBlockchainName": details.Blockchain.Name,
+					"Balance":            user.Formatter.Amount(details.Balance, details.Blockchain.Decimals, details.Blockchain.DisplayPrecision),
+					"Ticker":             details.Blockchain.Ticker,
+					"Hashrate":           user.Formatter.Hashrate(details.Hashrate),
+					"WorkersCount":       user.Formatter.Number(int64(details.Workers)),
+					"LastPayout":         lastPayout,
+				},
+			}),
+		})
+	}
+}
+
+func (h *ManageWalletHandler) onWorkers(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		workers, err := h.userWalletService.FindWalletWorkers(ctx, user.ID, wallet)
+		if err != nil {
+			zap.L().Warn("wallet workers are unavailable",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			h.sendPoolUnavailable(ctx, user, wallet, b)
+
+			return
+		}
+
+		if len(workers) == 0 {
+			h.reply(ctx, user, "UserHasNoActiveWorkers", b)
+
+			return
+		}
+
+		for _, worker := range workers {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: user.ChatID,
+				Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "WorkerInfo",
+					TemplateData: map[string]string{
+						"Region":   worker.Region,
+						"Worker":   formatUtils.WorkerName(worker.Worker, worker.Alias),
+						"Solo":     formatUtils.BoolText(worker.Solo, user.Localizer),
+						"Hashrate": user.Formatter.Hashrate(worker.Hashrate),
+						"Uptime":   user.Formatter.Uptime(worker.ConnectedAt),
+					},
+				}),
+			})
+		}
+	}
+}
+
+func (h *ManageWalletHandler) onPayouts(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		blockchain, err := h.blockchainsService.GetInfo(wallet.Coin)
+		if err != nil {
+			return
+		}
+
+		payouts, err := h.userWalletService.FindWalletPayouts(ctx, wallet, WALLET_PAYOUTS_LIMIT)
+		if err != nil {
+			zap.L().Warn("wallet payouts are unavailable",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			h.sendPoolUnavailable(ctx, user, wallet, b)
+
+			return
+		}
+
+		if len(payouts) == 0 {
+			h.reply(ctx, user, "WalletNoPayouts", b)
+
+			return
+		}
+
+		var msgBuf bytes.Buffer
+		msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletPayouts",
+			TemplateData: map[string]string{
+				"Wallet": formatUtils.WalletName(wallet.Wallet, wallet.Label, user.Settings.ShortWallets),
+			},
+		}))
+		for _, payout := range payouts {
+			msgBuf.WriteString("\n\n")
+			msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "PayoutInfo",
+				TemplateData: map[string]string{
+					"Amount": user.Formatter.Amount(payout.Amount, blockchain.Decimals, blockchain.DisplayPrecision),
+					"Ticker": blockchain.Ticker,
+					"TxHash": payout.TxHash,
+					"PaidAt": user.Formatter.DateTime(payout.PaidAt),
+				},
+			}))
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text:   msgBuf.String(),
+		})
+	}
+}
+
+func (h *ManageWalletHandler) onNotify(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		notify := !wallet.Notify
+		if err := h.userWalletService.SetNotify(ctx, user.ID, wallet.ID, notify); err != nil {
+			zap.L().Error("set user wallet notify error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		messageID := "WalletNotifyDisabled"
+		if notify {
+			messageID = "WalletNotifyEnabled"
+		}
+
+		h.reply(ctx, user, messageID, b)
+		h.sendMenu(ctx, user, wallet.ID, b)
+	}
+}
+
+func (h *ManageWalletHandler) onPause(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		paused := !wallet.Paused
+		if err := h.userWalletService.SetPaused(ctx, user.ID, wallet.ID, paused); err != nil {
+			zap.L().Error("set user wallet paused error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		messageID := "WalletMonitoringResumed"
+		if paused {
+			messageID = "WalletMonitoringPaused"
+		}
+
+		h.reply(ctx, user, messageID, b)
+		h.sendMenu(ctx, user, wallet.ID, b)
+	}
+}
+
+func (h *ManageWalletHandler) onRemove(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		h.removal.confirm(ctx, user, wallet, b)
+	}
+}
+
+func (h *ManageWalletHandler) onRename(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		payload := strconv.FormatInt(wallet.ID, 10)
+		if err := h.userActionService.Set(ctx, user.ID, storage.RenameWalletAction, &payload); err != nil {
+			zap.L().Error("set user rename wallet action error",
+				zap.Int64("user_id", user.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "EnterWalletLabel",
+				TemplateData: map[string]string{
+					"Wallet":     wallet.Wallet,
+					"RemoveText": REMOVE_NAME_TEXT,
+				},
+			}),
+			ReplyMarkup: botKeyboards.CreateBackReplyKeyboard(b, botKeyboards.WithStartKeyboardHandler(h.Back), user.Localizer),
+		})
+	}
+}
+
+func (h *ManageWalletHandler) onAliases(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		workers, err := h.userWalletService.FindWorkerAliases(ctx, user.ID, wallet.ID)
+		if err != nil {
+			zap.L().Error("find user wallet workers error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		if len(workers) == 0 {
+			h.reply(ctx, user, "ManageWalletNoWorkers", b)
+
+			return
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "ManageWalletSelectWorker",
+			}),
+			ReplyMarkup: botKeyboards.CreateWorkerAliasesInlineKeyboard(b, workers, h.onWorkerSelected(user, wallet, workers)),
+		})
+	}
+}
+
+func (h *ManageWalletHandler) onWorkerSelected(
+	user *middlewares.User,
+	wallet *storage.UserWalletDB,
+	workers []storage.WorkerAliasDB,
+) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		idx, err := strconv.Atoi(string(data))
+		if err != nil || idx < 0 || idx >= len(workers) {
+			return
+		}
+
+		worker := workers[idx].Worker
+		payload := fmt.Sprintf("%d%s%s", wallet.ID, WORKER_ALIAS_SEPARATOR, worker)
+		if err := h.userActionService.Set(ctx, user.ID, storage.AliasWorkerAction, &payload); err != nil {
+			zap.L().Error("set user alias worker action error",
+				zap.Int64("user_id", user.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "EnterWorkerAlias",
+				TemplateData: map[string]string{
+					"Worker":     worker,
+					"RemoveText": REMOVE_NAME_TEXT,
+				},
+			}),
+			ReplyMarkup: botKeyboards.CreateBackReplyKeyboard(b, botKeyboards.WithStartKeyboardHandler(h.Back), user.Localizer),
+		})
+	}
+}
+
+func (h *ManageWalletHandler) Back(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action before returning to menu from wallet management",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.sendText(ctx, user, startKeyboard, "ReturningToMenu", b)
+}
+
+func (h *ManageWalletHandler) Rename(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	if user.Action == nil || user.Action.Payload == nil {
+		return
+	}
+
+	id, err := strconv.ParseInt(*user.Action.Payload, 10, 64)
+	if err != nil {
+		return
+	}
+
+	label, ok := parseName(update.Message.Text)
+	if !ok {
+		h.sendNameTooLong(ctx, user, b)
+
+		return
+	}
+
+	if err := h.userWalletService.SetLabel(ctx, user.ID, id, label); err != nil {
+		zap.L().Error("set user wallet label error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("wallet_id", id),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action after renaming wallet",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.sendText(ctx, user, startKeyboard, "WalletLabelSaved", b)
+}
+
+func (h *ManageWalletHandler) AliasWorker(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	if user.Action == nil || user.Action.Payload == nil {
+		return
+	}
+
+	//	Wallet id never contains separator, worker name may
+	rawID, worker, ok := strings.Cut(*user.Action.Payload, WORKER_ALIAS_SEPARATOR)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return
+	}
+
+	alias, ok := parseName(update.Message.Text)
+	if !ok {
+		h.sendNameTooLong(ctx, user, b)
+
+		return
+	}
+
+	if err := h.userWalletService.SetWorkerAlias(ctx, user.ID, id, worker, alias); err != nil {
+		zap.L().Error("set user worker alias error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("wallet_id", id),
+			zap.String("worker", worker),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action after setting worker alias",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.sendText(ctx, user, startKeyboard, "WorkerAliasSaved", b)
+}
+
+func NewManageWalletHandler(
+	userWalletService *services.UserWalletService,
+	userActionService *services.UserActionService,
+	blockchainsService *blockchains.Service,
+) *ManageWalletHandler {
+	return &ManageWalletHandler{
+		userWalletService:  userWalletService,
+		userActionService:  userActionService,
+		blockchainsService: blockchainsService,
+		removal: &walletRemoval{
+			userWalletService: userWalletService,
+		},
+	}
+}
@@ -3,7 +3,7 @@ package handlers
 import (
 	"bytes"
 	"context"
-	"fmt"
+	"errors"
 	"math/big"
 	"strings"
 
@@ -60,7 +60,20 @@ func (h *PoolStatsHandler) OnBlockchainSelected(
 
 	client := poolProto.NewPoolServiceClient(conn)
 	poolInfo, err := client.GetPoolInfo(ctx, &emptypb.Empty{})
-	if err != nil {
+	if errors.Is(err, blockchains.ErrPoolUnavailable) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "PoolTemporarilyUnavailable",
+				TemplateData: map[string]string{
+					"PoolBlockchainName": blockchain.Name,
+				},
+			}),
+			ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+		})
+
+		return
+	} else if err != nil {
 		zap.L().Error("get blockchain pool info error",
 			zap.Int64("user_id", user.ID),
 			zap.String("coin", blockchain.Coin),
@@ -95,7 +108,7 @@ func (h *PoolStatsHandler) OnBlockchainSelected(
 	msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 		MessageID: "PoolStatsFeeInfo",
 		TemplateData: map[string]string{
-			"Fee": fmt.Sprintf("%.1f", poolInfo.Fee.Fee),
+			"Fee": user.Formatter.Decimal(poolInfo.Fee.Fee, 1),
 		},
 	}))
 
@@ -104,7 +117,7 @@ func (h *PoolStatsHandler) OnBlockchainSelected(
 		msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 			MessageID: "PoolStatsSoloFeeInfo",
 			TemplateData: map[string]string{
-				"Fee": fmt.Sprintf("%.1f", *poolInfo.Fee.SoloFee),
+				"Fee": user.Formatter.Decimal(*poolInfo.Fee.SoloFee, 1),
 			},
 		}))
 	}
@@ -117,9 +130,9 @@ func (h *PoolStatsHandler) OnBlockchainSelected(
 	msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 		MessageID: "PoolStatsMiningInfo",
 		TemplateData: map[string]string{
-			"MinersCount":   fmt.Sprintf("%d", poolStats.MinersCount),
-			"TotalHashrate": formatUtils.Hashrate(new(big.Int).SetBytes(poolStats.Hashrate)),
-			"AvgHashrate":   formatUtils.Hashrate(new(big.Int).SetBytes(poolStats.AvgHashrate)),
+			"MinersCount":   user.Formatter.Number(int64(poolStats.MinersCount)),
+			"TotalHashrate": user.Formatter.Hashrate(new(big.Int).SetBytes(poolStats.Hashrate)),
+			"AvgHashrate":   user.Formatter.Hashrate(new(big.Int).SetBytes(poolStats.AvgHashrate)),
 		},
 	}))
 
@@ -132,9 +145,9 @@ func (h *PoolStatsHandler) OnBlockchainSelected(
 		msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 			MessageID: "PoolStatsMiningInfo",
 			TemplateData: map[string]string{
-				"MinersCount":   fmt.Sprintf("%d", *poolStats.SoloMinersCount),
-				"TotalHashrate": formatUtils.Hashrate(new(big.Int).SetBytes(poolStats.SoloHashrate)),
-				"AvgHashrate":   formatUtils.Hashrate(new(big.Int).SetBytes(poolStats.SoloAvgHashrate)),
+				"MinersCount":   user.Formatter.Number(int64(*poolStats.SoloMinersCount)),
+				"TotalHashrate": user.Formatter.Hashrate(new(big.Int).SetBytes(poolStats.SoloHashrate)),
+				"AvgHashrate":   user.Formatter.Hashrate(new(big.Int).SetBytes(poolStats.SoloAvgHashrate)),
 			},
 		}))
 	}
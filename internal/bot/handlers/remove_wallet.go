@@ -16,6 +16,7 @@ import (
 type RemoveWalletHandler struct {
 	userWalletService *services.UserWalletService
 	userActionService *services.UserActionService
+	removal           *walletRemoval
 }
 
 func (h *RemoveWalletHandler) Back(ctx context.Context, user *middlewares.User, startKeyboard *botKeyboards.StartKeyboard, b *bot.Bot, update *models.Update) {
@@ -68,7 +69,12 @@ func (h *RemoveWalletHandler) OnBlockchainSelected(
 		return
 	}
 
-	userWalletsKeyboard := botKeyboards.CreateWalletsKeyboard(userWallets, botKeyboards.OnWalletSelectedWithStartKeyboardHandler(h.Remove), h.BackToBlockchainSelect)
+	userWalletsKeyboard := botKeyboards.CreateWalletsKeyboard(
+		userWallets,
+		user.Settings.ShortWallets,
+		botKeyboards.OnWalletSelectedWithStartKeyboardHandler(h.Remove),
+		h.BackToBlockchainSelect,
+	)
 
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
@@ -87,28 +93,36 @@ func (h *RemoveWalletHandler) Remove(
 	b *bot.Bot,
 	update *models.Update,
 ) {
-	if err := h.userWalletService.Remove(ctx, wallet.ID); err != nil {
-		zap.L().Error("remove user wallet error",
+	userWallet, err := h.userWalletService.Find(ctx, user.ID, wallet.ID)
+	if err != nil {
+		zap.L().Error("find user wallet error",
 			zap.Int64("user_id", user.ID),
 			zap.Int64("wallet_id", wallet.ID),
 			zap.Error(err),
 		)
 
+		return
+	} else if userWallet == nil {
 		return
 	}
 
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
 		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: "WalletRemoved",
+			MessageID: "ReturningToMenu",
 		}),
 		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
 	})
+
+	h.removal.confirm(ctx, user, userWallet, b)
 }
 
 func NewRemoveWalletHandler(userWalletService *services.UserWalletService, userActionService *services.UserActionService) *RemoveWalletHandler {
 	return &RemoveWalletHandler{
 		userWalletService: userWalletService,
 		userActionService: userActionService,
+		removal: &walletRemoval{
+			userWalletService: userWalletService,
+		},
 	}
 }
@@ -0,0 +1,482 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/common/constants"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	PHOTO_ATTACHMENT      = "photo"
+	DOCUMENT_ATTACHMENT   = "document"
+	VIDEO_ATTACHMENT      = "video"
+	VIDEO_NOTE_ATTACHMENT = "video_note"
+	ANIMATION_ATTACHMENT  = "animation"
+	AUDIO_ATTACHMENT      = "audio"
+	VOICE_ATTACHMENT      = "voice"
+)
+
+type SupportHandler struct {
+	supportService     *services.SupportService
+	userActionService  *services.UserActionService
+	languages          *languages.Languages
+	supportChatID      int64
+	supportBotUsername string
+}
+
+func messageAttachment(m *models.Message) (*string, *string) {
+	var attachmentType, fileID string
+
+	switch {
+	case len(m.Photo) > 0:
+		//	Last photo size is the largest one
+		attachmentType, fileID = PHOTO_ATTACHMENT, m.Photo[len(m.Photo)-1].FileID
+	case m.Document != nil:
+		attachmentType, fileID = DOCUMENT_ATTACHMENT, m.Document.FileID
+	case m.Video != nil:
+		attachmentType, fileID = VIDEO_ATTACHMENT, m.Video.FileID
+	case m.VideoNote != nil:
+		attachmentType, fileID = VIDEO_NOTE_ATTACHMENT, m.VideoNote.FileID
+	case m.Animation != nil:
+		attachmentType, fileID = ANIMATION_ATTACHMENT, m.Animation.FileID
+	case m.Audio != nil:
+		attachmentType, fileID = AUDIO_ATTACHMENT, m.Audio.FileID
+	case m.Voice != nil:
+		attachmentType, fileID = VOICE_ATTACHMENT, m.Voice.FileID
+	default:
+		return nil, nil
+	}
+
+	return &attachmentType, &fileID
+}
+
+func newSupportMessage(direction storage.SupportMessageDirection, authorID int64, m *models.Message) *storage.SupportMessage {
+	text := m.Text
+	if text == "" {
+		text = m.Caption
+	}
+
+	attachmentType, attachmentFileID := messageAttachment(m)
+	messageID := int64(m.ID)
+	message := &storage.SupportMessage{
+		Direction:        direction,
+		AuthorID:         authorID,
+		Text:             text,
+		AttachmentType:   attachmentType,
+		AttachmentFileID: attachmentFileID,
+	}
+
+	if direction == storage.SupportMessageFromUser {
+		message.UserMessageID = &messageID
+	} else {
+		message.SupportMessageID = &messageID
+	}
+
+	return message
+}
+
+func (h *SupportHandler) copyMessage(ctx context.Context, b *bot.Bot, chatID int64, m *models.Message, label string, replyTo *int64) (int64, error) {
+	var replyParameters *models.ReplyParameters
+	if replyTo != nil {
+		replyParameters = &models.ReplyParameters{
+			MessageID:                int(*replyTo),
+			AllowSendingWithoutReply: true,
+		}
+	}
+
+	attachmentType, _ := messageAttachment(m)
+	if label != "" && attachmentType == nil {
+		//	Text messages can't be copied with another caption, so they are resent with label
+		sentMessage, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:          chatID,
+			Text:            truncateMessage(label + "\n\n" + m.Text),
+			ReplyParameters: replyParameters,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return int64(sentMessage.ID), nil
+	}
+
+	params := &bot.CopyMessageParams{
+		ChatID:          chatID,
+		FromChatID:      strconv.FormatInt(m.Chat.ID, 10),
+		MessageID:       m.ID,
+		ReplyParameters: replyParameters,
+	}
+	if label != "" {
+		params.Caption = strings.TrimSpace(label + "\n\n" + m.Caption)
+	}
+
+	messageID, err := b.CopyMessage(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(messageID.ID), nil
+}
+
+func (h *SupportHandler) supportLocalizer() *i18n.Localizer {
+	return h.languages.GetLocalizer(h.languages.FallbackTag().String())
+}
+
+func (h *SupportHandler) ticketHeaderText(ticket *storage.SupportTicketDB, userContext *services.SupportUserContext, from *models.User) string {
+	l := h.supportLocalizer()
+	emptyText := l.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "AdminStatsEmpty",
+	})
+
+	username := emptyText
+	if userContext.Username != nil {
+		username = "@" + *userContext.Username
+	}
+
+	name := emptyText
+	if from != nil {
+		if fullName := strings.TrimSpace(from.FirstName + " " + from.LastName); fullName != "" {
+			name = fullName
+		}
+	}
+
+	wallets := emptyText
+	if len(userContext.Wallets) > 0 {
+		lines := make([]string, 0, len(userContext.Wallets))
+		for _, wallet := range userContext.Wallets {
+			lines = append(lines, l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "SupportTicketWallet",
+				TemplateData: map[string]string{
+					"Coin":   wallet.Coin,
+					"Wallet": wallet.Wallet,
+				},
+			}))
+		}
+
+		wallets = strings.Join(lines, "\n")
+	}
+
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "SupportTicketHeader",
+		TemplateData: map[string]string{
+			"ID":           strconv.FormatInt(ticket.ID, 10),
+			"UserID":       strconv.FormatInt(userContext.ID, 10),
+			"Username":     username,
+			"Name":         name,
+			"Lang":         userContext.Lang,
+			"Wallets":      wallets,
+			"CloseCommand": string(constants.SupportCloseCommand),
+		},
+	})
+}
+
+func (h *SupportHandler) forwardTicket(ctx context.Context, b *bot.Bot, ticket *storage.SupportTicketDB, messageID int64, m *models.Message) error {
+	userContext, err := h.supportService.GetUserContext(ctx, ticket.UserID)
+	if err != nil {
+		return err
+	}
+
+	headerMessage, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: h.supportChatID,
+		Text:   truncateMessage(h.ticketHeaderText(ticket, userContext, m.From)),
+	})
+	if err != nil {
+		return err
+	}
+
+	headerMessageID := int64(headerMessage.ID)
+	if err := h.supportService.SetTicketSupportMessageID(ctx, ticket.ID, headerMessageID); err != nil {
+		return err
+	}
+
+	supportMessageID, err := h.copyMessage(ctx, b, h.supportChatID, m, "", &headerMessageID)
+	if err != nil {
+		return err
+	}
+
+	return h.supportService.SetSupportMessageID(ctx, messageID, supportMessageID)
+}
+
+func (h *SupportHandler) CreateTicket(ctx context.Context, user *middlewares.User, startKeyboard *botKeyboards.StartKeyboard, b *bot.Bot, update *models.Update) {
+	ticket, messageID, err := h.supportService.Create(ctx, user.ID, newSupportMessage(storage.SupportMessageFromUser, user.ID, update.Message))
+	if err != nil {
+		zap.L().Error("create support ticket error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action after creating support ticket",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if err := h.forwardTicket(ctx, b, ticket, messageID, update.Message); err != nil {
+		//	Ticket is stored anyway, support can find it in database
+		zap.L().Error("forward support ticket error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "UserFeedbackSent",
+			TemplateData: map[string]string{
+				"TicketID":           strconv.FormatInt(ticket.ID, 10),
+				"SupportBotUsername": h.supportBotUsername,
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+	})
+}
+
+func (h *SupportHandler) FollowUp(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	ticket, err := h.supportService.FindByUserMessage(ctx, user.ID, int64(update.Message.ReplyToMessage.ID))
+	if err != nil {
+		zap.L().Error("find support ticket by user message error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if ticket == nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "SupportTicketNotFound",
+			}),
+		})
+
+		return
+	}
+
+	messageID, err := h.supportService.AddMessage(ctx, ticket.ID, newSupportMessage(storage.SupportMessageFromUser, user.ID, update.Message))
+	if err != nil {
+		zap.L().Error("add support ticket follow up error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	label := h.supportLocalizer().MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "SupportFollowUpLabel",
+		TemplateData: map[string]string{
+			"ID": strconv.FormatInt(ticket.ID, 10),
+		},
+	})
+	supportMessageID, err := h.copyMessage(ctx, b, h.supportChatID, update.Message, label, ticket.SupportMessageID)
+	if err != nil {
+		zap.L().Error("forward support ticket follow up error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if err := h.supportService.SetSupportMessageID(ctx, messageID, supportMessageID); err != nil {
+		zap.L().Error("set support ticket follow up message error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "SupportFollowUpSent",
+			TemplateData: map[string]string{
+				"ID": strconv.FormatInt(ticket.ID, 10),
+			},
+		}),
+	})
+}
+
+func (h *SupportHandler) replySupport(ctx context.Context, b *bot.Bot, update *models.Update, msgID string, ticketID int64) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:          update.Message.Chat.ID,
+		MessageThreadID: update.Message.MessageThreadID,
+		Text: h.supportLocalizer().MustLocalize(&i18n.LocalizeConfig{
+			MessageID: msgID,
+			TemplateData: map[string]string{
+				"ID": strconv.FormatInt(ticketID, 10),
+			},
+		}),
+		ReplyParameters: &models.ReplyParameters{
+			MessageID:                update.Message.ID,
+			AllowSendingWithoutReply: true,
+		},
+	})
+}
+
+func (h *SupportHandler) closeTicket(ctx context.Context, ticket *storage.SupportTicketDB, b *bot.Bot, update *models.Update) {
+	if err := h.supportService.Close(ctx, ticket.ID); err != nil {
+		zap.L().Error("close support ticket error",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	userContext, err := h.supportService.GetUserContext(ctx, ticket.UserID)
+	if err != nil {
+		zap.L().Error("get support ticket user error",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: userContext.ChatID,
+		Text: h.languages.GetLocalizer(userContext.Lang).MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "SupportTicketClosed",
+			TemplateData: map[string]string{
+				"ID":               strconv.FormatInt(ticket.ID, 10),
+				"ReportBugCommand": string(constants.ReportBugCommand),
+			},
+		}),
+	}); err != nil {
+		zap.L().Warn("failed to notify user about closed support ticket",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Int64("user_id", ticket.UserID),
+			zap.Error(err),
+		)
+	}
+
+	h.replySupport(ctx, b, update, "SupportTicketClosedBySupport", ticket.ID)
+}
+
+func (h *SupportHandler) Reply(ctx context.Context, b *bot.Bot, update *models.Update) {
+	ticket, err := h.supportService.FindBySupportMessage(ctx, int64(update.Message.ReplyToMessage.ID))
+	if err != nil {
+		zap.L().Error("find support ticket by support message error",
+			zap.Int64("chat_id", update.Message.Chat.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	//	Support chat replies not related to tickets are ignored
+	if ticket == nil {
+		return
+	}
+
+	if fields := strings.Fields(update.Message.Text); len(fields) > 0 && fields[0] == string(constants.SupportCloseCommand) {
+		h.closeTicket(ctx, ticket, b, update)
+
+		return
+	}
+
+	var authorID int64
+	if update.Message.From != nil {
+		authorID = update.Message.From.ID
+	}
+
+	messageID, err := h.supportService.AddMessage(ctx, ticket.ID, newSupportMessage(storage.SupportMessageFromSupport, authorID, update.Message))
+	if err != nil {
+		zap.L().Error("add support ticket reply error",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	userContext, err := h.supportService.GetUserContext(ctx, ticket.UserID)
+	if err != nil {
+		zap.L().Error("get support ticket user error",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	firstUserMessageID, err := h.supportService.FindFirstUserMessageID(ctx, ticket.ID)
+	if err != nil {
+		zap.L().Error("find support ticket first user message error",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	label := h.languages.GetLocalizer(userContext.Lang).MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "SupportReplyLabel",
+		TemplateData: map[string]string{
+			"ID": strconv.FormatInt(ticket.ID, 10),
+		},
+	})
+	userMessageID, err := h.copyMessage(ctx, b, userContext.ChatID, update.Message, label, firstUserMessageID)
+	if err != nil {
+		zap.L().Warn("failed to deliver support reply to user",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Int64("user_id", ticket.UserID),
+			zap.Error(err),
+		)
+
+		h.replySupport(ctx, b, update, "SupportReplyFailed", ticket.ID)
+
+		return
+	}
+
+	if err := h.supportService.SetUserMessageID(ctx, messageID, userMessageID); err != nil {
+		zap.L().Error("set support ticket reply message error",
+			zap.Int64("ticket_id", ticket.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	h.replySupport(ctx, b, update, "SupportReplyDelivered", ticket.ID)
+}
+
+func NewSupportHandler(
+	supportService *services.SupportService,
+	userActionService *services.UserActionService,
+	languages *languages.Languages,
+	supportChatID int64,
+	supportBotUsername string,
+) *SupportHandler {
+	return &SupportHandler{
+		supportService:     supportService,
+		userActionService:  userActionService,
+		languages:          languages,
+		supportChatID:      supportChatID,
+		supportBotUsername: supportBotUsername,
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/go-telegram/ui/keyboard/inline"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const USER_DATA_FILE_NAME = "mydata.json"
+
+type UserDataHandler struct {
+	privacyService *privacy.Service
+}
+
+func (h *UserDataHandler) Export(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	data, err := h.privacyService.Export(ctx, user.ID)
+	if err != nil {
+		zap.L().Error("export user data error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if _, err := b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID: user.ChatID,
+		Document: &models.InputFileUpload{
+			Filename: USER_DATA_FILE_NAME,
+			Data:     bytes.NewReader(data),
+		},
+		Caption: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "UserDataExportCaption",
+		}),
+	}); err != nil {
+		zap.L().Error("send user data export error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (h *UserDataHandler) Delete(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ConfirmUserDataDeletion",
+		}),
+		ReplyMarkup: botKeyboards.CreateConfirmInlineKeyboard(
+			b,
+			h.onConfirmDelete(user),
+			h.onCancelDelete(user),
+			user.Localizer,
+		),
+	})
+}
+
+func (h *UserDataHandler) onConfirmDelete(user *middlewares.User) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		if err := h.privacyService.Delete(ctx, user.ID, privacy.DeletionUserRequest); err != nil {
+			zap.L().Error("delete user data error",
+				zap.Int64("user_id", user.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "UserDataDeleted",
+			}),
+			ReplyMarkup: &models.ReplyKeyboardRemove{
+				RemoveKeyboard: true,
+			},
+		})
+	}
+}
+
+func (h *UserDataHandler) onCancelDelete(user *middlewares.User) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "UserDataDeletionCancelled",
+			}),
+		})
+	}
+}
+
+func NewUserDataHandler(privacyService *privacy.Service) *UserDataHandler {
+	return &UserDataHandler{
+		privacyService: privacyService,
+	}
+}
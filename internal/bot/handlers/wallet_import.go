@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	EXPORT_FILE_NAME    = "wallets.json"
+	IMPORT_REPORT_SPLIT = "\n"
+)
+
+var ErrImportFileTooLarge = errors.New("import file is too large")
+
+var importStatusMessages = map[services.WalletImportStatus]string{
+	services.WalletImportAdded:           "ImportWalletAdded",
+	services.WalletImportInvalidLine:     "ImportWalletInvalidLine",
+	services.WalletImportUnknownCoin:     "ImportWalletUnknownCoin",
+	services.WalletImportInvalidWallet:   "ImportWalletInvalidWallet",
+	services.WalletImportDuplicate:       "ImportWalletDuplicate",
+	services.WalletImportLimitExceeded:   "ImportWalletLimitExceeded",
+	services.WalletImportPoolUnavailable: "ImportWalletPoolUnavailable",
+	services.WalletImportFailed:          "ImportWalletFailed",
+}
+
+type WalletImportHandler struct {
+	walletImportService *services.WalletImportService
+	userActionService   *services.UserActionService
+	maxLines            int
+	maxFileSize         int64
+}
+
+func (h *WalletImportHandler) reply(ctx context.Context, user *middlewares.User, messageID string, b *bot.Bot) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: messageID,
+			TemplateData: map[string]string{
+				"MaxLines": user.Formatter.Count("Wallet", int64(h.maxLines)),
+			},
+		}),
+	})
+}
+
+func (h *WalletImportHandler) Start(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	if err := h.userActionService.Set(ctx, user.ID, storage.ImportWalletsAction, nil); err != nil {
+		zap.L().Error("set user import wallets action error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ImportWalletsPrompt",
+			TemplateData: map[string]string{
+				"MaxLines": user.Formatter.Count("Wallet", int64(h.maxLines)),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateBackReplyKeyboard(b, botKeyboards.WithStartKeyboardHandler(h.Back), user.Localizer),
+	})
+}
+
+func (h *WalletImportHandler) Back(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action before returning to menu from wallets import",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ReturningToMenu",
+		}),
+		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+	})
+}
+
+func (h *WalletImportHandler) download(ctx context.Context, document *models.Document, b *bot.Bot) ([]byte, error) {
+	if document.FileSize > h.maxFileSize {
+		return nil, ErrImportFileTooLarge
+	}
+
+	file, err := b.GetFile(ctx, &bot.GetFileParams{
+		FileID: document.FileID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import file (id: %s): %w", document.FileID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import file (id: %s) request: %w", document.FileID, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download import file (id: %s): %w", document.FileID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download import file (id: %s), status: %d", document.FileID, resp.StatusCode)
+	}
+
+	//	File size reported by Telegram is optional, so body is limited too
+	data, err := io.ReadAll(io.LimitReader(resp.Body, h.maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file (id: %s): %w", document.FileID, err)
+	} else if int64(len(data)) > h.maxFileSize {
+		return nil, ErrImportFileTooLarge
+	}
+
+	return data, nil
+}
+
+func (h *WalletImportHandler) formatResult(result services.WalletImportResult, user *middlewares.User) string {
+	return user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "ImportWalletsResult",
+		TemplateData: map[string]string{
+			"Line":   strconv.Itoa(result.Line),
+			"Coin":   result.Coin,
+			"Wallet": formatUtils.WalletName(result.Wallet, result.Label, user.Settings.ShortWallets),
+			"Status": user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: importStatusMessages[result.Status],
+			}),
+		},
+	})
+}
+
+func (h *WalletImportHandler) sendReport(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	results []services.WalletImportResult,
+	b *bot.Bot,
+) {
+	added := 0
+	for _, result := range results {
+		if result.Status == services.WalletImportAdded {
+			added++
+		}
+	}
+
+	var msgBuf bytes.Buffer
+	msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "ImportWalletsReport",
+		TemplateData: map[string]string{
+			"Added": user.Formatter.Number(int64(added)),
+			"Total": user.Formatter.Number(int64(len(results))),
+		},
+	}))
+
+	//	Report of large import is split into several messages
+	messages := []string{}
+	for _, result := range results {
+		line := h.formatResult(result, user)
+		if utf8.RuneCount(msgBuf.Bytes())+utf8.RuneCountInString(IMPORT_REPORT_SPLIT+line) > MAX_MESSAGE_LENGTH {
+			messages = append(messages, msgBuf.String())
+			msgBuf.Reset()
+		}
+
+		if msgBuf.Len() > 0 {
+			msgBuf.WriteString(IMPORT_REPORT_SPLIT)
+		}
+
+		msgBuf.WriteString(line)
+	}
+
+	messages = append(messages, msgBuf.String())
+	for i, text := range messages {
+		params := &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text:   text,
+		}
+		if i == len(messages)-1 {
+			params.ReplyMarkup = botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer)
+		}
+
+		b.SendMessage(ctx, params)
+	}
+}
+
+func (h *WalletImportHandler) Handler(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	data := []byte(update.Message.Text)
+	if update.Message.Document != nil {
+		fileData, err := h.download(ctx, update.Message.Document, b)
+		if errors.Is(err, ErrImportFileTooLarge) {
+			h.reply(ctx, user, "ImportWalletsFileTooLarge", b)
+
+			return
+		} else if err != nil {
+			zap.L().Error("download import file error",
+				zap.Int64("user_id", user.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		data = fileData
+	} else if strings.TrimSpace(update.Message.Text) == "" {
+		h.reply(ctx, user, "ImportWalletsEmpty", b)
+
+		return
+	}
+
+	lines, err := h.walletImportService.Parse(data)
+	if errors.Is(err, services.ErrImportEmpty) {
+		h.reply(ctx, user, "ImportWalletsEmpty", b)
+
+		return
+	} else if errors.Is(err, services.ErrImportTooManyLines) {
+		h.reply(ctx, user, "ImportWalletsTooMany", b)
+
+		return
+	} else if err != nil {
+		h.reply(ctx, user, "ImportWalletsInvalidFormat", b)
+
+		return
+	}
+
+	h.reply(ctx, user, "ImportWalletsInProgress", b)
+
+	results := h.walletImportService.Import(ctx, user.ID, lines)
+	if err := h.userActionService.Clear(ctx, user.ID); err != nil {
+		zap.L().Error("error clearing user action after importing wallets",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+	}
+
+	h.sendReport(ctx, user, startKeyboard, results, b)
+}
+
+func (h *WalletImportHandler) Export(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	data, err := h.walletImportService.Export(ctx, user.ID, services.WalletExportSettings{
+		Lang:             user.Lang,
+		PayoutsNotify:    user.Settings.PayoutsNotify,
+		BlocksNotify:     user.Settings.BlocksNotify,
+		BroadcastsNotify: user.Settings.BroadcastsNotify,
+		ShortWallets:     user.Settings.ShortWallets,
+	})
+	if err != nil {
+		zap.L().Error("export user wallets error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if _, err := b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID: update.Message.Chat.ID,
+		Document: &models.InputFileUpload{
+			Filename: EXPORT_FILE_NAME,
+			Data:     bytes.NewReader(data),
+		},
+		Caption: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ExportWalletsCaption",
+		}),
+	}); err != nil {
+		zap.L().Error("send user wallets export error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+func NewWalletImportHandler(
+	walletImportService *services.WalletImportService,
+	userActionService *services.UserActionService,
+	maxLines int,
+	maxFileSize int64,
+) *WalletImportHandler {
+	return &WalletImportHandler{
+		walletImportService: walletImportService,
+		userActionService:   userActionService,
+		maxLines:            maxLines,
+		maxFileSize:         maxFileSize,
+	}
+}
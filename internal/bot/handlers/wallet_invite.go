@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/go-telegram/ui/keyboard/inline"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const JOIN_WALLET_DEEP_LINK = "join_"
+
+type WalletInviteHandler struct {
+	walletInviteService *services.WalletInviteService
+	languages           *languages.Languages
+}
+
+func (h *WalletInviteHandler) Invite(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	wallets, err := h.walletInviteService.FindOwnedWallets(ctx, user.ID)
+	if err != nil {
+		zap.L().Error("find user owned wallets error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if len(wallets) == 0 {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "WalletInviteNoOwnedWallets",
+			}),
+		})
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "WalletInviteSelectWallet",
+		}),
+		ReplyMarkup: botKeyboards.CreateWalletInviteInlineKeyboard(
+			b,
+			wallets,
+			user.Settings.ShortWallets,
+			h.onWalletSelected(user),
+			user.Localizer,
+		),
+	})
+}
+
+func (h *WalletInviteHandler) onWalletSelected(user *middlewares.User) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		subscriptionID, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return
+		}
+
+		token, err := h.walletInviteService.Create(ctx, user.ID, subscriptionID)
+		if errors.Is(err, services.ErrWalletNotOwned) {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: user.ChatID,
+				Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "WalletInviteNoOwnedWallets",
+				}),
+			})
+
+			return
+		} else if err != nil {
+			zap.L().Error("create wallet invite error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", subscriptionID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		me, err := b.GetMe(ctx)
+		if err != nil {
+			zap.L().Error("get bot info error",
+				zap.Int64("user_id", user.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "WalletInviteLink",
+				TemplateData: map[string]string{
+					"Link": fmt.Sprintf("https://t.me/%s?start=%s%s", me.Username, JOIN_WALLET_DEEP_LINK, token),
+					"Days": user.Formatter.Days(int(services.WALLET_INVITE_TTL.Hours() / 24)),
+				},
+			}),
+		})
+	}
+}
+
+func (h *WalletInviteHandler) Join(
+	ctx context.Context,
+	user *middlewares.User,
+	startKeyboard *botKeyboards.StartKeyboard,
+	token string,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	invite, err := h.walletInviteService.Find(ctx, token)
+	if err != nil {
+		zap.L().Error("find wallet invite error",
+			zap.Int64("user_id", user.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if invite == nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "WalletInviteInvalid",
+			}),
+			ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+		})
+
+		return
+	}
+
+	status, err := h.walletInviteService.Accept(ctx, user.ID, invite)
+	if errors.Is(err, services.ErrWalletsLimitExceeded) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "ExceededWalletsLimit",
+			}),
+			ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+		})
+
+		return
+	} else if err != nil {
+		zap.L().Error("accept wallet invite error",
+			zap.Int64("user_id", user.ID),
+			zap.Int64("wallet_id", invite.WalletID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	if status != storage.WalletInviteAccepted {
+		msgID := "WalletAlreadyAdded"
+		if status == storage.WalletInviteUsed {
+			msgID = "WalletInviteInvalid"
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: msgID,
+			}),
+			ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+		})
+
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "WalletInviteAccepted",
+			TemplateData: map[string]string{
+				"Coin":   invite.Coin,
+				"Wallet": invite.Wallet,
+				"Role":   string(invite.Role),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateStartReplyKeyboard(b, startKeyboard, user.Localizer),
+	})
+
+	//	Owner is told who got access to the wallet
+	if invite.CreatedBy != user.ID {
+		username := update.Message.From.Username
+		if username == "" {
+			username = update.Message.From.FirstName
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: invite.CreatorChatID,
+			Text: h.languages.GetLocalizer(invite.CreatorLang).MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "WalletInviteJoined",
+				TemplateData: map[string]string{
+					"User":   username,
+					"Coin":   invite.Coin,
+					"Wallet": invite.Wallet,
+				},
+			}),
+		})
+	}
+}
+
+func NewWalletInviteHandler(walletInviteService *services.WalletInviteService, languages *languages.Languages) *WalletInviteHandler {
+	return &WalletInviteHandler{
+		walletInviteService: walletInviteService,
+		languages:           languages,
+	}
+}
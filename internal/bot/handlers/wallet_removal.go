@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/go-telegram/ui/keyboard/inline"
+	botKeyboards "github.com/grandminingpool/telegram-bot/internal/bot/keyboards"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+type walletRemoval struct {
+	userWalletService *services.UserWalletService
+}
+
+func (r *walletRemoval) reply(ctx context.Context, user *middlewares.User, messageID string, b *bot.Bot) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: messageID,
+		}),
+	})
+}
+
+func (r *walletRemoval) confirm(ctx context.Context, user *middlewares.User, wallet *storage.UserWalletDB, b *bot.Bot) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: user.ChatID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ConfirmWalletRemoval",
+			TemplateData: map[string]string{
+				"Coin":   wallet.Coin,
+				"Wallet": formatUtils.WalletName(wallet.Wallet, wallet.Label, user.Settings.ShortWallets),
+			},
+		}),
+		ReplyMarkup: botKeyboards.CreateConfirmInlineKeyboard(
+			b,
+			r.onConfirm(user, wallet),
+			r.onCancel(user),
+			user.Localizer,
+		),
+	})
+}
+
+func (r *walletRemoval) onConfirm(user *middlewares.User, wallet *storage.UserWalletDB) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		//	Worker names are removed with wallet, so they are kept for undo
+		aliases, err := r.userWalletService.FindAliases(ctx, user.ID, wallet.ID)
+		if err != nil {
+			zap.L().Error("find user wallet worker aliases error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		if err := r.userWalletService.Remove(ctx, wallet.ID); err != nil {
+			zap.L().Error("remove user wallet error",
+				zap.Int64("user_id", user.ID),
+				zap.Int64("wallet_id", wallet.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: user.ChatID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "WalletRemoved",
+			}),
+			ReplyMarkup: botKeyboards.CreateUndoInlineKeyboard(b, r.onUndo(user, wallet, aliases), user.Localizer),
+		})
+	}
+}
+
+func (r *walletRemoval) onCancel(user *middlewares.User) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		r.reply(ctx, user, "WalletRemovalCancelled", b)
+	}
+}
+
+func (r *walletRemoval) onUndo(user *middlewares.User, wallet *storage.UserWalletDB, aliases map[string]string) inline.OnSelect {
+	return func(ctx context.Context, b *bot.Bot, mes models.MaybeInaccessibleMessage, data []byte) {
+		if err := r.userWalletService.Restore(ctx, user.ID, wallet, aliases); err != nil {
+			zap.L().Error("restore user wallet error",
+				zap.Int64("user_id", user.ID),
+				zap.String("coin", wallet.Coin),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		r.reply(ctx, user, "WalletRestored", b)
+	}
+}
@@ -0,0 +1,88 @@
+package botKeyboards
+
+import (
+	"sort"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/ui/keyboard/reply"
+	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+const (
+	BROADCAST_KEYBOARD_PREFIX = "broadcast"
+	BROADCAST_KEYBOARD_COLS   = 2
+)
+
+type OnBroadcastLanguageHandlerFunc func(language.Tag) middlewares.UserHandlerFunc
+
+type BroadcastKeyboard struct {
+	localizers          []languages.LocalizersItem
+	onAddLanguage       OnBroadcastLanguageHandlerFunc
+	onRecipientsHandler middlewares.UserHandlerFunc
+	onSendHandler       middlewares.UserHandlerFunc
+	onCancelHandler     middlewares.UserHandlerFunc
+}
+
+func CreateBroadcastKeyboard(
+	localizers []languages.LocalizersItem,
+	onAddLanguage OnBroadcastLanguageHandlerFunc,
+	onRecipientsHandler middlewares.UserHandlerFunc,
+	onSendHandler middlewares.UserHandlerFunc,
+	onCancelHandler middlewares.UserHandlerFunc,
+) *BroadcastKeyboard {
+	sortedLocalizers := make([]languages.LocalizersItem, len(localizers))
+	copy(sortedLocalizers, localizers)
+	sort.Slice(sortedLocalizers, func(i, j int) bool {
+		return sortedLocalizers[i].Tag.String() < sortedLocalizers[j].Tag.String()
+	})
+
+	return &BroadcastKeyboard{
+		localizers:          sortedLocalizers,
+		onAddLanguage:       onAddLanguage,
+		onRecipientsHandler: onRecipientsHandler,
+		onSendHandler:       onSendHandler,
+		onCancelHandler:     onCancelHandler,
+	}
+}
+
+func CreateBroadcastReplyKeyboard(b *bot.Bot, broadcastKeyboard *BroadcastKeyboard, messages map[string]string, localizer *i18n.Localizer) *reply.ReplyKeyboard {
+	replyKeyboard := reply.New(b, reply.IsSelective(), reply.WithPrefix(BROADCAST_KEYBOARD_PREFIX)).Row()
+	cols := 0
+	for _, l := range broadcastKeyboard.localizers {
+		if _, ok := messages[l.Tag.String()]; ok {
+			continue
+		}
+
+		replyKeyboard = replyKeyboard.Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastAddLanguageButton",
+			TemplateData: map[string]string{
+				"Language": l.Localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "Language",
+				}),
+			},
+		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(broadcastKeyboard.onAddLanguage(l.Tag)))
+		cols++
+		if cols == BROADCAST_KEYBOARD_COLS {
+			replyKeyboard = replyKeyboard.Row()
+			cols = 0
+		}
+	}
+
+	if cols > 0 {
+		replyKeyboard = replyKeyboard.Row()
+	}
+
+	return replyKeyboard.
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastRecipientsButton",
+		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(broadcastKeyboard.onRecipientsHandler)).Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastSendButton",
+		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(broadcastKeyboard.onSendHandler)).
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "BroadcastCancelButton",
+		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(broadcastKeyboard.onCancelHandler)).Row()
+}
@@ -0,0 +1,56 @@
+package botKeyboards
+
+import (
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/ui/keyboard/inline"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+func CreateChatTargetInlineKeyboard(
+	b *bot.Bot,
+	onWallets inline.OnSelect,
+	onUnlink inline.OnSelect,
+	localizer *i18n.Localizer,
+) *inline.Keyboard {
+	return inline.New(b).
+		Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ChatTargetWalletsButton",
+		}), nil, onWallets).
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ChatTargetUnlinkButton",
+		}), nil, onUnlink)
+}
+
+func CreateChatTargetWalletsInlineKeyboard(
+	b *bot.Bot,
+	wallets []storage.ChatTargetWalletDB,
+	shortWallets bool,
+	onToggle inline.OnSelect,
+	onDone inline.OnSelect,
+	localizer *i18n.Localizer,
+) *inline.Keyboard {
+	keyboard := inline.New(b)
+	for _, wallet := range wallets {
+		msgID := "ChatTargetWalletDisabled"
+		if wallet.Enabled {
+			msgID = "ChatTargetWalletEnabled"
+		}
+
+		keyboard = keyboard.Row().Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: msgID,
+			TemplateData: map[string]string{
+				"Coin":   wallet.Coin,
+				"Wallet": formatUtils.WalletName(wallet.Wallet, wallet.Label, shortWallets),
+			},
+		}), []byte(strconv.FormatInt(wallet.ID, 10)), onToggle)
+	}
+
+	return keyboard.Row().Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "ChatTargetWalletsDoneButton",
+	}), nil, onDone)
+}
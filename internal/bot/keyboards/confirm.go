@@ -0,0 +1,23 @@
+package botKeyboards
+
+import (
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/ui/keyboard/inline"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+func CreateConfirmInlineKeyboard(
+	b *bot.Bot,
+	onConfirm inline.OnSelect,
+	onCancel inline.OnSelect,
+	localizer *i18n.Localizer,
+) *inline.Keyboard {
+	return inline.New(b).
+		Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "Yes",
+		}), nil, onConfirm).
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "No",
+		}), nil, onCancel)
+}
@@ -0,0 +1,108 @@
+package botKeyboards
+
+import (
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/ui/keyboard/inline"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+func CreateManageWalletsInlineKeyboard(
+	b *bot.Bot,
+	wallets []storage.UserWalletDB,
+	shortWallets bool,
+	onSelect inline.OnSelect,
+	localizer *i18n.Localizer,
+) *inline.Keyboard {
+	keyboard := inline.New(b)
+	for _, wallet := range wallets {
+		keyboard = keyboard.Row().Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletButton",
+			TemplateData: map[string]string{
+				"Coin":   wallet.Coin,
+				"Wallet": formatUtils.WalletName(wallet.Wallet, wallet.Label, shortWallets),
+			},
+		}), []byte(strconv.FormatInt(wallet.ID, 10)), onSelect)
+	}
+
+	return keyboard
+}
+
+func CreateManageWalletInlineKeyboard(
+	b *bot.Bot,
+	wallet *storage.UserWalletDB,
+	onDetails inline.OnSelect,
+	onRename inline.OnSelect,
+	onWorkers inline.OnSelect,
+	onPayouts inline.OnSelect,
+	onAliases inline.OnSelect,
+	onNotify inline.OnSelect,
+	onPause inline.OnSelect,
+	onRemove inline.OnSelect,
+	localizer *i18n.Localizer,
+) *inline.Keyboard {
+	notifyMessageID := "ManageWalletEnableNotifyButton"
+	if wallet.Notify {
+		notifyMessageID = "ManageWalletDisableNotifyButton"
+	}
+
+	pauseMessageID := "ManageWalletPauseButton"
+	if wallet.Paused {
+		pauseMessageID = "ManageWalletResumeButton"
+	}
+
+	return inline.New(b).
+		Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletDetailsButton",
+		}), nil, onDetails).
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletRenameButton",
+		}), nil, onRename).
+		Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletWorkersButton",
+		}), nil, onWorkers).
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletPayoutsButton",
+		}), nil, onPayouts).
+		Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletAliasesButton",
+		}), nil, onAliases).
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: notifyMessageID,
+		}), nil, onNotify).
+		Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: pauseMessageID,
+		}), nil, onPause).
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "ManageWalletRemoveButton",
+		}), nil, onRemove)
+}
+
+func CreateUndoInlineKeyboard(b *bot.Bot, onUndo inline.OnSelect, localizer *i18n.Localizer) *inline.Keyboard {
+	return inline.New(b).
+		Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "UndoButton",
+		}), nil, onUndo)
+}
+
+func CreateWorkerAliasesInlineKeyboard(
+	b *bot.Bot,
+	workers []storage.WorkerAliasDB,
+	onSelect inline.OnSelect,
+) *inline.Keyboard {
+	keyboard := inline.New(b)
+	//	Worker names may exceed callback data limit, so their index is passed
+	for i, worker := range workers {
+		keyboard = keyboard.Row().Button(formatUtils.WorkerName(worker.Worker, worker.Alias), []byte(strconv.Itoa(i)), onSelect)
+	}
+
+	return keyboard
+}
@@ -26,6 +26,8 @@ type SettingsKeyboard struct {
 	languagesKeyboard *LanguagesKeyboard
 	payoutsNotify     bool
 	blocksNotify      bool
+	broadcastsNotify  bool
+	shortWallets      bool
 }
 
 func (k *SettingsKeyboard) IsPayoutsNotify() bool {
@@ -36,6 +38,14 @@ func (k *SettingsKeyboard) IsBlocksNotify() bool {
 	return k.blocksNotify
 }
 
+func (k *SettingsKeyboard) IsBroadcastsNotify() bool {
+	return k.broadcastsNotify
+}
+
+func (k *SettingsKeyboard) IsShortWallets() bool {
+	return k.shortWallets
+}
+
 func (k *SettingsKeyboard) TogglePayoutsNotify(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
 	newPayoutsNotify := !k.payoutsNotify
 
@@ -96,6 +106,68 @@ func (k *SettingsKeyboard) ToggleBlocksNotify(ctx context.Context, user *middlew
 	})
 }
 
+func (k *SettingsKeyboard) ToggleBroadcastsNotify(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	newBroadcastsNotify := !k.broadcastsNotify
+
+	if err := k.userService.SetBroadcastsNotify(ctx, user.ID, newBroadcastsNotify); err != nil {
+		zap.L().Error("update user broadcasts notify error",
+			zap.Int64("user_id", user.ID),
+			zap.Bool("broadcasts_notify", newBroadcastsNotify),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	k.broadcastsNotify = newBroadcastsNotify
+
+	var msgID string
+	if newBroadcastsNotify {
+		msgID = "BroadcastsNotificationsEnabled"
+	} else {
+		msgID = "BroadcastsNotificationsDisabled"
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: msgID,
+		}),
+		ReplyMarkup: CreateSettingsReplyKeyboard(b, k, user.Localizer),
+	})
+}
+
+func (k *SettingsKeyboard) ToggleShortWallets(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
+	newShortWallets := !k.shortWallets
+
+	if err := k.userService.SetShortWallets(ctx, user.ID, newShortWallets); err != nil {
+		zap.L().Error("update user short wallets error",
+			zap.Int64("user_id", user.ID),
+			zap.Bool("short_wallets", newShortWallets),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	k.shortWallets = newShortWallets
+
+	var msgID string
+	if newShortWallets {
+		msgID = "ShortWalletsEnabled"
+	} else {
+		msgID = "ShortWalletsDisabled"
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: msgID,
+		}),
+		ReplyMarkup: CreateSettingsReplyKeyboard(b, k, user.Localizer),
+	})
+}
+
 func (k *SettingsKeyboard) ShowLanguages(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
@@ -117,7 +189,7 @@ func (k *SettingsKeyboard) Back(ctx context.Context, user *middlewares.User, b *
 }
 
 func CreateSettingsReplyKeyboard(b *bot.Bot, settingsKeyboard *SettingsKeyboard, localizer *i18n.Localizer) *reply.ReplyKeyboard {
-	var payoutsNotifyMsgID, blocksNotifyMsgID string
+	var payoutsNotifyMsgID, blocksNotifyMsgID, broadcastsNotifyMsgID, shortWalletsMsgID string
 
 	if settingsKeyboard.IsPayoutsNotify() {
 		payoutsNotifyMsgID = "SettingsDisablePayoutsNotifyButton"
@@ -126,11 +198,23 @@ func CreateSettingsReplyKeyboard(b *bot.Bot, settingsKeyboard *SettingsKeyboard,
 	}
 
 	if settingsKeyboard.IsBlocksNotify() {
-		blocksNotifyMsgID = "SettingsEnablePayoutsNotifyButton"
+		blocksNotifyMsgID = "SettingsDisableBlocksNotifyButton"
 	} else {
 		blocksNotifyMsgID = "SettingsEnableBlocksNotifyButton"
 	}
 
+	if settingsKeyboard.IsBroadcastsNotify() {
+		broadcastsNotifyMsgID = "SettingsDisableBroadcastsNotifyButton"
+	} else {
+		broadcastsNotifyMsgID = "SettingsEnableBroadcastsNotifyButton"
+	}
+
+	if settingsKeyboard.IsShortWallets() {
+		shortWalletsMsgID = "SettingsDisableShortWalletsButton"
+	} else {
+		shortWalletsMsgID = "SettingsEnableShortWalletsButton"
+	}
+
 	return reply.New(b, reply.IsSelective(), reply.WithPrefix(SETTINGS_KEYBOARD_PREFIX)).Row().
 		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
 			MessageID: payoutsNotifyMsgID,
@@ -138,6 +222,12 @@ func CreateSettingsReplyKeyboard(b *bot.Bot, settingsKeyboard *SettingsKeyboard,
 		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
 			MessageID: blocksNotifyMsgID,
 		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(settingsKeyboard.ToggleBlocksNotify)).Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: broadcastsNotifyMsgID,
+		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(settingsKeyboard.ToggleBroadcastsNotify)).Row().
+		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: shortWalletsMsgID,
+		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(settingsKeyboard.ToggleShortWallets)).Row().
 		Button(localizer.MustLocalize(&i18n.LocalizeConfig{
 			MessageID: "SettingsLanguageButton",
 		}), b, bot.MatchTypeExact, middlewares.WithUserHandler(settingsKeyboard.ShowLanguages)).Row().
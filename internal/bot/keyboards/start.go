@@ -7,6 +7,7 @@ import (
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 	"github.com/go-telegram/ui/keyboard/reply"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
 	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
 	"github.com/grandminingpool/telegram-bot/internal/bot/services"
 	"github.com/grandminingpool/telegram-bot/internal/common/types"
@@ -79,8 +80,28 @@ func (k *StartKeyboard) RemoveWallet(ctx context.Context, user *middlewares.User
 	}
 }
 
+func (k *StartKeyboard) sendUnavailablePools(
+	ctx context.Context,
+	user *middlewares.User,
+	unavailablePools []*blockchains.BlockchainInfo,
+	b *bot.Bot,
+	update *models.Update,
+) {
+	for _, blockchain := range unavailablePools {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "PoolTemporarilyUnavailable",
+				TemplateData: map[string]string{
+					"PoolBlockchainName": blockchain.Name,
+				},
+			}),
+		})
+	}
+}
+
 func (k *StartKeyboard) ShowWallets(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
-	wallets, err := k.userWalletService.FindWallets(ctx, user.ID)
+	wallets, unavailablePools, err := k.userWalletService.FindWallets(ctx, user.ID)
 	if err != nil {
 		zap.L().Error("find user wallets error",
 			zap.Int64("user_id", user.ID),
@@ -90,7 +111,7 @@ func (k *StartKeyboard) ShowWallets(ctx context.Context, user *middlewares.User,
 		return
 	}
 
-	if len(wallets) == 0 {
+	if len(wallets) == 0 && len(unavailablePools) == 0 {
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
 			Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
@@ -103,12 +124,12 @@ func (k *StartKeyboard) ShowWallets(ctx context.Context, user *middlewares.User,
 			msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 				MessageID: "WalletInfo",
 				TemplateData: map[string]string{
-					"Wallet":             wallet.Wallet,
+					"Wallet":             formatUtils.WalletName(wallet.Wallet, wallet.Label, user.Settings.ShortWallets),
 					"PoolBlockchainName": wallet.Pool.Blockchain.Name,
 				},
 			}))
 			msgBuf.WriteString("\n\n")
-			balanceText := formatUtils.WalletBalance(wallet.Balance, wallet.Pool.Blockchain.AtomicUnit)
+			balanceText := user.Formatter.Amount(wallet.Balance, wallet.Pool.Blockchain.Decimals, wallet.Pool.Blockchain.DisplayPrecision)
 			msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 				MessageID: "WalletBalance",
 				TemplateData: map[string]string{
@@ -118,13 +139,14 @@ func (k *StartKeyboard) ShowWallets(ctx context.Context, user *middlewares.User,
 			}))
 
 			if wallet.Pool.MinPayout != nil {
-				minPayoutText := formatUtils.WalletBalance(*wallet.Pool.MinPayout, wallet.Pool.Blockchain.AtomicUnit)
+				minPayoutText := user.Formatter.Amount(*wallet.Pool.MinPayout, wallet.Pool.Blockchain.Decimals, wallet.Pool.Blockchain.DisplayPrecision)
 				msgBuf.WriteString("\n\n")
 				msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 					MessageID: "WalletLeftForPayment",
 					TemplateData: map[string]string{
 						"Balance":   balanceText,
 						"MinPayout": minPayoutText,
+						"Ticker":    wallet.Pool.Blockchain.Ticker,
 					},
 				}))
 			}
@@ -136,11 +158,13 @@ func (k *StartKeyboard) ShowWallets(ctx context.Context, user *middlewares.User,
 
 			msgBuf.Reset()
 		}
+
+		k.sendUnavailablePools(ctx, user, unavailablePools, b, update)
 	}
 }
 
 func (k *StartKeyboard) ShowWorkers(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
-	workers, err := k.userWalletService.FindWorkers(ctx, user.ID)
+	workers, unavailablePools, err := k.userWalletService.FindWorkers(ctx, user.ID)
 	if err != nil {
 		zap.L().Error("find user workers error",
 			zap.Int64("user_id", user.ID),
@@ -157,13 +181,15 @@ func (k *StartKeyboard) ShowWorkers(ctx context.Context, user *middlewares.User,
 				MessageID: "UserHasNoActiveWorkers",
 			}),
 		})
+
+		k.sendUnavailablePools(ctx, user, unavailablePools, b, update)
 	} else {
 		var msgBuf bytes.Buffer
 		for _, worker := range workers {
 			msgBuf.WriteString(user.Localizer.MustLocalize(&i18n.LocalizeConfig{
 				MessageID: "WalletInfo",
 				TemplateData: map[string]string{
-					"Wallet":             worker.Wallet,
+					"Wallet":             formatUtils.WalletName(worker.Wallet, worker.Label, user.Settings.ShortWallets),
 					"PoolBlockchainName": worker.Pool.Blockchain.Name,
 				},
 			}))
@@ -172,10 +198,10 @@ func (k *StartKeyboard) ShowWorkers(ctx context.Context, user *middlewares.User,
 				MessageID: "WorkerInfo",
 				TemplateData: map[string]string{
 					"Region":   worker.Region,
-					"Worker":   worker.Worker,
+					"Worker":   formatUtils.WorkerName(worker.Worker, worker.Alias),
 					"Solo":     formatUtils.BoolText(worker.Solo, user.Localizer),
-					"Hashrate": formatUtils.Hashrate(worker.Hashrate),
-					"Uptime":   formatUtils.UptimeText(worker.ConnectedAt, user.Localizer),
+					"Hashrate": user.Formatter.Hashrate(worker.Hashrate),
+					"Uptime":   user.Formatter.Uptime(worker.ConnectedAt),
 				},
 			}))
 
@@ -186,6 +212,8 @@ func (k *StartKeyboard) ShowWorkers(ctx context.Context, user *middlewares.User,
 
 			msgBuf.Reset()
 		}
+
+		k.sendUnavailablePools(ctx, user, unavailablePools, b, update)
 	}
 }
 
@@ -206,6 +234,8 @@ func (k *StartKeyboard) ShowSettings(ctx context.Context, user *middlewares.User
 		languagesKeyboard: k.languagesKeyboard,
 		payoutsNotify:     user.Settings.PayoutsNotify,
 		blocksNotify:      user.Settings.BlocksNotify,
+		broadcastsNotify:  user.Settings.BroadcastsNotify,
+		shortWallets:      user.Settings.ShortWallets,
 	}
 
 	newCtx := context.WithValue(ctx, SETTINGS_KEYBOARD_CTX_KEY, userSettingsKeyboard)
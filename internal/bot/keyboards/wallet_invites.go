@@ -0,0 +1,32 @@
+package botKeyboards
+
+import (
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/ui/keyboard/inline"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+func CreateWalletInviteInlineKeyboard(
+	b *bot.Bot,
+	wallets []storage.UserWalletDB,
+	shortWallets bool,
+	onSelect inline.OnSelect,
+	localizer *i18n.Localizer,
+) *inline.Keyboard {
+	keyboard := inline.New(b)
+	for _, wallet := range wallets {
+		keyboard = keyboard.Row().Button(localizer.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: "WalletInviteButton",
+			TemplateData: map[string]string{
+				"Coin":   wallet.Coin,
+				"Wallet": formatUtils.WalletName(wallet.Wallet, wallet.Label, shortWallets),
+			},
+		}), []byte(strconv.FormatInt(wallet.ID, 10)), onSelect)
+	}
+
+	return keyboard
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/grandminingpool/telegram-bot/internal/bot/middlewares"
 	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
@@ -21,13 +22,18 @@ type OnWalletSelectedWithStartKeyboardHandlerFunc func(context.Context, *middlew
 
 type WalletsKeyboard struct {
 	wallets         []services.UserWalletInfo
+	shortWallets    bool
 	onSelectHandler OnWalletSelectedHandlerFunc
 	onBackHandler   middlewares.UserHandlerFunc
 }
 
+func (k *WalletsKeyboard) walletName(wallet services.UserWalletInfo) string {
+	return formatUtils.WalletName(wallet.Wallet, wallet.Label, k.shortWallets)
+}
+
 func (k *WalletsKeyboard) OnWalletSelected(ctx context.Context, user *middlewares.User, b *bot.Bot, update *models.Update) {
 	idx := slices.IndexFunc(k.wallets, func(wallet services.UserWalletInfo) bool {
-		return wallet.Wallet == update.Message.Text
+		return k.walletName(wallet) == update.Message.Text
 	})
 	if idx != -1 {
 		wallet := k.wallets[idx]
@@ -38,10 +44,12 @@ func (k *WalletsKeyboard) OnWalletSelected(ctx context.Context, user *middleware
 
 func CreateWalletsKeyboard(
 	wallets []services.UserWalletInfo,
+	shortWallets bool,
 	onSelectHandler OnWalletSelectedHandlerFunc,
 	onBackHandler middlewares.UserHandlerFunc) *WalletsKeyboard {
 	return &WalletsKeyboard{
 		wallets:         wallets,
+		shortWallets:    shortWallets,
 		onSelectHandler: onSelectHandler,
 		onBackHandler:   onBackHandler,
 	}
@@ -50,7 +58,7 @@ func CreateWalletsKeyboard(
 func CreateWalletsReplyKeyboard(b *bot.Bot, walletsKeyboard *WalletsKeyboard, localizer *i18n.Localizer) *reply.ReplyKeyboard {
 	replyKeyboard := reply.New(b, reply.IsSelective(), reply.WithPrefix(WALLETS_KEYBOARD_PREFIX)).Row()
 	for _, wallet := range walletsKeyboard.wallets {
-		replyKeyboard = replyKeyboard.Button(wallet.Wallet, b, bot.MatchTypeExact, middlewares.WithUserHandler(walletsKeyboard.OnWalletSelected)).Row()
+		replyKeyboard = replyKeyboard.Button(walletsKeyboard.walletName(wallet), b, bot.MatchTypeExact, middlewares.WithUserHandler(walletsKeyboard.OnWalletSelected)).Row()
 	}
 
 	return replyKeyboard.Button(localizer.MustLocalize(&i18n.LocalizeConfig{
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+type AdminMiddleware struct {
+	adminService *services.AdminService
+}
+
+func (m *AdminMiddleware) Handler(next UserHandlerFunc) UserHandlerFunc {
+	return func(ctx context.Context, user *User, b *bot.Bot, update *models.Update) {
+		isAdmin, err := m.adminService.IsAdmin(ctx, user.ID)
+		if err != nil {
+			zap.L().Error("check user admin role error",
+				zap.Int64("user_id", user.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		if !isAdmin {
+			zap.L().Warn("user without admin role tried to run admin command",
+				zap.Int64("user_id", user.ID),
+				zap.String("command", update.Message.Text),
+			)
+
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: update.Message.Chat.ID,
+				Text: user.Localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "DefaultMessage",
+				}),
+			})
+
+			return
+		}
+
+		next(ctx, user, b, update)
+	}
+}
+
+func CreateAdminMiddleware(adminService *services.AdminService) *AdminMiddleware {
+	return &AdminMiddleware{
+		adminService: adminService,
+	}
+}
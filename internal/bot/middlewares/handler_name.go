@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/common/types"
+)
+
+const (
+	HANDLER_NAME_CTX_KEY types.CtxKey = "handlerName"
+	DEFAULT_HANDLER_NAME              = "other"
+)
+
+type handlerName struct {
+	name string
+}
+
+func UpdateType(update *models.Update) string {
+	switch {
+	case update.Message != nil:
+		return "message"
+	case update.CallbackQuery != nil:
+		return "callback_query"
+	case update.InlineQuery != nil:
+		return "inline_query"
+	case update.MyChatMember != nil:
+		return "my_chat_member"
+	case update.ChannelPost != nil:
+		return "channel_post"
+	default:
+		return "other"
+	}
+}
+
+// Handler name holder is shared between metrics and tracing middlewares,
+// it's filled later by the handler registered with WithHandlerName
+func withHandlerName(ctx context.Context) (context.Context, *handlerName) {
+	if holder, ok := ctx.Value(HANDLER_NAME_CTX_KEY).(*handlerName); ok {
+		return ctx, holder
+	}
+
+	holder := &handlerName{name: DEFAULT_HANDLER_NAME}
+
+	return context.WithValue(ctx, HANDLER_NAME_CTX_KEY, holder), holder
+}
+
+func WithHandlerName(name string, handler bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if holder, ok := ctx.Value(HANDLER_NAME_CTX_KEY).(*handlerName); ok {
+			holder.name = name
+		}
+
+		handler(ctx, b, update)
+	}
+}
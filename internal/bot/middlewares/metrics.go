@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
+)
+
+type MetricsMiddleware struct{}
+
+func (m *MetricsMiddleware) Middleware(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		ctx, name := withHandlerName(ctx)
+		start := time.Now()
+
+		next(ctx, b, update)
+
+		metrics.UpdatesTotal.WithLabelValues(name.name, UpdateType(update)).Inc()
+		metrics.HandlerDuration.WithLabelValues(name.name).Observe(time.Since(start).Seconds())
+	}
+}
+
+func CreateMetricsMiddleware() *MetricsMiddleware {
+	return &MetricsMiddleware{}
+}
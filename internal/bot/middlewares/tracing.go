@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddleware struct{}
+
+func updateAttributes(update *models.Update) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.Int64("telegram.update_id", update.ID),
+		attribute.String("telegram.update_type", UpdateType(update)),
+	}
+
+	switch {
+	case update.Message != nil:
+		attrs = append(attrs, attribute.Int64("telegram.chat_id", update.Message.Chat.ID))
+		if update.Message.From != nil {
+			attrs = append(attrs, attribute.Int64("telegram.user_id", update.Message.From.ID))
+		}
+	case update.CallbackQuery != nil:
+		attrs = append(attrs, attribute.Int64("telegram.user_id", update.CallbackQuery.From.ID))
+	case update.InlineQuery != nil && update.InlineQuery.From != nil:
+		attrs = append(attrs, attribute.Int64("telegram.user_id", update.InlineQuery.From.ID))
+	}
+
+	return attrs
+}
+
+func (m *TracingMiddleware) Middleware(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		ctx, name := withHandlerName(ctx)
+		ctx, span := tracing.Tracer().Start(ctx, "telegram.update",
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(updateAttributes(update)...),
+		)
+		defer span.End()
+
+		next(ctx, b, update)
+
+		span.SetName("telegram.update " + name.name)
+		span.SetAttributes(attribute.String("telegram.handler", name.name))
+	}
+}
+
+func CreateTracingMiddleware() *TracingMiddleware {
+	return &TracingMiddleware{}
+}
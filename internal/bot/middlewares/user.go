@@ -8,29 +8,48 @@ import (
 	"github.com/grandminingpool/telegram-bot/internal/bot/services"
 	"github.com/grandminingpool/telegram-bot/internal/common/languages"
 	"github.com/grandminingpool/telegram-bot/internal/common/types"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"go.uber.org/zap"
 )
 
-const USER_CTX_KEY types.CtxKey = "botUser"
+const (
+	USER_CTX_KEY      types.CtxKey = "botUser"
+	PRIVATE_CHAT_TYPE              = "private"
+)
 
 type UserSettings struct {
-	PayoutsNotify bool
-	BlocksNotify  bool
+	PayoutsNotify    bool
+	BlocksNotify     bool
+	BroadcastsNotify bool
+	ShortWallets     bool
 }
 
 type UserAction struct {
-	Action  services.UserAction
+	Action  storage.UserAction
 	Payload *string
 }
 
+type UserChat struct {
+	ID       int64
+	Type     string
+	ThreadID int
+}
+
 type User struct {
 	ID        int64
 	ChatID    int64
 	Lang      string
 	Localizer *i18n.Localizer
+	Formatter *formatUtils.Formatter
 	Settings  UserSettings
 	Action    *UserAction
+	Chat      UserChat
+}
+
+func (u *User) IsPrivateChat() bool {
+	return u.Chat.Type == PRIVATE_CHAT_TYPE
 }
 
 type UserHandlerFunc func(context.Context, *User, *bot.Bot, *models.Update)
@@ -41,55 +60,84 @@ type UserMiddleware struct {
 	languages         *languages.Languages
 }
 
+func (m *UserMiddleware) getUser(ctx context.Context, message *models.Message) (*storage.UserDB, error) {
+	if message.Chat.Type == PRIVATE_CHAT_TYPE {
+		return m.userService.Init(ctx, message.From, message.Chat.ID)
+	}
+
+	//	In groups sender is only looked up, group chat must not replace user private chat
+	return m.userService.Find(ctx, message.From.ID)
+}
+
 func (m *UserMiddleware) Middleware(next bot.HandlerFunc) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
-		if update.Message != nil {
-			user, err := m.userService.Init(ctx, update.Message.From, update.Message.Chat.ID)
-			if err != nil {
-				zap.L().Error("init user error",
-					zap.Int64("user_id", update.Message.From.ID),
-					zap.Error(err),
-				)
+		//	Messages from channels and anonymous group admins have no sender user
+		if update.Message == nil || update.Message.From == nil || update.Message.SenderChat != nil {
+			next(ctx, b, update)
 
-				next(ctx, b, update)
+			return
+		}
 
-				return
-			}
+		user, err := m.getUser(ctx, update.Message)
+		if err != nil {
+			zap.L().Error("init user error",
+				zap.Int64("user_id", update.Message.From.ID),
+				zap.Int64("chat_id", update.Message.Chat.ID),
+				zap.Error(err),
+			)
 
-			userAction, err := m.userActionService.Get(ctx, user.ID)
-			if err != nil {
-				zap.L().Error("get user action error",
-					zap.Int64("user_id", user.ID),
-					zap.Error(err),
-				)
-			}
+			next(ctx, b, update)
 
-			userLocalizer := m.languages.GetLocalizer(user.Lang)
-
-			userCtx := &User{
-				ID:        user.ID,
-				Lang:      user.Lang,
-				Localizer: userLocalizer,
-				Settings: UserSettings{
-					PayoutsNotify: user.PayoutsNotify,
-					BlocksNotify:  user.BlocksNotify,
-				},
-				Action: nil,
-			}
+			return
+		} else if user == nil {
+			next(ctx, b, update)
 
-			if userAction != nil {
-				userCtx.Action = &UserAction{
-					Action:  userAction.Action,
-					Payload: userAction.Payload,
-				}
-			}
+			return
+		}
 
-			newCtx := context.WithValue(ctx, USER_CTX_KEY, userCtx)
+		userAction, err := m.userActionService.Get(ctx, user.ID)
+		if err != nil {
+			zap.L().Error("get user action error",
+				zap.Int64("user_id", user.ID),
+				zap.Error(err),
+			)
+		}
 
-			next(newCtx, b, update)
-		} else {
-			next(ctx, b, update)
+		userLocalizer := m.languages.GetLocalizer(user.Lang)
+
+		userCtx := &User{
+			ID:        user.ID,
+			ChatID:    user.ChatID,
+			Lang:      user.Lang,
+			Localizer: userLocalizer,
+			Formatter: formatUtils.NewFormatter(userLocalizer),
+			Settings: UserSettings{
+				PayoutsNotify:    user.PayoutsNotify,
+				BlocksNotify:     user.BlocksNotify,
+				BroadcastsNotify: user.BroadcastsNotify,
+				ShortWallets:     user.ShortWallets,
+			},
+			Action: nil,
+			Chat: UserChat{
+				ID:   update.Message.Chat.ID,
+				Type: update.Message.Chat.Type,
+			},
+		}
+
+		if update.Message.IsTopicMessage {
+			userCtx.Chat.ThreadID = update.Message.MessageThreadID
 		}
+
+		if userAction != nil {
+			userCtx.Action = &UserAction{
+				Action:  userAction.Action,
+				Payload: userAction.Payload,
+			}
+		}
+
+		newCtx := context.WithValue(ctx, USER_CTX_KEY, userCtx)
+
+		next(newCtx, b, update)
 	}
 }
 
@@ -106,6 +154,15 @@ func CreateUserMiddleware(
 }
 
 func WithUserHandler(handler UserHandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		user, ok := ctx.Value(USER_CTX_KEY).(*User)
+		if ok && user.IsPrivateChat() {
+			handler(ctx, user, b, update)
+		}
+	}
+}
+
+func WithChatUserHandler(handler UserHandlerFunc) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
 		user, ok := ctx.Value(USER_CTX_KEY).(*User)
 		if ok {
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/hashicorp/go-set/v2"
+)
+
+type BotStats struct {
+	UsersCount    int64
+	Wallets       []storage.CoinCountDB
+	ActiveWorkers []storage.CoinCountDB
+}
+
+type AdminUserInfo struct {
+	User    storage.UserDB
+	IsAdmin bool
+	Wallets []storage.AdminUserWalletDB
+}
+
+type AdminService struct {
+	admins   storage.AdminRepository
+	users    storage.UserRepository
+	adminIDs *set.Set[int64]
+}
+
+func (s *AdminService) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	if s.adminIDs.Contains(userID) {
+		return true, nil
+	}
+
+	return s.admins.IsAdmin(ctx, userID)
+}
+
+func (s *AdminService) GetAdminIDs(ctx context.Context) ([]int64, error) {
+	ids, err := s.admins.FindIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	adminIDs := s.adminIDs.Copy()
+	adminIDs.InsertSlice(ids)
+
+	return adminIDs.Slice(), nil
+}
+
+func (s *AdminService) GetStats(ctx context.Context) (*BotStats, error) {
+	usersCount, err := s.admins.CountUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.admins.CountWallets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	activeWorkers, err := s.admins.CountWorkers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BotStats{
+		UsersCount:    usersCount,
+		Wallets:       wallets,
+		ActiveWorkers: activeWorkers,
+	}, nil
+}
+
+func (s *AdminService) findUserDB(ctx context.Context, query string) (*storage.UserDB, error) {
+	if id, err := strconv.ParseInt(query, 10, 64); err == nil {
+		return s.users.Find(ctx, id)
+	}
+
+	return s.users.FindByUsername(ctx, strings.TrimPrefix(query, "@"))
+}
+
+func (s *AdminService) FindUser(ctx context.Context, query string) (*AdminUserInfo, error) {
+	user, err := s.findUserDB(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user (query: %s): %w", query, err)
+	} else if user == nil {
+		return nil, nil
+	}
+
+	isAdmin, err := s.IsAdmin(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.admins.FindUserWallets(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminUserInfo{
+		User:    *user,
+		IsAdmin: isAdmin,
+		Wallets: wallets,
+	}, nil
+}
+
+func NewAdminService(admins storage.AdminRepository, users storage.UserRepository, adminIDs []int64) *AdminService {
+	return &AdminService{
+		admins:   admins,
+		users:    users,
+		adminIDs: set.From(adminIDs),
+	}
+}
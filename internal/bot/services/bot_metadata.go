@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type BotMetadataService struct {
+	botMetadata storage.BotMetadataRepository
+}
+
+func (s *BotMetadataService) FindHashes(ctx context.Context) (map[string]string, error) {
+	return s.botMetadata.FindHashes(ctx)
+}
+
+func (s *BotMetadataService) SaveHash(ctx context.Context, key, hash string) error {
+	return s.botMetadata.SaveHash(ctx, key, hash)
+}
+
+func (s *BotMetadataService) DeleteHash(ctx context.Context, key string) error {
+	return s.botMetadata.DeleteHash(ctx, key)
+}
+
+func NewBotMetadataService(botMetadata storage.BotMetadataRepository) *BotMetadataService {
+	return &BotMetadataService{
+		botMetadata: botMetadata,
+	}
+}
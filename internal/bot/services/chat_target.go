@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type ChatTargetService struct {
+	chatTargets storage.ChatTargetRepository
+}
+
+func (s *ChatTargetService) Link(ctx context.Context, target *storage.ChatTargetDB) (*storage.ChatTargetDB, error) {
+	return s.chatTargets.Link(ctx, target)
+}
+
+func (s *ChatTargetService) Find(ctx context.Context, userID, id int64) (*storage.ChatTargetDB, error) {
+	return s.chatTargets.Find(ctx, userID, id)
+}
+
+func (s *ChatTargetService) FindByUser(ctx context.Context, userID int64) ([]storage.ChatTargetDB, error) {
+	return s.chatTargets.FindByUser(ctx, userID)
+}
+
+func (s *ChatTargetService) Unlink(ctx context.Context, userID, id int64) error {
+	return s.chatTargets.Unlink(ctx, userID, id)
+}
+
+func (s *ChatTargetService) FindWallets(ctx context.Context, userID, targetID int64) ([]storage.ChatTargetWalletDB, error) {
+	return s.chatTargets.FindWallets(ctx, userID, targetID)
+}
+
+func (s *ChatTargetService) SetWallet(ctx context.Context, targetID, walletID int64, enabled bool) error {
+	return s.chatTargets.SetWallet(ctx, targetID, walletID, enabled)
+}
+
+func NewChatTargetService(chatTargets storage.ChatTargetRepository) *ChatTargetService {
+	return &ChatTargetService{
+		chatTargets: chatTargets,
+	}
+}
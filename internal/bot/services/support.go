@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type SupportUserWalletDB struct {
+	Coin   string `db:"blockchain_coin"`
+	Wallet string `db:"wallet"`
+}
+
+type SupportUserContext struct {
+	ID       int64   `db:"id"`
+	ChatID   int64   `db:"chat_id"`
+	Lang     string  `db:"lang"`
+	Username *string `db:"username"`
+	Wallets  []SupportUserWalletDB
+}
+
+type SupportService struct {
+	support storage.SupportRepository
+	users   storage.UserRepository
+	wallets storage.WalletRepository
+}
+
+func (s *SupportService) Create(ctx context.Context, userID int64, message *storage.SupportMessage) (*storage.SupportTicketDB, int64, error) {
+	return s.support.Create(ctx, userID, message)
+}
+
+func (s *SupportService) AddMessage(ctx context.Context, ticketID int64, message *storage.SupportMessage) (int64, error) {
+	status := storage.SupportTicketOpen
+	if message.Direction == storage.SupportMessageFromSupport {
+		status = storage.SupportTicketAnswered
+	}
+
+	return s.support.AddMessage(ctx, ticketID, message, status)
+}
+
+func (s *SupportService) SetTicketSupportMessageID(ctx context.Context, ticketID, supportMessageID int64) error {
+	return s.support.SetTicketSupportMessageID(ctx, ticketID, supportMessageID)
+}
+
+func (s *SupportService) SetUserMessageID(ctx context.Context, messageID, userMessageID int64) error {
+	return s.support.SetUserMessageID(ctx, messageID, userMessageID)
+}
+
+func (s *SupportService) SetSupportMessageID(ctx context.Context, messageID, supportMessageID int64) error {
+	return s.support.SetSupportMessageID(ctx, messageID, supportMessageID)
+}
+
+func (s *SupportService) FindBySupportMessage(ctx context.Context, supportMessageID int64) (*storage.SupportTicketDB, error) {
+	return s.support.FindBySupportMessage(ctx, supportMessageID)
+}
+
+func (s *SupportService) FindByUserMessage(ctx context.Context, userID, userMessageID int64) (*storage.SupportTicketDB, error) {
+	return s.support.FindByUserMessage(ctx, userID, userMessageID)
+}
+
+func (s *SupportService) FindFirstUserMessageID(ctx context.Context, ticketID int64) (*int64, error) {
+	return s.support.FindFirstUserMessageID(ctx, ticketID)
+}
+
+func (s *SupportService) Close(ctx context.Context, ticketID int64) error {
+	return s.support.Close(ctx, ticketID)
+}
+
+func (s *SupportService) GetUserContext(ctx context.Context, userID int64) (*SupportUserContext, error) {
+	user, err := s.users.Find(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get support user (id: %d): %w", userID, err)
+	} else if user == nil {
+		return nil, fmt.Errorf("failed to get support user (id: %d): user not found", userID)
+	}
+
+	userWallets, err := s.wallets.FindAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get support user (id: %d) wallets: %w", userID, err)
+	}
+
+	userContext := SupportUserContext{
+		ID:       user.ID,
+		ChatID:   user.ChatID,
+		Lang:     user.Lang,
+		Username: user.Username,
+		Wallets:  make([]SupportUserWalletDB, 0, len(userWallets)),
+	}
+	for _, wallet := range userWallets {
+		userContext.Wallets = append(userContext.Wallets, SupportUserWalletDB{
+			Coin:   wallet.Coin,
+			Wallet: wallet.Wallet,
+		})
+	}
+
+	return &userContext, nil
+}
+
+func NewSupportService(support storage.SupportRepository, users storage.UserRepository, wallets storage.WalletRepository) *SupportService {
+	return &SupportService{
+		support: support,
+		users:   users,
+		wallets: wallets,
+	}
+}
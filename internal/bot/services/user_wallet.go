@@ -11,8 +11,11 @@ import (
 	poolProto "github.com/grandminingpool/pool-api-proto/generated/pool"
 	poolMinersProto "github.com/grandminingpool/pool-api-proto/generated/pool_miners"
 	poolPayoutsProto "github.com/grandminingpool/pool-api-proto/generated/pool_payouts"
+	paginationProto "github.com/grandminingpool/pool-api-proto/generated/utils/pagination"
+	sortsProto "github.com/grandminingpool/pool-api-proto/generated/utils/sorts"
 	"github.com/grandminingpool/telegram-bot/internal/blockchains"
-	"github.com/jmoiron/sqlx"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -25,27 +28,61 @@ type PoolInfo struct {
 type UserWalletInfo struct {
 	ID      int64
 	Wallet  string
+	Label   string
+	Role    storage.WalletRole
 	AddedAt time.Time
 }
 
+type UserWalletWorker struct {
+	Worker      string
+	Alias       string
+	Region      string
+	Solo        bool
+	Hashrate    *big.Int
+	ConnectedAt time.Time
+}
+
+type UserWalletPayout struct {
+	TxHash string
+	Amount uint64
+	PaidAt time.Time
+}
+
+type UserWalletDetails struct {
+	Blockchain *blockchains.BlockchainInfo
+	Balance    uint64
+	Hashrate   *big.Int
+	Workers    int
+	LastPayout *UserWalletPayout
+}
+
 type UserPoolWallets struct {
 	Pool    *PoolInfo
 	Wallets []UserWalletInfo
 }
 
+type PoolInfoResult struct {
+	Blockchain *blockchains.BlockchainInfo
+	Info       *PoolInfo
+	Err        error
+}
+
 type UserPoolBalances struct {
 	Coin     string
 	Balances map[string]*poolPayoutsProto.MinerBalance
+	Err      error
 }
 
 type UserPoolWorkers struct {
 	Coin    string
 	Workers map[string]*poolMinersProto.MinerWorkers
+	Err     error
 }
 
 type UserPoolWallet struct {
 	Pool    *PoolInfo
 	Wallet  string
+	Label   string
 	Balance uint64
 	AddedAt time.Time
 }
@@ -53,7 +90,9 @@ type UserPoolWallet struct {
 type UserPoolWorker struct {
 	Pool        *PoolInfo
 	Wallet      string
+	Label       string
 	Worker      string
+	Alias       string
 	Region      string
 	Solo        bool
 	Hashrate    *big.Int
@@ -61,12 +100,12 @@ type UserPoolWorker struct {
 }
 
 type UserWalletService struct {
-	pgConn             *sqlx.DB
+	wallets            storage.WalletRepository
 	blockchainsService *blockchains.Service
 }
 
 func (w *UserWalletService) FindBlockchains(ctx context.Context, userID int64) ([]blockchains.BlockchainInfo, error) {
-	rows, err := w.pgConn.QueryContext(ctx, "SELECT DISTINCT blockchain_coin FROM user_wallets WHERE user_id = $1", userID)
+	wallets, err := w.wallets.FindAll(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user (id: %d) blockchains: %w", userID, err)
 	}
@@ -74,151 +113,146 @@ func (w *UserWalletService) FindBlockchains(ctx context.Context, userID int64) (
 	blockchainsInfo := w.blockchainsService.GetBlockchainsInfo()
 	userBlockchains := []blockchains.BlockchainInfo{}
 
-	for rows.Next() {
-		var coin string
-		if err := rows.Scan(&coin); err == nil {
-			idx := slices.IndexFunc(blockchainsInfo, func(blockchain blockchains.BlockchainInfo) bool {
-				return blockchain.Coin == coin
-			})
+	for _, wallet := range wallets {
+		if slices.ContainsFunc(userBlockchains, func(blockchain blockchains.BlockchainInfo) bool {
+			return blockchain.Coin == wallet.Coin
+		}) {
+			continue
+		}
 
-			if idx != -1 {
-				userBlockchains = append(userBlockchains, blockchainsInfo[idx])
-			}
+		idx := slices.IndexFunc(blockchainsInfo, func(blockchain blockchains.BlockchainInfo) bool {
+			return blockchain.Coin == wallet.Coin
+		})
+
+		if idx != -1 {
+			userBlockchains = append(userBlockchains, blockchainsInfo[idx])
 		}
 	}
 
 	return userBlockchains, nil
 }
 
-func (w *UserWalletService) getPoolsInfoMap(ctx context.Context, coins []string) (map[string]PoolInfo, error) {
+func (w *UserWalletService) getPoolsInfoMap(ctx context.Context, coins []string) (map[string]PoolInfo, []*blockchains.BlockchainInfo) {
 	poolsInfoMap := make(map[string]PoolInfo)
-	resultCh := make(chan PoolInfo, len(coins))
-	errCh := make(chan error, len(coins))
-	newCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	unavailablePools := []*blockchains.BlockchainInfo{}
+	resultCh := make(chan PoolInfoResult, len(coins))
 	defer close(resultCh)
-	defer close(errCh)
 
+	requestsCount := 0
 	for _, coin := range coins {
 		blockchain, err := w.blockchainsService.GetInfo(coin)
 		if err != nil {
-			return nil, err
+			continue
 		}
 
 		conn, err := w.blockchainsService.GetConnection(blockchain.Coin)
 		if err != nil {
-			return nil, err
+			continue
 		}
 
 		client := poolProto.NewPoolServiceClient(conn)
+		requestsCount++
+
+		go func(b *blockchains.BlockchainInfo, cl poolProto.PoolServiceClient) {
+			result := PoolInfoResult{
+				Blockchain: b,
+			}
 
-		go func(c context.Context, b *blockchains.BlockchainInfo, cl poolProto.PoolServiceClient) {
-			select {
-			case <-c.Done():
-				return
-			default:
-				poolInfo, err := cl.GetPoolInfo(ctx, &emptypb.Empty{})
-				if err != nil {
-					errCh <- fmt.Errorf("failed to get blockchain (coin: %s) pool info: %w", b.Coin, err)
-				} else {
-					resultCh <- PoolInfo{
-						Blockchain: b,
-						Host:       poolInfo.Host,
-						MinPayout:  poolInfo.PayoutsInfo.MinPayout,
-					}
+			poolInfo, err := cl.GetPoolInfo(ctx, &emptypb.Empty{})
+			if err != nil {
+				result.Err = fmt.Errorf("failed to get blockchain (coin: %s) pool info: %w", b.Coin, err)
+			} else {
+				result.Info = &PoolInfo{
+					Blockchain: b,
+					Host:       poolInfo.Host,
+				}
+
+				if poolInfo.PayoutsInfo != nil {
+					result.Info.MinPayout = poolInfo.PayoutsInfo.MinPayout
 				}
 			}
-		}(newCtx, blockchain, client)
+
+			resultCh <- result
+		}(blockchain, client)
 	}
 
-	for i := 0; i < len(coins); i++ {
-		select {
-		case err := <-errCh:
-			return nil, err
-		case poolInfo := <-resultCh:
-			poolsInfoMap[poolInfo.Blockchain.Coin] = poolInfo
-		default:
+	for i := 0; i < requestsCount; i++ {
+		result := <-resultCh
+		if result.Err != nil {
+			zap.L().Warn("pool info is unavailable",
+				zap.String("coin", result.Blockchain.Coin),
+				zap.Error(result.Err),
+			)
+
+			unavailablePools = append(unavailablePools, result.Blockchain)
+		} else {
+			poolsInfoMap[result.Blockchain.Coin] = *result.Info
 		}
 	}
 
-	return poolsInfoMap, nil
+	return poolsInfoMap, unavailablePools
 }
 
-func (w *UserWalletService) getWalletsMap(ctx context.Context, userID int64) (map[string]UserPoolWallets, error) {
-	walletsMap := make(map[string]UserPoolWallets)
-	rows, err := w.pgConn.QueryContext(ctx, "SELECT id, blockchain_coin, wallet, added_at from user_wallets WHERE user_id = $1 ORDER BY added_at", userID)
-	coins := []string{}
+func (w *UserWalletService) getWalletsMap(ctx context.Context, userID int64) (map[string]*UserPoolWallets, []*blockchains.BlockchainInfo, error) {
+	walletsMap := make(map[string]*UserPoolWallets)
+	userWallets, err := w.wallets.FindAll(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query user (id: %d) wallets: %w", userID, err)
+		return nil, nil, err
 	}
 
-	for rows.Next() {
-		var (
-			id           int64
-			coin, wallet string
-			addedAt      time.Time
-		)
-		if err := rows.Scan(&id, &coin, &wallet, &addedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan user (id: %d) wallets columns: %w", userID, err)
-		}
-
-		coins = append(coins, coin)
+	coins := []string{}
+	for _, userWallet := range userWallets {
 		walletItem := UserWalletInfo{
-			ID:      id,
-			Wallet:  wallet,
-			AddedAt: addedAt,
+			ID:      userWallet.ID,
+			Wallet:  userWallet.Wallet,
+			Label:   userWallet.Label,
+			Role:    userWallet.Role,
+			AddedAt: userWallet.AddedAt,
 		}
-		wallets, ok := walletsMap[coin]
+		wallets, ok := walletsMap[userWallet.Coin]
 		if ok {
 			wallets.Wallets = append(wallets.Wallets, walletItem)
 		} else {
-			walletsMap[coin] = UserPoolWallets{
+			coins = append(coins, userWallet.Coin)
+			walletsMap[userWallet.Coin] = &UserPoolWallets{
 				Pool:    nil,
 				Wallets: []UserWalletInfo{walletItem},
 			}
 		}
 	}
 
+	unavailablePools := []*blockchains.BlockchainInfo{}
 	if len(coins) > 0 {
-		poolsInfoMap, err := w.getPoolsInfoMap(ctx, coins)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user (id: %d) wallets pools info: %w", userID, err)
-		}
+		poolsInfoMap, unavailable := w.getPoolsInfoMap(ctx, coins)
+		unavailablePools = unavailable
 
 		for coin, wallets := range walletsMap {
 			poolInfo, ok := poolsInfoMap[coin]
 			if ok {
-				walletsMap[coin] = UserPoolWallets{
-					Pool:    &poolInfo,
-					Wallets: wallets.Wallets,
-				}
+				wallets.Pool = &poolInfo
+			} else {
+				delete(walletsMap, coin)
 			}
 		}
 	}
 
-	return walletsMap, nil
+	return walletsMap, unavailablePools, nil
 }
 
-func (w *UserWalletService) FindWallets(ctx context.Context, userID int64) ([]UserPoolWallet, error) {
-	walletsMap, err := w.getWalletsMap(ctx, userID)
+func (w *UserWalletService) FindWallets(ctx context.Context, userID int64) ([]UserPoolWallet, []*blockchains.BlockchainInfo, error) {
+	walletsMap, unavailablePools, err := w.getWalletsMap(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	balancesMap := make(map[string]map[string]uint64)
-	defer clear(balancesMap)
-
 	resultCh := make(chan UserPoolBalances, len(walletsMap))
-	errCh := make(chan error, len(walletsMap))
-	newCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
 	defer close(resultCh)
-	defer close(errCh)
 
+	requestsCount := 0
 	for coin, userWallets := range walletsMap {
 		conn, err := w.blockchainsService.GetConnection(coin)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get user (id: %d) wallets blockchain connection: %w", userID, err)
+			return nil, nil, fmt.Errorf("failed to get user (id: %d) wallets blockchain connection: %w", userID, err)
 		}
 
 		client := poolPayoutsProto.NewPoolPayoutsServiceClient(conn)
@@ -227,48 +261,53 @@ func (w *UserWalletService) FindWallets(ctx context.Context, userID int64) ([]Us
 			addresses = append(addresses, wi.Wallet)
 		}
 
-		go func(c context.Context, cn string, adds []string, cl poolPayoutsProto.PoolPayoutsServiceClient) {
-			select {
-			case <-c.Done():
-				return
-			default:
-				balances, err := client.GetMinersBalancesFromList(ctx, &poolMinersProto.MinerAddressesRequest{
-					Addresses: adds,
-				})
-				if err != nil {
-					errCh <- fmt.Errorf("failed to get user (id: %d) blockchain (coin: %s) wallets balances: %w", userID, cn, err)
-				} else {
-					resultCh <- UserPoolBalances{
-						Coin:     cn,
-						Balances: balances.Balances,
-					}
-				}
+		requestsCount++
+
+		go func(cn string, adds []string, cl poolPayoutsProto.PoolPayoutsServiceClient) {
+			result := UserPoolBalances{
+				Coin: cn,
+			}
+
+			balances, err := cl.GetMinersBalancesFromList(ctx, &poolMinersProto.MinerAddressesRequest{
+				Addresses: adds,
+			})
+			if err != nil {
+				result.Err = fmt.Errorf("failed to get user (id: %d) blockchain (coin: %s) wallets balances: %w", userID, cn, err)
+			} else {
+				result.Balances = balances.Balances
 			}
-		}(newCtx, coin, addresses, client)
+
+			resultCh <- result
+		}(coin, addresses, client)
 	}
 
 	wallets := []UserPoolWallet{}
-	for i := 0; i < len(walletsMap); i++ {
-		select {
-		case err := <-errCh:
-			return nil, err
-		case userBalances := <-resultCh:
-			userWallets, ok := walletsMap[userBalances.Coin]
+	for i := 0; i < requestsCount; i++ {
+		userBalances := <-resultCh
+		userWallets := walletsMap[userBalances.Coin]
+		if userBalances.Err != nil {
+			zap.L().Warn("pool wallets balances are unavailable",
+				zap.Int64("user_id", userID),
+				zap.String("coin", userBalances.Coin),
+				zap.Error(userBalances.Err),
+			)
+
+			unavailablePools = append(unavailablePools, userWallets.Pool.Blockchain)
+
+			continue
+		}
+
+		for _, wi := range userWallets.Wallets {
+			balance, ok := userBalances.Balances[wi.Wallet]
 			if ok {
-				for _, wi := range userWallets.Wallets {
-					balance, ok := userBalances.Balances[wi.Wallet]
-
-					if ok {
-						wallets = append(wallets, UserPoolWallet{
-							Pool:    userWallets.Pool,
-							Wallet:  wi.Wallet,
-							Balance: balance.Balance,
-							AddedAt: wi.AddedAt,
-						})
-					}
-				}
+				wallets = append(wallets, UserPoolWallet{
+					Pool:    userWallets.Pool,
+					Wallet:  wi.Wallet,
+					Label:   wi.Label,
+					Balance: balance.Balance,
+					AddedAt: wi.AddedAt,
+				})
 			}
-		default:
 		}
 	}
 
@@ -276,29 +315,28 @@ func (w *UserWalletService) FindWallets(ctx context.Context, userID int64) ([]Us
 		return wallets[i].AddedAt.Before(wallets[j].AddedAt)
 	})
 
-	return wallets, nil
+	return wallets, unavailablePools, nil
 }
 
-func (w *UserWalletService) FindWorkers(ctx context.Context, userID int64) ([]UserPoolWorker, error) {
-	walletsMap, err := w.getWalletsMap(ctx, userID)
+func (w *UserWalletService) FindWorkers(ctx context.Context, userID int64) ([]UserPoolWorker, []*blockchains.BlockchainInfo, error) {
+	walletsMap, unavailablePools, err := w.getWalletsMap(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	workersMap := make(map[string]UserPoolWorkers)
-	defer clear(workersMap)
+	aliasesMap, err := w.findWorkerAliasesMap(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	resultCh := make(chan UserPoolWorkers, len(walletsMap))
-	errCh := make(chan error, len(walletsMap))
-	newCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
 	defer close(resultCh)
-	defer close(errCh)
 
+	requestsCount := 0
 	for coin, userWallets := range walletsMap {
 		conn, err := w.blockchainsService.GetConnection(coin)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get user (id: %d) workers blockchain connection: %w", userID, err)
+			return nil, nil, fmt.Errorf("failed to get user (id: %d) workers blockchain connection: %w", userID, err)
 		}
 
 		client := poolMinersProto.NewPoolMinersServiceClient(conn)
@@ -307,52 +345,59 @@ func (w *UserWalletService) FindWorkers(ctx context.Context, userID int64) ([]Us
 			addresses = append(addresses, wi.Wallet)
 		}
 
-		go func(c context.Context, cn string, adds []string, cl poolMinersProto.PoolMinersServiceClient) {
-			select {
-			case <-c.Done():
-				return
-			default:
-				workers, err := client.GetMinersWorkersFromList(ctx, &poolMinersProto.MinerAddressesRequest{
-					Addresses: adds,
-				})
-				if err != nil {
-					errCh <- fmt.Errorf("failed to get user (id: %d) blockchain (coin: %s) wallets workers: %w", userID, cn, err)
-				} else {
-					resultCh <- UserPoolWorkers{
-						Coin:    cn,
-						Workers: workers.Workers,
-					}
-				}
+		requestsCount++
+
+		go func(cn string, adds []string, cl poolMinersProto.PoolMinersServiceClient) {
+			result := UserPoolWorkers{
+				Coin: cn,
+			}
+
+			workers, err := cl.GetMinersWorkersFromList(ctx, &poolMinersProto.MinerAddressesRequest{
+				Addresses: adds,
+			})
+			if err != nil {
+				result.Err = fmt.Errorf("failed to get user (id: %d) blockchain (coin: %s) wallets workers: %w", userID, cn, err)
+			} else {
+				result.Workers = workers.Workers
 			}
-		}(newCtx, coin, addresses, client)
+
+			resultCh <- result
+		}(coin, addresses, client)
 	}
 
 	workers := []UserPoolWorker{}
-	for i := 0; i < len(walletsMap); i++ {
-		select {
-		case err := <-errCh:
-			return nil, err
-		case userWorkers := <-resultCh:
-			userWallets, ok := walletsMap[userWorkers.Coin]
+	for i := 0; i < requestsCount; i++ {
+		userWorkers := <-resultCh
+		userWallets := walletsMap[userWorkers.Coin]
+		if userWorkers.Err != nil {
+			zap.L().Warn("pool wallets workers are unavailable",
+				zap.Int64("user_id", userID),
+				zap.String("coin", userWorkers.Coin),
+				zap.Error(userWorkers.Err),
+			)
+
+			unavailablePools = append(unavailablePools, userWallets.Pool.Blockchain)
+
+			continue
+		}
+
+		for _, wi := range userWallets.Wallets {
+			wks, ok := userWorkers.Workers[wi.Wallet]
 			if ok {
-				for _, wi := range userWallets.Wallets {
-					wks, ok := userWorkers.Workers[wi.Wallet]
-					if ok {
-						for _, wk := range wks.Workers {
-							workers = append(workers, UserPoolWorker{
-								Pool:        userWallets.Pool,
-								Wallet:      wi.Wallet,
-								Worker:      wk.Worker,
-								Region:      wk.Region,
-								Solo:        wk.Solo,
-								Hashrate:    new(big.Int).SetBytes(wk.Hashrate),
-								ConnectedAt: wk.ConnectedAt.AsTime(),
-							})
-						}
-					}
+				for _, wk := range wks.Workers {
+					workers = append(workers, UserPoolWorker{
+						Pool:        userWallets.Pool,
+						Wallet:      wi.Wallet,
+						Label:       wi.Label,
+						Worker:      wk.Worker,
+						Alias:       aliasesMap[wi.ID][wk.Worker],
+						Region:      wk.Region,
+						Solo:        wk.Solo,
+						Hashrate:    new(big.Int).SetBytes(wk.Hashrate),
+						ConnectedAt: wk.ConnectedAt.AsTime(),
+					})
 				}
 			}
-		default:
 		}
 	}
 
@@ -360,25 +405,23 @@ func (w *UserWalletService) FindWorkers(ctx context.Context, userID int64) ([]Us
 		return workers[i].ConnectedAt.Before(workers[j].ConnectedAt)
 	})
 
-	return workers, nil
+	return workers, unavailablePools, nil
 }
 
 func (w *UserWalletService) FindBlockchainWallets(ctx context.Context, userID int64, coin string) ([]UserWalletInfo, error) {
-	wallets := []UserWalletInfo{}
-	rows, err := w.pgConn.QueryContext(ctx, "SELECT id, wallet FROM user_wallets WHERE user_id = $1 AND blockchain_coin = $2", userID, coin)
+	userWallets, err := w.wallets.FindAll(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user (id: %d) blockchain (coin: %s) wallets: %w", userID, coin, err)
 	}
 
-	for rows.Next() {
-		var (
-			id     int64
-			wallet string
-		)
-		if err := rows.Scan(&id, &wallet); err == nil {
+	wallets := []UserWalletInfo{}
+	for _, userWallet := range userWallets {
+		if userWallet.Coin == coin {
 			wallets = append(wallets, UserWalletInfo{
-				ID:     id,
-				Wallet: wallet,
+				ID:     userWallet.ID,
+				Wallet: userWallet.Wallet,
+				Label:  userWallet.Label,
+				Role:   userWallet.Role,
 			})
 		}
 	}
@@ -386,48 +429,221 @@ func (w *UserWalletService) FindBlockchainWallets(ctx context.Context, userID in
 	return wallets, nil
 }
 
-func (w *UserWalletService) Count(ctx context.Context, userID int64, coin string) (int, error) {
-	var count int
-	if err := w.pgConn.GetContext(ctx, &count, `SELECT COUNT(*) 
-		FROM user_wallets 
-		WHERE user_id = $1 AND blockchain_coin = $2`, userID, coin); err != nil {
-		return 0, fmt.Errorf("failed to count user wallets (id: %d, coin: %s), error: %w", userID, coin, err)
+func (w *UserWalletService) findWorkerAliasesMap(ctx context.Context, userID int64) (map[int64]map[string]string, error) {
+	return w.wallets.FindAliases(ctx, userID)
+}
+
+func (w *UserWalletService) Find(ctx context.Context, userID, id int64) (*storage.UserWalletDB, error) {
+	return w.wallets.Find(ctx, userID, id)
+}
+
+func (w *UserWalletService) FindAll(ctx context.Context, userID int64) ([]storage.UserWalletDB, error) {
+	return w.wallets.FindAll(ctx, userID)
+}
+
+func (w *UserWalletService) SetLabel(ctx context.Context, userID, id int64, label string) error {
+	//	Empty label removes wallet name
+	return w.wallets.SetLabel(ctx, userID, id, label)
+}
+
+func (w *UserWalletService) FindWorkerAliases(ctx context.Context, userID, id int64) ([]storage.WorkerAliasDB, error) {
+	return w.wallets.FindWorkerAliases(ctx, userID, id)
+}
+
+func (w *UserWalletService) SetWorkerAlias(ctx context.Context, userID, id int64, worker, alias string) error {
+	return w.wallets.SetWorkerAlias(ctx, userID, id, worker, alias)
+}
+
+func (w *UserWalletService) SetNotify(ctx context.Context, userID, id int64, notify bool) error {
+	return w.wallets.SetNotify(ctx, userID, id, notify)
+}
+
+func (w *UserWalletService) SetPaused(ctx context.Context, userID, id int64, paused bool) error {
+	return w.wallets.SetPaused(ctx, userID, id, paused)
+}
+
+func (w *UserWalletService) FindWalletWorkers(ctx context.Context, userID int64, wallet *storage.UserWalletDB) ([]UserWalletWorker, error) {
+	conn, err := w.blockchainsService.GetConnection(wallet.Coin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) connection: %w", wallet.Coin, err)
 	}
 
-	return count, nil
+	poolWorkers, err := poolMinersProto.NewPoolMinersServiceClient(conn).GetMinersWorkersFromList(ctx, &poolMinersProto.MinerAddressesRequest{
+		Addresses: []string{wallet.Wallet},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user (id: %d) wallet (id: %d) workers: %w", userID, wallet.ID, err)
+	}
+
+	aliasesMap, err := w.findWorkerAliasesMap(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := []UserWalletWorker{}
+	if wks, ok := poolWorkers.Workers[wallet.Wallet]; ok {
+		for _, wk := range wks.Workers {
+			workers = append(workers, UserWalletWorker{
+				Worker:      wk.Worker,
+				Alias:       aliasesMap[wallet.ID][wk.Worker],
+				Region:      wk.Region,
+				Solo:        wk.Solo,
+				Hashrate:    new(big.Int).SetBytes(wk.Hashrate),
+				ConnectedAt: wk.ConnectedAt.AsTime(),
+			})
+		}
+	}
+
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].ConnectedAt.Before(workers[j].ConnectedAt)
+	})
+
+	return workers, nil
 }
 
-func (w *UserWalletService) CheckDuplicates(ctx context.Context, userID int64, coin, wallet string) (bool, error) {
-	var count int
-	if err := w.pgConn.GetContext(ctx, &count, `SELECT COUNT(*) 
-		FROM user_wallets 
-		WHERE user_id = $1 AND blockchain_coin = $2 AND wallet = $3`,
-		userID, coin, wallet); err != nil {
-		return false, fmt.Errorf("failed to count duplicate user wallets (id: %d, coin: %s, wallet: %s), error: %w", userID, coin, wallet, err)
+func (w *UserWalletService) FindWalletPayouts(ctx context.Context, wallet *storage.UserWalletDB, limit uint32) ([]UserWalletPayout, error) {
+	conn, err := w.blockchainsService.GetConnection(wallet.Coin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) connection: %w", wallet.Coin, err)
 	}
 
-	return count > 0, nil
+	poolPayouts, err := poolPayoutsProto.NewPoolPayoutsServiceClient(conn).GetPayouts(ctx, &poolPayoutsProto.GetPayoutsRequest{
+		Sorts: &poolPayoutsProto.PayoutsSorts{
+			PaidAt: &sortsProto.SortOrder{
+				Direction: sortsProto.SortDirection_DESC,
+			},
+		},
+		Filters: &poolPayoutsProto.PayoutsFilters{
+			Miner: &wallet.Wallet,
+		},
+		Pagination: &paginationProto.PaginationRequest{
+			Limit: limit,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet (id: %d) payouts: %w", wallet.ID, err)
+	}
+
+	payouts := []UserWalletPayout{}
+	if poolPayouts.Payouts != nil {
+		for _, payout := range poolPayouts.Payouts.Payouts {
+			payouts = append(payouts, UserWalletPayout{
+				TxHash: payout.TxHash,
+				Amount: payout.Amount,
+				PaidAt: payout.PaidAt.AsTime(),
+			})
+		}
+	}
+
+	return payouts, nil
 }
 
-func (w *UserWalletService) Add(ctx context.Context, userID int64, coin, wallet string) error {
-	if _, err := w.pgConn.ExecContext(ctx, `INSERT INTO user_wallets (user_id, blockchain_coin, wallet) VALUES ($1, $2, $3)`, userID, coin, wallet); err != nil {
-		return fmt.Errorf("failed to add user wallet (id: %d, coin: %s,  wallet: %s), error: %w", userID, coin, wallet, err)
+func (w *UserWalletService) FindWalletDetails(ctx context.Context, userID int64, wallet *storage.UserWalletDB) (*UserWalletDetails, error) {
+	blockchain, err := w.blockchainsService.GetInfo(wallet.Coin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) info: %w", wallet.Coin, err)
+	}
+
+	conn, err := w.blockchainsService.GetConnection(wallet.Coin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) connection: %w", wallet.Coin, err)
+	}
+
+	balances, err := poolPayoutsProto.NewPoolPayoutsServiceClient(conn).GetMinersBalancesFromList(ctx, &poolMinersProto.MinerAddressesRequest{
+		Addresses: []string{wallet.Wallet},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user (id: %d) wallet (id: %d) balance: %w", userID, wallet.ID, err)
+	}
+
+	workers, err := w.FindWalletWorkers(ctx, userID, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	payouts, err := w.FindWalletPayouts(ctx, wallet, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &UserWalletDetails{
+		Blockchain: blockchain,
+		Hashrate:   big.NewInt(0),
+		Workers:    len(workers),
+	}
+
+	if balance, ok := balances.Balances[wallet.Wallet]; ok {
+		details.Balance = balance.Balance
+	}
+
+	for _, worker := range workers {
+		details.Hashrate.Add(details.Hashrate, worker.Hashrate)
+	}
+
+	if len(payouts) > 0 {
+		details.LastPayout = &payouts[0]
 	}
 
-	return nil
+	return details, nil
 }
 
-func (w *UserWalletService) Remove(ctx context.Context, id int64) error {
-	if _, err := w.pgConn.ExecContext(ctx, `DELETE FROM user_wallets WHERE id = $1`, id); err != nil {
-		return fmt.Errorf("failed to remove user wallet (id: %d), error: %w", id, err)
+func (w *UserWalletService) FindAliases(ctx context.Context, userID, id int64) (map[string]string, error) {
+	aliasesMap, err := w.findWorkerAliasesMap(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if aliases, ok := aliasesMap[id]; ok {
+		return aliases, nil
+	}
+
+	return map[string]string{}, nil
+}
+
+func (w *UserWalletService) ValidateAddress(ctx context.Context, coin, wallet string) (bool, error) {
+	conn, err := w.blockchainsService.GetConnection(coin)
+	if err != nil {
+		return false, fmt.Errorf("failed to get blockchain (coin: %s) connection: %w", coin, err)
 	}
 
-	return nil
+	client := poolMinersProto.NewPoolMinersServiceClient(conn)
+	response, err := client.ValidateAddress(ctx, &poolMinersProto.MinerAddressRequest{
+		Address: wallet,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to validate blockchain (coin: %s) wallet (%s): %w", coin, wallet, err)
+	}
+
+	return response.Valid, nil
+}
+
+func (w *UserWalletService) Count(ctx context.Context, userID int64, coin string) (int, error) {
+	return w.wallets.Count(ctx, userID, coin)
+}
+
+func (w *UserWalletService) CheckDuplicates(ctx context.Context, userID int64, coin, wallet string) (bool, error) {
+	return w.wallets.Exists(ctx, userID, coin, wallet)
+}
+
+func (w *UserWalletService) Add(ctx context.Context, userID int64, coin, wallet string) error {
+	return w.AddLabeled(ctx, userID, coin, wallet, "")
+}
+
+func (w *UserWalletService) AddLabeled(ctx context.Context, userID int64, coin, wallet, label string) error {
+	return w.wallets.Add(ctx, userID, coin, wallet, label)
+}
+
+func (w *UserWalletService) Remove(ctx context.Context, id int64) error {
+	return w.wallets.Remove(ctx, id)
+}
+
+func (w *UserWalletService) Restore(ctx context.Context, userID int64, wallet *storage.UserWalletDB, aliases map[string]string) error {
+	return w.wallets.Restore(ctx, userID, wallet, aliases)
 }
 
-func NewUserWalletService(pgConn *sqlx.DB, blockchainsService *blockchains.Service) *UserWalletService {
+func NewUserWalletService(wallets storage.WalletRepository, blockchainsService *blockchains.Service) *UserWalletService {
 	return &UserWalletService{
-		pgConn:             pgConn,
+		wallets:            wallets,
 		blockchainsService: blockchainsService,
 	}
 }
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	MAX_WALLET_LABEL_LENGTH = 64
+	IMPORT_CSV_HEADER       = "coin"
+)
+
+type WalletImportStatus string
+
+const (
+	WalletImportAdded           WalletImportStatus = "added"
+	WalletImportInvalidLine     WalletImportStatus = "invalid_line"
+	WalletImportUnknownCoin     WalletImportStatus = "unknown_coin"
+	WalletImportInvalidWallet   WalletImportStatus = "invalid_wallet"
+	WalletImportDuplicate       WalletImportStatus = "duplicate"
+	WalletImportLimitExceeded   WalletImportStatus = "limit_exceeded"
+	WalletImportPoolUnavailable WalletImportStatus = "pool_unavailable"
+	WalletImportFailed          WalletImportStatus = "failed"
+)
+
+var (
+	ErrImportEmpty         = errors.New("no wallets to import")
+	ErrImportTooManyLines  = errors.New("too many wallets to import")
+	ErrImportInvalidFormat = errors.New("invalid wallets import format")
+)
+
+type WalletImportLine struct {
+	Line   int
+	Coin   string
+	Wallet string
+	Label  string
+	Valid  bool
+}
+
+type WalletImportResult struct {
+	WalletImportLine
+	Status WalletImportStatus
+}
+
+type walletImportJSONItem struct {
+	Coin   string `json:"coin"`
+	Wallet string `json:"wallet"`
+	Label  string `json:"label"`
+}
+
+type WalletExportSettings struct {
+	Lang             string `json:"lang"`
+	PayoutsNotify    bool   `json:"payouts_notify"`
+	BlocksNotify     bool   `json:"blocks_notify"`
+	BroadcastsNotify bool   `json:"broadcasts_notify"`
+	ShortWallets     bool   `json:"short_wallets"`
+}
+
+type WalletExportItem struct {
+	Coin          string             `json:"coin"`
+	Wallet        string             `json:"wallet"`
+	Label         string             `json:"label,omitempty"`
+	Role          storage.WalletRole `json:"role"`
+	Notify        bool               `json:"notify"`
+	Paused        bool               `json:"paused"`
+	WorkerAliases map[string]string  `json:"worker_aliases,omitempty"`
+}
+
+type WalletExport struct {
+	ExportedAt time.Time            `json:"exported_at"`
+	Settings   WalletExportSettings `json:"settings"`
+	Wallets    []WalletExportItem   `json:"wallets"`
+}
+
+type WalletImportService struct {
+	userWalletService   *UserWalletService
+	blockchainsService  *blockchains.Service
+	walletsLimitPerUser int
+	maxLines            int
+	concurrency         int
+}
+
+func newWalletImportLine(line int, coin, wallet, label string) WalletImportLine {
+	importLine := WalletImportLine{
+		Line:   line,
+		Coin:   strings.TrimSpace(coin),
+		Wallet: strings.TrimSpace(wallet),
+		Label:  strings.TrimSpace(label),
+	}
+	importLine.Valid = importLine.Coin != "" &&
+		importLine.Wallet != "" &&
+		utf8.RuneCountInString(importLine.Label) <= MAX_WALLET_LABEL_LENGTH
+
+	return importLine
+}
+
+func (s *WalletImportService) parseCSV(data []byte) ([]WalletImportLine, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	reader.Comment = '#'
+
+	lines := []WalletImportLine{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrImportInvalidFormat, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(lines) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), IMPORT_CSV_HEADER) {
+			continue
+		}
+
+		switch len(record) {
+		case 2:
+			lines = append(lines, newWalletImportLine(line, record[0], record[1], ""))
+		case 3:
+			lines = append(lines, newWalletImportLine(line, record[0], record[1], record[2]))
+		default:
+			lines = append(lines, WalletImportLine{
+				Line:   line,
+				Coin:   strings.TrimSpace(record[0]),
+				Wallet: strings.Join(record[1:], ","),
+			})
+		}
+	}
+
+	return lines, nil
+}
+
+func (s *WalletImportService) parseJSON(data []byte) ([]WalletImportLine, error) {
+	//	Exported file can be imported back, so both wallets list and export object are accepted
+	items := []walletImportJSONItem{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		var export struct {
+			Wallets []walletImportJSONItem `json:"wallets"`
+		}
+		if err := json.Unmarshal(data, &export); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrImportInvalidFormat, err)
+		}
+
+		items = export.Wallets
+	}
+
+	lines := make([]WalletImportLine, 0, len(items))
+	for i, item := range items {
+		lines = append(lines, newWalletImportLine(i+1, item.Coin, item.Wallet, item.Label))
+	}
+
+	return lines, nil
+}
+
+func (s *WalletImportService) Parse(data []byte) ([]WalletImportLine, error) {
+	var (
+		lines []WalletImportLine
+		err   error
+	)
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) || bytes.HasPrefix(trimmed, []byte("{")) {
+		lines, err = s.parseJSON(trimmed)
+	} else {
+		lines, err = s.parseCSV(trimmed)
+	}
+
+	if err != nil {
+		return nil, err
+	} else if len(lines) == 0 {
+		return nil, ErrImportEmpty
+	} else if len(lines) > s.maxLines {
+		return nil, ErrImportTooManyLines
+	}
+
+	return lines, nil
+}
+
+func (s *WalletImportService) findCoin(coin string) (string, bool) {
+	for _, blockchain := range s.blockchainsService.GetBlockchainsInfo() {
+		if strings.EqualFold(blockchain.Coin, coin) {
+			return blockchain.Coin, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *WalletImportService) validate(ctx context.Context, userID int64, results []WalletImportResult) {
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, s.concurrency)
+	for i := range results {
+		if results[i].Status != "" {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(result *WalletImportResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			valid, err := s.userWalletService.ValidateAddress(ctx, result.Coin, result.Wallet)
+			if errors.Is(err, blockchains.ErrPoolUnavailable) {
+				result.Status = WalletImportPoolUnavailable
+			} else if err != nil {
+				zap.L().Error("import wallet address validation error",
+					zap.Int64("user_id", userID),
+					zap.String("coin", result.Coin),
+					zap.String("wallet", result.Wallet),
+					zap.Error(err),
+				)
+
+				result.Status = WalletImportFailed
+			} else if !valid {
+				result.Status = WalletImportInvalidWallet
+			}
+		}(&results[i])
+	}
+
+	wg.Wait()
+}
+
+func (s *WalletImportService) Import(ctx context.Context, userID int64, lines []WalletImportLine) []WalletImportResult {
+	results := make([]WalletImportResult, len(lines))
+	seen := make(map[string]struct{}, len(lines))
+	for i, line := range lines {
+		results[i].WalletImportLine = line
+		if !line.Valid {
+			results[i].Status = WalletImportInvalidLine
+
+			continue
+		}
+
+		coin, ok := s.findCoin(line.Coin)
+		if !ok {
+			results[i].Status = WalletImportUnknownCoin
+
+			continue
+		}
+
+		results[i].Coin = coin
+		key := coin + ":" + line.Wallet
+		if _, ok := seen[key]; ok {
+			results[i].Status = WalletImportDuplicate
+
+			continue
+		}
+
+		seen[key] = struct{}{}
+	}
+
+	//	Validation requests go to pools, while wallets are added one by one to keep limit consistent
+	s.validate(ctx, userID, results)
+
+	counts := make(map[string]int)
+	for i := range results {
+		result := &results[i]
+		if result.Status != "" {
+			continue
+		}
+
+		count, ok := counts[result.Coin]
+		if !ok {
+			c, err := s.userWalletService.Count(ctx, userID, result.Coin)
+			if err != nil {
+				zap.L().Error("count user wallets error",
+					zap.Int64("user_id", userID),
+					zap.String("coin", result.Coin),
+					zap.Error(err),
+				)
+
+				result.Status = WalletImportFailed
+
+				continue
+			}
+
+			count = c
+		}
+
+		hasDuplicates, err := s.userWalletService.CheckDuplicates(ctx, userID, result.Coin, result.Wallet)
+		if err != nil {
+			zap.L().Error("check user wallet duplicates error",
+				zap.Int64("user_id", userID),
+				zap.String("coin", result.Coin),
+				zap.String("wallet", result.Wallet),
+				zap.Error(err),
+			)
+
+			result.Status = WalletImportFailed
+		} else if hasDuplicates {
+			result.Status = WalletImportDuplicate
+		} else if count+1 > s.walletsLimitPerUser {
+			result.Status = WalletImportLimitExceeded
+		} else if err := s.userWalletService.AddLabeled(ctx, userID, result.Coin, result.Wallet, result.Label); err != nil {
+			zap.L().Error("import user wallet error",
+				zap.Int64("user_id", userID),
+				zap.String("coin", result.Coin),
+				zap.String("wallet", result.Wallet),
+				zap.Error(err),
+			)
+
+			result.Status = WalletImportFailed
+		} else {
+			result.Status = WalletImportAdded
+			count++
+		}
+
+		counts[result.Coin] = count
+	}
+
+	return results
+}
+
+func (s *WalletImportService) Export(ctx context.Context, userID int64, settings WalletExportSettings) ([]byte, error) {
+	wallets, err := s.userWalletService.FindAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasesMap, err := s.userWalletService.findWorkerAliasesMap(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := WalletExport{
+		ExportedAt: time.Now().UTC(),
+		Settings:   settings,
+		Wallets:    make([]WalletExportItem, 0, len(wallets)),
+	}
+	for _, wallet := range wallets {
+		export.Wallets = append(export.Wallets, WalletExportItem{
+			Coin:          wallet.Coin,
+			Wallet:        wallet.Wallet,
+			Label:         wallet.Label,
+			Role:          wallet.Role,
+			Notify:        wallet.Notify,
+			Paused:        wallet.Paused,
+			WorkerAliases: aliasesMap[wallet.ID],
+		})
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user (id: %d) wallets export: %w", userID, err)
+	}
+
+	return data, nil
+}
+
+func NewWalletImportService(
+	userWalletService *UserWalletService,
+	blockchainsService *blockchains.Service,
+	walletsLimitPerUser int,
+	maxLines int,
+	concurrency int,
+) *WalletImportService {
+	return &WalletImportService{
+		userWalletService:   userWalletService,
+		blockchainsService:  blockchainsService,
+		walletsLimitPerUser: walletsLimitPerUser,
+		maxLines:            maxLines,
+		concurrency:         max(concurrency, 1),
+	}
+}
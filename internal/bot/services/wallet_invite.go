@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+const (
+	WALLET_INVITE_TTL          = 7 * 24 * time.Hour
+	WALLET_INVITE_TOKEN_LENGTH = 18
+)
+
+var (
+	ErrWalletNotOwned       = errors.New("wallet is not owned by user")
+	ErrWalletsLimitExceeded = errors.New("wallets limit per user is exceeded")
+)
+
+type WalletInviteService struct {
+	walletInvites       storage.WalletInviteRepository
+	wallets             storage.WalletRepository
+	walletsLimitPerUser int
+}
+
+func generateInviteToken() (string, error) {
+	buf := make([]byte, WALLET_INVITE_TOKEN_LENGTH)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate wallet invite token: %w", err)
+	}
+
+	//	URL encoding keeps token inside characters allowed in /start payload
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (s *WalletInviteService) FindOwnedWallets(ctx context.Context, userID int64) ([]storage.UserWalletDB, error) {
+	userWallets, err := s.wallets.FindAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) owned wallets: %w", userID, err)
+	}
+
+	wallets := []storage.UserWalletDB{}
+	for _, wallet := range userWallets {
+		if wallet.Role == storage.WalletOwnerRole {
+			wallets = append(wallets, wallet)
+		}
+	}
+
+	return wallets, nil
+}
+
+func (s *WalletInviteService) Create(ctx context.Context, userID, subscriptionID int64) (string, error) {
+	token, err := generateInviteToken()
+	if err != nil {
+		return "", err
+	}
+
+	created, err := s.walletInvites.Create(ctx, token, userID, subscriptionID, storage.WalletViewerRole, time.Now().Add(WALLET_INVITE_TTL))
+	if err != nil {
+		return "", err
+	} else if !created {
+		return "", ErrWalletNotOwned
+	}
+
+	return token, nil
+}
+
+func (s *WalletInviteService) Find(ctx context.Context, token string) (*storage.WalletInviteDB, error) {
+	return s.walletInvites.Find(ctx, token)
+}
+
+func (s *WalletInviteService) Accept(ctx context.Context, userID int64, invite *storage.WalletInviteDB) (storage.WalletInviteAcceptStatus, error) {
+	walletsCount, err := s.wallets.Count(ctx, userID, invite.Coin)
+	if err != nil {
+		return "", fmt.Errorf("failed to count user (id: %d) wallets before invite acceptance: %w", userID, err)
+	}
+
+	if walletsCount+1 > s.walletsLimitPerUser {
+		return "", ErrWalletsLimitExceeded
+	}
+
+	return s.walletInvites.Accept(ctx, userID, invite)
+}
+
+func NewWalletInviteService(
+	walletInvites storage.WalletInviteRepository,
+	wallets storage.WalletRepository,
+	walletsLimitPerUser int,
+) *WalletInviteService {
+	return &WalletInviteService{
+		walletInvites:       walletInvites,
+		wallets:             wallets,
+		walletsLimitPerUser: walletsLimitPerUser,
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	poolMinersProto "github.com/grandminingpool/pool-api-proto/generated/pool_miners"
+	poolPayoutsProto "github.com/grandminingpool/pool-api-proto/generated/pool_payouts"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	"go.uber.org/zap"
+)
+
+type WalletLookupResult struct {
+	Blockchain   *blockchains.BlockchainInfo
+	Wallet       string
+	Balance      uint64
+	Hashrate     *big.Int
+	WorkersCount int
+}
+
+type walletLookupCacheItem struct {
+	results   []WalletLookupResult
+	expiresAt time.Time
+}
+
+type walletLookupBlockchainResult struct {
+	Blockchain *blockchains.BlockchainInfo
+	Result     *WalletLookupResult
+	Err        error
+}
+
+type WalletLookupService struct {
+	blockchainsService *blockchains.Service
+	userWalletService  *UserWalletService
+	cacheTTL           time.Duration
+	mu                 sync.Mutex
+	cache              map[string]walletLookupCacheItem
+}
+
+func (s *WalletLookupService) lookupBlockchain(ctx context.Context, blockchain *blockchains.BlockchainInfo, wallet string) (*WalletLookupResult, error) {
+	valid, err := s.userWalletService.ValidateAddress(ctx, blockchain.Coin, wallet)
+	if err != nil {
+		return nil, err
+	} else if !valid {
+		return nil, nil
+	}
+
+	conn, err := s.blockchainsService.GetConnection(blockchain.Coin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) connection: %w", blockchain.Coin, err)
+	}
+
+	addresses := &poolMinersProto.MinerAddressesRequest{
+		Addresses: []string{wallet},
+	}
+	balances, err := poolPayoutsProto.NewPoolPayoutsServiceClient(conn).GetMinersBalancesFromList(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) wallet (%s) balance: %w", blockchain.Coin, wallet, err)
+	}
+
+	workers, err := poolMinersProto.NewPoolMinersServiceClient(conn).GetMinersWorkersFromList(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockchain (coin: %s) wallet (%s) workers: %w", blockchain.Coin, wallet, err)
+	}
+
+	result := &WalletLookupResult{
+		Blockchain: blockchain,
+		Wallet:     wallet,
+		Hashrate:   big.NewInt(0),
+	}
+
+	if balance, ok := balances.Balances[wallet]; ok {
+		result.Balance = balance.Balance
+	}
+
+	if wks, ok := workers.Workers[wallet]; ok {
+		result.WorkersCount = len(wks.Workers)
+		for _, wk := range wks.Workers {
+			result.Hashrate.Add(result.Hashrate, new(big.Int).SetBytes(wk.Hashrate))
+		}
+	}
+
+	return result, nil
+}
+
+func (s *WalletLookupService) Get(wallet string) ([]WalletLookupResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.cache[wallet]
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, false
+	}
+
+	return item.results, true
+}
+
+func (s *WalletLookupService) set(wallet string, results []WalletLookupResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, item := range s.cache {
+		if now.After(item.expiresAt) {
+			delete(s.cache, key)
+		}
+	}
+
+	s.cache[wallet] = walletLookupCacheItem{
+		results:   results,
+		expiresAt: now.Add(s.cacheTTL),
+	}
+}
+
+func (s *WalletLookupService) Lookup(ctx context.Context, wallet string) []WalletLookupResult {
+	if results, ok := s.Get(wallet); ok {
+		return results
+	}
+
+	blockchainsInfo := s.blockchainsService.GetBlockchainsInfo()
+	resultCh := make(chan walletLookupBlockchainResult, len(blockchainsInfo))
+	defer close(resultCh)
+
+	//	Coin of wallet is unknown, so address is validated by every available pool
+	requestsCount := 0
+	for i := range blockchainsInfo {
+		blockchain := &blockchainsInfo[i]
+		if !s.blockchainsService.IsAvailable(blockchain.Coin) {
+			continue
+		}
+
+		requestsCount++
+
+		go func(bc *blockchains.BlockchainInfo) {
+			result, err := s.lookupBlockchain(ctx, bc, wallet)
+			resultCh <- walletLookupBlockchainResult{
+				Blockchain: bc,
+				Result:     result,
+				Err:        err,
+			}
+		}(blockchain)
+	}
+
+	results := []WalletLookupResult{}
+	failed := false
+	for i := 0; i < requestsCount; i++ {
+		lookupResult := <-resultCh
+		if lookupResult.Err != nil {
+			zap.L().Warn("wallet lookup is unavailable",
+				zap.String("coin", lookupResult.Blockchain.Coin),
+				zap.Error(lookupResult.Err),
+			)
+
+			failed = true
+		} else if lookupResult.Result != nil {
+			results = append(results, *lookupResult.Result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Blockchain.Coin < results[j].Blockchain.Coin
+	})
+
+	//	Incomplete results are not cached to retry unavailable pools on next query
+	if !failed {
+		s.set(wallet, results)
+	}
+
+	return results
+}
+
+func NewWalletLookupService(
+	blockchainsService *blockchains.Service,
+	userWalletService *UserWalletService,
+	cacheTTL time.Duration,
+) *WalletLookupService {
+	return &WalletLookupService{
+		blockchainsService: blockchainsService,
+		userWalletService:  userWalletService,
+		cacheTTL:           cacheTTL,
+		cache:              make(map[string]walletLookupCacheItem),
+	}
+}
@@ -2,27 +2,284 @@ package poolBot
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/grandminingpool/telegram-bot/internal/bot/services"
+	"github.com/grandminingpool/telegram-bot/internal/common/constants"
 	"github.com/grandminingpool/telegram-bot/internal/common/languages"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
 )
 
-func SetBotDescription(ctx context.Context, b *bot.Bot, localizers []languages.LocalizersItem) error {
-	for _, l := range localizers {
-		ok, err := b.SetMyDescription(ctx, &bot.SetMyDescriptionParams{
-			Description: l.Localizer.MustLocalize(&i18n.LocalizeConfig{
-				MessageID: "BotDescription",
+const (
+	DEFAULT_LANGUAGE_KEY = "default"
+	ADMIN_SCOPE_PREFIX   = "admin_"
+)
+
+type botCommand struct {
+	command   constants.BotCommand
+	messageID string
+}
+
+var (
+	privateCommands = []botCommand{
+		{constants.StartCommand, "StartCommandDescription"},
+		{constants.ManageWalletsCommand, "ManageWalletsCommandDescription"},
+		{constants.InviteCommand, "InviteCommandDescription"},
+		{constants.ChatsCommand, "ChatsCommandDescription"},
+		{constants.ImportWalletsCommand, "ImportWalletsCommandDescription"},
+		{constants.ExportWalletsCommand, "ExportWalletsCommandDescription"},
+		{constants.FAQCommand, "FAQCommandDescription"},
+		{constants.ReportBugCommand, "ReportBugCommandDescription"},
+		{constants.MyDataCommand, "MyDataCommandDescription"},
+		{constants.DeleteMeCommand, "DeleteMeCommandDescription"},
+	}
+	groupCommands = []botCommand{
+		{constants.LinkChatCommand, "LinkChatCommandDescription"},
+	}
+	adminCommands = []botCommand{
+		{constants.AdminStatsCommand, "AdminStatsCommandDescription"},
+		{constants.AdminUserCommand, "AdminUserCommandDescription"},
+		{constants.AdminRunJobCommand, "AdminRunJobCommandDescription"},
+		{constants.AdminPauseCommand, "AdminPauseCommandDescription"},
+		{constants.AdminResumeCommand, "AdminResumeCommandDescription"},
+		{constants.AdminErrorsCommand, "AdminErrorsCommandDescription"},
+		{constants.BroadcastCommand, "BroadcastCommandDescription"},
+		{constants.BroadcastStatusCommand, "BroadcastStatusCommandDescription"},
+	}
+)
+
+type commandsScope struct {
+	name     string
+	scope    models.BotCommandScope
+	commands []botCommand
+}
+
+type metadataLocale struct {
+	key          string
+	languageCode string
+	localizer    *i18n.Localizer
+}
+
+type metadataSyncer struct {
+	b                  *bot.Bot
+	botMetadataService *services.BotMetadataService
+	hashes             map[string]string
+}
+
+func hashMetadata(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func localizeCommands(commands []botCommand, localizer *i18n.Localizer) []models.BotCommand {
+	localized := make([]models.BotCommand, 0, len(commands))
+	for _, c := range commands {
+		localized = append(localized, models.BotCommand{
+			Command: strings.TrimPrefix(string(c.command), "/"),
+			Description: localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: c.messageID,
 			}),
-			LanguageCode: l.Tag.String(),
 		})
+	}
+
+	return localized
+}
+
+func (s *metadataSyncer) sync(ctx context.Context, key string, value any, set func() (bool, error)) error {
+	hash, err := hashMetadata(value)
+	if err != nil {
+		return fmt.Errorf("failed to hash bot metadata (key: %s): %w", key, err)
+	}
+
+	//	Telegram limits metadata methods calls, so unchanged values are not sent on every start
+	if s.hashes[key] == hash {
+		return nil
+	}
+
+	ok, err := set()
+	if err != nil {
+		return fmt.Errorf("failed to set bot metadata (key: %s): %w", key, err)
+	} else if !ok {
+		return fmt.Errorf("unsuccessful set bot metadata (key: %s)", key)
+	}
+
+	zap.L().Info("synced bot metadata", zap.String("key", key))
+
+	return s.botMetadataService.SaveHash(ctx, key, hash)
+}
+
+func (s *metadataSyncer) syncLocale(ctx context.Context, locale metadataLocale, scopes []commandsScope) error {
+	name := locale.localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "BotName",
+	})
+	if err := s.sync(ctx, "name:"+locale.key, name, func() (bool, error) {
+		return s.b.SetMyName(ctx, &bot.SetMyNameParams{
+			Name:         name,
+			LanguageCode: locale.languageCode,
+		})
+	}); err != nil {
+		return err
+	}
+
+	shortDescription := locale.localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "BotShortDescription",
+	})
+	if err := s.sync(ctx, "short_description:"+locale.key, shortDescription, func() (bool, error) {
+		return s.b.SetMyShortDescription(ctx, &bot.SetMyShortDescriptionParams{
+			ShortDescription: shortDescription,
+			LanguageCode:     locale.languageCode,
+		})
+	}); err != nil {
+		return err
+	}
+
+	description := locale.localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "BotDescription",
+	})
+	if err := s.sync(ctx, "description:"+locale.key, description, func() (bool, error) {
+		return s.b.SetMyDescription(ctx, &bot.SetMyDescriptionParams{
+			Description:  description,
+			LanguageCode: locale.languageCode,
+		})
+	}); err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		commands := localizeCommands(scope.commands, locale.localizer)
+		if err := s.sync(ctx, "commands:"+scope.name+":"+locale.key, commands, func() (bool, error) {
+			return s.b.SetMyCommands(ctx, &bot.SetMyCommandsParams{
+				Commands:     commands,
+				Scope:        scope.scope,
+				LanguageCode: locale.languageCode,
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *metadataSyncer) deleteStaleAdminCommands(ctx context.Context, adminIDs []int64) error {
+	admins := make(map[string]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[ADMIN_SCOPE_PREFIX+strconv.FormatInt(id, 10)] = true
+	}
+
+	//	Users removed from admins must not keep admin commands in their menu
+	for key := range s.hashes {
+		if !strings.HasPrefix(key, "commands:"+ADMIN_SCOPE_PREFIX) {
+			continue
+		}
+
+		scopeName, locale, _ := strings.Cut(strings.TrimPrefix(key, "commands:"), ":")
+		if admins[scopeName] {
+			continue
+		}
+
+		chatID, err := strconv.ParseInt(strings.TrimPrefix(scopeName, ADMIN_SCOPE_PREFIX), 10, 64)
 		if err != nil {
-			return fmt.Errorf("failed to set bot description for locale: %s, error: %w", l.Tag.String(), err)
-		} else if !ok {
-			return fmt.Errorf("unsuccessful set bot description for locale: %s", l.Tag.String())
+			continue
+		}
+
+		languageCode := locale
+		if locale == DEFAULT_LANGUAGE_KEY {
+			languageCode = ""
+		}
+
+		if _, err := s.b.DeleteMyCommands(ctx, &bot.DeleteMyCommandsParams{
+			Scope:        &models.BotCommandScopeChat{ChatID: chatID},
+			LanguageCode: languageCode,
+		}); err != nil {
+			return fmt.Errorf("failed to delete bot metadata (key: %s): %w", key, err)
+		}
+
+		if err := s.botMetadataService.DeleteHash(ctx, key); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
+
+func SyncBotMetadata(
+	ctx context.Context,
+	b *bot.Bot,
+	botMetadataService *services.BotMetadataService,
+	adminService *services.AdminService,
+	languages *languages.Languages,
+) error {
+	hashes, err := botMetadataService.FindHashes(ctx)
+	if err != nil {
+		return err
+	}
+
+	adminIDs, err := adminService.GetAdminIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	scopes := []commandsScope{
+		{
+			name:     "default",
+			scope:    &models.BotCommandScopeDefault{},
+			commands: privateCommands,
+		},
+		{
+			name:     "groups",
+			scope:    &models.BotCommandScopeAllGroupChats{},
+			commands: groupCommands,
+		},
+	}
+
+	//	Chat scope replaces default commands, so admin menu contains both sets
+	for _, id := range adminIDs {
+		scopes = append(scopes, commandsScope{
+			name:     ADMIN_SCOPE_PREFIX + strconv.FormatInt(id, 10),
+			scope:    &models.BotCommandScopeChat{ChatID: id},
+			commands: append(append([]botCommand{}, privateCommands...), adminCommands...),
+		})
+	}
+
+	//	Metadata without language code is shown to users with not loaded locales
+	locales := []metadataLocale{{
+		key:       DEFAULT_LANGUAGE_KEY,
+		localizer: languages.GetLocalizer(languages.FallbackTag().String()),
+	}}
+	for _, l := range languages.GetLocalizers() {
+		locales = append(locales, metadataLocale{
+			key:          l.Tag.String(),
+			languageCode: l.Tag.String(),
+			localizer:    l.Localizer,
+		})
+	}
+
+	syncer := &metadataSyncer{
+		b:                  b,
+		botMetadataService: botMetadataService,
+		hashes:             hashes,
+	}
+
+	for _, locale := range locales {
+		if err := syncer.syncLocale(ctx, locale, scopes); err != nil {
+			return err
+		}
+	}
+
+	return syncer.deleteStaleAdminCommands(ctx, adminIDs)
+}
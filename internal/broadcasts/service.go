@@ -0,0 +1,236 @@
+package broadcasts
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	telegramUtils "github.com/grandminingpool/telegram-bot/internal/utils/telegram"
+	"go.uber.org/zap"
+)
+
+const BROADCAST_MESSAGE = "broadcast"
+
+var (
+	ErrBroadcastNotFound = errors.New("broadcast not found")
+	ErrEmptyBroadcast    = errors.New("broadcast has no messages")
+)
+
+type Service struct {
+	broadcasts     storage.BroadcastRepository
+	b              *bot.Bot
+	privacyService *privacy.Service
+	config         *botConfig.BroadcastConfig
+	serviceCtx     context.Context
+	wg             sync.WaitGroup
+}
+
+func (s *Service) Find(ctx context.Context, id int64) (*storage.Broadcast, error) {
+	return s.broadcasts.Find(ctx, id)
+}
+
+func (s *Service) FindDraft(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	return s.broadcasts.FindDraft(ctx, adminID)
+}
+
+func (s *Service) FindLatest(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	return s.broadcasts.FindLatest(ctx, adminID)
+}
+
+func (s *Service) CreateDraft(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	return s.broadcasts.CreateDraft(ctx, adminID)
+}
+
+func (s *Service) SetMessage(ctx context.Context, id int64, lang, text string) error {
+	return s.broadcasts.SetMessage(ctx, id, lang, text)
+}
+
+func (s *Service) SetCoins(ctx context.Context, id int64, coins []string) error {
+	return s.broadcasts.SetCoins(ctx, id, coins)
+}
+
+func (s *Service) Cancel(ctx context.Context, id int64) error {
+	return s.broadcasts.Cancel(ctx, id)
+}
+
+func (s *Service) CountRecipients(ctx context.Context, id int64) (int64, error) {
+	return s.broadcasts.CountRecipients(ctx, id)
+}
+
+func (s *Service) Stats(ctx context.Context, id int64) (*storage.DeliveryStats, error) {
+	return s.broadcasts.Stats(ctx, id)
+}
+
+func (s *Service) Send(ctx context.Context, id int64) (int64, error) {
+	broadcast, err := s.Find(ctx, id)
+	if err != nil {
+		return 0, err
+	} else if broadcast == nil || broadcast.Status != storage.BroadcastDraft {
+		return 0, ErrBroadcastNotFound
+	} else if len(broadcast.Messages) == 0 {
+		return 0, ErrEmptyBroadcast
+	}
+
+	recipients, started, err := s.broadcasts.Start(ctx, id)
+	if err != nil {
+		return 0, err
+	} else if !started {
+		return 0, ErrBroadcastNotFound
+	}
+
+	zap.L().Info("broadcast started",
+		zap.Int64("broadcast_id", id),
+		zap.Int64("admin_id", broadcast.CreatedBy),
+		zap.Int64("recipients", recipients),
+	)
+
+	s.wg.Add(1)
+	go s.deliver(s.serviceCtx, broadcast)
+
+	return recipients, nil
+}
+
+func (s *Service) setDeliveryStatus(ctx context.Context, id, userID int64, status storage.DeliveryStatus, deliveryErr error) error {
+	var errText *string
+	if deliveryErr != nil {
+		text := deliveryErr.Error()
+		errText = &text
+	}
+
+	return s.broadcasts.SetDeliveryStatus(ctx, id, userID, status, errText)
+}
+
+func (s *Service) sendMessage(ctx context.Context, chatID int64, text string) error {
+	for {
+		_, err := s.b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+
+		var tooManyRequestsErr *bot.TooManyRequestsError
+		if !errors.As(err, &tooManyRequestsErr) {
+			metrics.MessagesTotal.WithLabelValues(BROADCAST_MESSAGE, metrics.Status(err)).Inc()
+
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(tooManyRequestsErr.RetryAfter) * time.Second):
+		}
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, broadcast *storage.Broadcast) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.SendInterval())
+	defer ticker.Stop()
+
+	for {
+		deliveries, err := s.broadcasts.FindPendingDeliveries(ctx, broadcast.ID, s.config.BatchSize)
+		if err != nil {
+			zap.L().Error("failed to query broadcast pending deliveries",
+				zap.Int64("broadcast_id", broadcast.ID),
+				zap.Error(err),
+			)
+
+			return
+		}
+
+		if len(deliveries) == 0 {
+			break
+		}
+
+		for _, delivery := range deliveries {
+			status := storage.DeliverySent
+			var deliveryErr error
+
+			if !delivery.BroadcastsNotify || !delivery.Active {
+				status = storage.DeliverySkipped
+			} else {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+
+				if deliveryErr = s.sendMessage(ctx, delivery.ChatID, broadcast.Text(delivery.Lang)); deliveryErr != nil {
+					if ctx.Err() != nil {
+						return
+					}
+
+					status = storage.DeliveryFailed
+				}
+			}
+
+			if err := s.setDeliveryStatus(ctx, broadcast.ID, delivery.UserID, status, deliveryErr); err != nil {
+				zap.L().Error("failed to update broadcast delivery status", zap.Error(err))
+
+				return
+			}
+
+			if kind := telegramUtils.ClassifySendError(deliveryErr); deliveryErr != nil && kind.Unreachable() {
+				if err := s.privacyService.Deactivate(ctx, delivery.ChatID, kind); err != nil {
+					zap.L().Error("failed to deactivate broadcast recipient", zap.Error(err))
+				}
+			}
+		}
+	}
+
+	if err := s.broadcasts.Finish(ctx, broadcast.ID); err != nil {
+		zap.L().Error("failed to finish broadcast",
+			zap.Int64("broadcast_id", broadcast.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	zap.L().Info("broadcast finished", zap.Int64("broadcast_id", broadcast.ID))
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	s.serviceCtx = ctx
+
+	//	Continue broadcasts interrupted by restart
+	ids, err := s.broadcasts.FindSendingIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		broadcast, err := s.Find(ctx, id)
+		if err != nil {
+			return err
+		} else if broadcast == nil {
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.deliver(ctx, broadcast)
+	}
+
+	return nil
+}
+
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+func NewService(broadcasts storage.BroadcastRepository, b *bot.Bot, privacyService *privacy.Service, config *botConfig.BroadcastConfig) *Service {
+	return &Service{
+		broadcasts:     broadcasts,
+		b:              b,
+		privacyService: privacyService,
+		config:         config,
+		serviceCtx:     context.Background(),
+	}
+}
@@ -0,0 +1,195 @@
+package poolAPIClient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+type CertsWatcher struct {
+	mu         sync.RWMutex
+	caFile     string
+	certFile   string
+	keyFile    string
+	rootCAs    *x509.CertPool
+	clientCert *tls.Certificate
+	watcher    *fsnotify.Watcher
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+func (w *CertsWatcher) load() error {
+	caPEM, err := os.ReadFile(w.caFile)
+	if err != nil {
+		return fmt.Errorf("failed to read pool api CA certificate (file: %s): %w", w.caFile, err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("failed to parse pool api CA certificate (file: %s)", w.caFile)
+	}
+
+	var clientCert *tls.Certificate
+	if w.certFile != "" && w.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(w.certFile, w.keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load pool api client certificate (cert: %s, key: %s): %w", w.certFile, w.keyFile, err)
+		}
+
+		clientCert = &cert
+	}
+
+	w.mu.Lock()
+	w.rootCAs = rootCAs
+	w.clientCert = clientCert
+	w.mu.Unlock()
+
+	return nil
+}
+
+func (w *CertsWatcher) isWatchedFile(name string) bool {
+	name = filepath.Clean(name)
+
+	return name == w.caFile || name == w.certFile || name == w.keyFile
+}
+
+func (w *CertsWatcher) watch() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			//	Secrets mounted by orchestrators are replaced via symlink swap,
+			//	so any change in watched directories triggers reload
+			if !w.isWatchedFile(event.Name) && !event.Has(fsnotify.Create) {
+				continue
+			}
+
+			if err := w.load(); err != nil {
+				zap.L().Warn("failed to reload pool api certificates, keeping previous ones",
+					zap.String("event", event.String()),
+					zap.Error(err),
+				)
+			} else {
+				zap.L().Info("reloaded pool api certificates", zap.String("event", event.String()))
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			zap.L().Error("pool api certificates watcher error", zap.Error(err))
+		}
+	}
+}
+
+func (w *CertsWatcher) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.clientCert == nil {
+		return &tls.Certificate{}, nil
+	}
+
+	return w.clientCert, nil
+}
+
+func (w *CertsWatcher) VerifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("pool api server didn't provide certificate")
+	}
+
+	w.mu.RLock()
+	rootCAs := w.rootCAs
+	w.mu.RUnlock()
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         rootCAs,
+		Intermediates: intermediates,
+		DNSName:       cs.ServerName,
+	}); err != nil {
+		return fmt.Errorf("failed to verify pool api server certificate: %w", err)
+	}
+
+	return nil
+}
+
+func (w *CertsWatcher) TLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		//	Server certificate is verified in VerifyConnection against
+		//	reloadable CA pool instead of the static RootCAs
+		InsecureSkipVerify:   true,
+		VerifyConnection:     w.VerifyConnection,
+		GetClientCertificate: w.GetClientCertificate,
+	}
+}
+
+func (w *CertsWatcher) Close() error {
+	close(w.done)
+	err := w.watcher.Close()
+	w.wg.Wait()
+
+	return err
+}
+
+func NewCertsWatcher(caFile, certFile, keyFile string) (*CertsWatcher, error) {
+	w := &CertsWatcher{
+		caFile: filepath.Clean(caFile),
+		done:   make(chan struct{}),
+	}
+
+	if certFile != "" && keyFile != "" {
+		w.certFile = filepath.Clean(certFile)
+		w.keyFile = filepath.Clean(keyFile)
+	}
+
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool api certificates watcher: %w", err)
+	}
+
+	dirs := map[string]struct{}{}
+	for _, file := range []string{w.caFile, w.certFile, w.keyFile} {
+		if file != "" {
+			dirs[filepath.Dir(file)] = struct{}{}
+		}
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+
+			return nil, fmt.Errorf("failed to watch pool api certificates directory (path: %s): %w", dir, err)
+		}
+	}
+
+	w.watcher = watcher
+	w.wg.Add(1)
+	go w.watch()
+
+	return w, nil
+}
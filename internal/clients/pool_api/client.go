@@ -1,22 +1,103 @@
 package poolAPIClient
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 
+	"github.com/grandminingpool/telegram-bot/internal/common/flags"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
-func NewClient(address, certsPath, caCertFile, serverName string) (*grpc.ClientConn, error) {
-	creds, err := credentials.NewClientTLSFromFile(fmt.Sprintf("%s/%s", caCertFile, certsPath), serverName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load pool api client certificate: %w", err)
+var ErrInsecureNotAllowed = errors.New("insecure pool api connection is allowed only in dev mode")
+
+type Config struct {
+	Address    string
+	CertsPath  string
+	TLSCA      string
+	TLSCert    string
+	TLSKey     string
+	ServerName string
+	Token      string
+	Insecure   bool
+}
+
+type Client struct {
+	conn         *grpc.ClientConn
+	certsWatcher *CertsWatcher
+}
+
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+func (c *Client) Close() error {
+	err := c.conn.Close()
+
+	if c.certsWatcher != nil {
+		if watcherErr := c.certsWatcher.Close(); watcherErr != nil && err == nil {
+			err = watcherErr
+		}
+	}
+
+	return err
+}
+
+func certFilePath(certsPath, file string) string {
+	if file == "" {
+		return ""
 	}
 
-	client, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	return filepath.Join(certsPath, file)
+}
+
+func NewClient(config *Config, appMode flags.AppMode, opts ...grpc.DialOption) (*Client, error) {
+	client := &Client{}
+	dialOptions := []grpc.DialOption{}
+
+	if config.Insecure {
+		if appMode != flags.AppModeDev {
+			return nil, ErrInsecureNotAllowed
+		}
+
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		if (config.TLSCert == "") != (config.TLSKey == "") {
+			return nil, errors.New("pool api client certificate and key must be set together")
+		}
+
+		certsWatcher, err := NewCertsWatcher(
+			certFilePath(config.CertsPath, config.TLSCA),
+			certFilePath(config.CertsPath, config.TLSCert),
+			certFilePath(config.CertsPath, config.TLSKey),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pool api client certificates: %w", err)
+		}
+
+		client.certsWatcher = certsWatcher
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(certsWatcher.TLSConfig(config.ServerName))))
+	}
+
+	if config.Token != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(&tokenCredentials{
+			token:                    config.Token,
+			requireTransportSecurity: !config.Insecure,
+		}))
+	}
+
+	conn, err := grpc.NewClient(config.Address, append(dialOptions, opts...)...)
 	if err != nil {
+		if client.certsWatcher != nil {
+			client.certsWatcher.Close()
+		}
+
 		return nil, fmt.Errorf("failed to create pool api client: %w", err)
 	}
 
+	client.conn = conn
+
 	return client, nil
 }
@@ -0,0 +1,21 @@
+package poolAPIClient
+
+import (
+	"context"
+	"fmt"
+)
+
+type tokenCredentials struct {
+	token                    string
+	requireTransportSecurity bool
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", c.token),
+	}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTransportSecurity
+}
@@ -7,3 +7,36 @@ const (
 	FAQCommand       BotCommand = "/faq"
 	ReportBugCommand BotCommand = "/reportbug"
 )
+
+const SupportCloseCommand BotCommand = "/close"
+
+const (
+	AdminStatsCommand  BotCommand = "/stats"
+	AdminUserCommand   BotCommand = "/user"
+	AdminRunJobCommand BotCommand = "/runjob"
+	AdminPauseCommand  BotCommand = "/pause"
+	AdminResumeCommand BotCommand = "/resume"
+	AdminErrorsCommand BotCommand = "/errors"
+)
+
+const (
+	BroadcastCommand       BotCommand = "/broadcast"
+	BroadcastStatusCommand BotCommand = "/broadcaststatus"
+)
+
+const (
+	LinkChatCommand BotCommand = "/linkchat"
+	ChatsCommand    BotCommand = "/chats"
+)
+
+const (
+	InviteCommand        BotCommand = "/invite"
+	ManageWalletsCommand BotCommand = "/manage"
+	ImportWalletsCommand BotCommand = "/import"
+	ExportWalletsCommand BotCommand = "/export"
+)
+
+const (
+	MyDataCommand   BotCommand = "/mydata"
+	DeleteMeCommand BotCommand = "/deleteme"
+)
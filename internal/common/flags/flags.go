@@ -29,6 +29,7 @@ type ParsedFlags struct {
 	LoggerErrorOutputPath *string
 	LocalesPath           *string
 	Locales               *Locales
+	Args                  []string
 }
 
 type FlagsLoggerConfig struct {
@@ -43,6 +44,7 @@ type FlagsConfig struct {
 	Logger      FlagsLoggerConfig
 	LocalesPath string
 	Locales     Locales
+	Command     []string
 }
 
 func ParseFlags() *ParsedFlags {
@@ -66,6 +68,8 @@ func ParseFlags() *ParsedFlags {
 
 	flag.Parse()
 
+	parsedFlags.Args = flag.Args()
+
 	return parsedFlags
 }
 
@@ -104,7 +108,7 @@ func SetupFlags(parsedFlags *ParsedFlags) *FlagsConfig {
 		localesPath = *parsedFlags.LocalesPath
 	}
 
-	if parsedFlags.Locales != nil {
+	if parsedFlags.Locales != nil && len(*parsedFlags.Locales) > 0 {
 		locales = *parsedFlags.Locales
 	}
 
@@ -112,7 +116,9 @@ func SetupFlags(parsedFlags *ParsedFlags) *FlagsConfig {
 		Mode:        appMode,
 		ConfigsPath: configsPath,
 		CertsPath:   certsPath,
+		Logger:      loggerConfig,
 		LocalesPath: localesPath,
 		Locales:     locales,
+		Command:     parsedFlags.Args,
 	}
 }
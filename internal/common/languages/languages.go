@@ -2,6 +2,7 @@ package languages
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/pelletier/go-toml/v2"
@@ -13,6 +14,7 @@ type Languages struct {
 	fallbackLocale    language.Tag
 	fallbackLocalizer *i18n.Localizer
 	localizers        map[language.Tag]*i18n.Localizer
+	tags              []language.Tag
 }
 
 type LocalizersItem struct {
@@ -21,30 +23,40 @@ type LocalizersItem struct {
 }
 
 func (l *Languages) GetLocalizers() []LocalizersItem {
-	localizers := make([]LocalizersItem, 0, len(l.localizers))
+	localizers := make([]LocalizersItem, 0, len(l.tags))
 
-	for tag, localizer := range l.localizers {
+	//	Locales are returned in flag order to keep languages keyboard stable
+	for _, tag := range l.tags {
 		localizers = append(localizers, LocalizersItem{
 			Tag:       tag,
-			Localizer: localizer,
+			Localizer: l.localizers[tag],
 		})
 	}
 
 	return localizers
 }
 
+func (l *Languages) FallbackTag() language.Tag {
+	return l.fallbackLocale
+}
+
 func (l *Languages) GetLocalizer(locale string) *i18n.Localizer {
 	tag, err := language.Parse(locale)
 	if err != nil {
 		tag = l.fallbackLocale
 	}
 
-	localizer, ok := l.localizers[tag]
-	if !ok {
-		return l.fallbackLocalizer
+	if localizer, ok := l.localizers[tag]; ok {
+		return localizer
+	}
+
+	//	Telegram sends regional codes like "zh-hans" or "pt-br", so base language is used for them
+	base, _ := tag.Base()
+	if localizer, ok := l.localizers[language.Make(base.String())]; ok {
+		return localizer
 	}
 
-	return localizer
+	return l.fallbackLocalizer
 }
 
 func LoadLanguages(localesPath string, locales []language.Tag) (*Languages, error) {
@@ -59,6 +71,11 @@ func LoadLanguages(localesPath string, locales []language.Tag) (*Languages, erro
 		localizers:        make(map[language.Tag]*i18n.Localizer),
 	}
 
+	//	Fallback messages are required for keys missing in other locales
+	if !slices.Contains(locales, fallbackLocale) {
+		locales = append([]language.Tag{fallbackLocale}, locales...)
+	}
+
 	for _, tag := range locales {
 		file := fmt.Sprintf("active.%s.toml", tag.String())
 		_, err := bundle.LoadMessageFile(fmt.Sprintf("%s/%s", localesPath, file))
@@ -68,6 +85,7 @@ func LoadLanguages(localesPath string, locales []language.Tag) (*Languages, erro
 
 		localizer := i18n.NewLocalizer(bundle, tag.String())
 		languages.localizers[tag] = localizer
+		languages.tags = append(languages.tags, tag)
 	}
 
 	return languages, nil
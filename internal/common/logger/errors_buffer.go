@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type ErrorEntry struct {
+	Time    time.Time
+	Message string
+	Fields  string
+}
+
+// ErrorsBuffer keeps last error log entries in memory, so they can be
+// viewed by admins without access to log files
+type ErrorsBuffer struct {
+	mu      sync.Mutex
+	entries []ErrorEntry
+	next    int
+	size    int
+}
+
+func (b *ErrorsBuffer) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.ErrorLevel
+}
+
+func (b *ErrorsBuffer) With(fields []zapcore.Field) zapcore.Core {
+	return &errorsBufferCore{
+		buffer: b,
+		fields: fields,
+	}
+}
+
+func (b *ErrorsBuffer) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if b.Enabled(entry.Level) {
+		return checked.AddCore(entry, b)
+	}
+
+	return checked
+}
+
+func (b *ErrorsBuffer) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	b.add(entry, fields)
+
+	return nil
+}
+
+func (b *ErrorsBuffer) Sync() error {
+	return nil
+}
+
+func encodeFields(fields []zapcore.Field) string {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	keys := make([]string, 0, len(encoder.Fields))
+	for key := range encoder.Fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, encoder.Fields[key]))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+func (b *ErrorsBuffer) add(entry zapcore.Entry, fields []zapcore.Field) {
+	errorEntry := ErrorEntry{
+		Time:    entry.Time,
+		Message: entry.Message,
+		Fields:  encodeFields(fields),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) < b.size {
+		b.entries = append(b.entries, errorEntry)
+	} else {
+		b.entries[b.next] = errorEntry
+	}
+
+	b.next = (b.next + 1) % b.size
+}
+
+// Recent returns up to limit last error entries, newest first
+func (b *ErrorsBuffer) Recent(limit int) []ErrorEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := len(b.entries)
+	if limit <= 0 || limit > count {
+		limit = count
+	}
+
+	entries := make([]ErrorEntry, 0, limit)
+	for i := 1; i <= limit; i++ {
+		entries = append(entries, b.entries[(b.next-i+count)%count])
+	}
+
+	return entries
+}
+
+func (b *ErrorsBuffer) WrapCore() zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, b)
+	})
+}
+
+type errorsBufferCore struct {
+	buffer *ErrorsBuffer
+	fields []zapcore.Field
+}
+
+func (c *errorsBufferCore) Enabled(level zapcore.Level) bool {
+	return c.buffer.Enabled(level)
+}
+
+func (c *errorsBufferCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorsBufferCore{
+		buffer: c.buffer,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *errorsBufferCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c *errorsBufferCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.buffer.add(entry, append(c.fields[:len(c.fields):len(c.fields)], fields...))
+
+	return nil
+}
+
+func (c *errorsBufferCore) Sync() error {
+	return nil
+}
+
+func NewErrorsBuffer(size int) *ErrorsBuffer {
+	if size < 1 {
+		size = 1
+	}
+
+	return &ErrorsBuffer{
+		entries: make([]ErrorEntry, 0, size),
+		size:    size,
+	}
+}
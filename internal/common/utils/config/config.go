@@ -1,6 +1,8 @@
 package configUtils
 
 import (
+	"errors"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
 	configErrors "github.com/grandminingpool/telegram-bot/internal/common/errors/config"
@@ -14,6 +16,19 @@ func ReadConfig(viper *viper.Viper, configName string) error {
 	return nil
 }
 
+func ReadOptionalConfig(v *viper.Viper, configName string) error {
+	if err := v.ReadInConfig(); err != nil {
+		var notFoundErr viper.ConfigFileNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil
+		}
+
+		return &configErrors.ReadConfigError{ConfigName: configName, Err: err}
+	}
+
+	return nil
+}
+
 func LoadConfig[T any](viper *viper.Viper, validate *validator.Validate, configName string) (*T, error) {
 	var config T
 	if err := viper.Unmarshal(&config); err != nil {
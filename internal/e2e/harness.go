@@ -0,0 +1,239 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
+	"github.com/grandminingpool/telegram-bot/internal/app"
+	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	storageTest "github.com/grandminingpool/telegram-bot/internal/storage/storagetest"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+const (
+	FAKE_POOL_URL       = "passthrough:///fake-pool"
+	JOB_WAIT_TIMEOUT    = 10 * time.Second
+	JOB_WAIT_POLL_DELAY = 10 * time.Millisecond
+)
+
+var ErrJobTimeout = errors.New("notify job has not finished in time")
+
+func DefaultConfig() *botConfig.Config {
+	return &botConfig.Config{
+		BotToken: FAKE_BOT_TOKEN,
+		PoolURL:  "https://pool.e2e.test",
+		SupportBot: botConfig.SupportBotConfig{
+			UserID:   1,
+			Username: "e2e_support",
+		},
+		WalletsLimitPerUser: 50,
+		Notify: botConfig.NotifyConfig{
+			//	Small limits split checks of several wallets to multiple pool requests and notification groups
+			MaxWalletsInPayoutsRequest: 2,
+			MaxWalletsInWorkersRequest: 2,
+			MaxUsersDBChangesLimit:     2,
+			ParallelNotificationsCount: 2,
+			//	Jobs are only run by scenarios, scheduled runs must not interfere
+			CheckIntervals: botConfig.CheckIntervalsConfig{
+				Workers: 24 * 60,
+				Payouts: 24 * 60,
+			},
+		},
+		PoolAPI: botConfig.PoolAPIConfig{
+			CallTimeout:         5,
+			HealthCheckInterval: 15,
+			HealthCheckTimeout:  3,
+			FailureThreshold:    3,
+			OpenTimeout:         30,
+		},
+		Admin: botConfig.AdminConfig{
+			RecentErrorsLimit: 50,
+		},
+		Broadcast: botConfig.BroadcastConfig{
+			MessagesPerSecond: 20,
+			BatchSize:         100,
+		},
+		InlineQuery: botConfig.InlineQueryConfig{
+			CacheTime:         60,
+			RateLimitInterval: 2,
+		},
+		WalletImport: botConfig.WalletImportConfig{
+			MaxLines:    100,
+			MaxFileSize: 262144,
+			Concurrency: 8,
+		},
+		Privacy: botConfig.PrivacyConfig{
+			InactiveRetention: 30,
+			CleanupInterval:   60,
+		},
+	}
+}
+
+func DefaultBlockchain() storage.BlockchainDB {
+	return storage.BlockchainDB{
+		Coin:          "btc",
+		Name:          "Bitcoin",
+		Ticker:        "BTC",
+		Decimals:      8,
+		ExampleWallet: "bc1qexamplewallet",
+		PoolAPIDB: storage.PoolAPIDB{
+			URL:      FAKE_POOL_URL,
+			Insecure: true,
+		},
+	}
+}
+
+type Harness struct {
+	Telegram     *FakeTelegram
+	Pool         *FakePool
+	DB           storageTest.DB
+	Bot          *bot.Bot
+	Languages    *languages.Languages
+	Config       *botConfig.Config
+	Blockchain   storage.BlockchainDB
+	app          *app.App
+	cancel       context.CancelFunc
+	updatesMu    sync.Mutex
+	lastUpdateID int64
+}
+
+func (h *Harness) nextUpdateID() int64 {
+	h.updatesMu.Lock()
+	defer h.updatesMu.Unlock()
+
+	h.lastUpdateID++
+
+	return h.lastUpdateID
+}
+
+func (h *Harness) Localize(lang, messageID string, templateData map[string]string) string {
+	return h.Languages.GetLocalizer(lang).MustLocalize(&i18n.LocalizeConfig{
+		MessageID:    messageID,
+		TemplateData: templateData,
+	})
+}
+
+func (h *Harness) SendText(ctx context.Context, userID int64, text string) {
+	updateID := h.nextUpdateID()
+
+	//	Private message from user is handled synchronously, so its answers are sent after return
+	h.Bot.ProcessUpdate(ctx, &models.Update{
+		ID: updateID,
+		Message: &models.Message{
+			ID: int(updateID),
+			From: &models.User{
+				ID:           userID,
+				FirstName:    "Miner",
+				Username:     fmt.Sprintf("miner_%d", userID),
+				LanguageCode: "en",
+			},
+			Chat: models.Chat{
+				ID:   userID,
+				Type: "private",
+			},
+			Date: int(time.Now().Unix()),
+			Text: text,
+		},
+	})
+}
+
+func (h *Harness) RunJob(ctx context.Context, name string) error {
+	//	Job runs in scheduler, so its result is awaited from job states
+	startedAt := time.Now()
+	if err := h.app.Notify.RunNow(name); err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, JOB_WAIT_TIMEOUT)
+	defer cancel()
+
+	for {
+		for _, state := range h.app.Notify.JobStates() {
+			if state.Name == name && state.LastRunAt != nil && !state.LastRunAt.Before(startedAt) {
+				if state.LastError != "" {
+					return fmt.Errorf("notify job (name: %s) failed: %s", name, state.LastError)
+				}
+
+				return nil
+			}
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			return fmt.Errorf("%w (name: %s)", ErrJobTimeout, name)
+		case <-time.After(JOB_WAIT_POLL_DELAY):
+		}
+	}
+}
+
+func (h *Harness) Close() {
+	if h.app != nil {
+		h.app.Notify.Stop()
+	}
+
+	h.cancel()
+
+	if h.app != nil {
+		h.app.Broadcasts.Wait()
+		h.app.Privacy.Wait()
+		h.app.Blockchains.Close()
+	}
+
+	h.Pool.Close()
+	h.Telegram.Close()
+}
+
+func NewHarness(ctx context.Context, localesPath string, db storageTest.DB) (*Harness, error) {
+	//	App is created the same way as in cmd/bot, only with given storage, fake telegram and fake pool api
+	languages, err := languages.LoadLanguages(localesPath, []language.Tag{language.English})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load languages: %w", err)
+	}
+
+	config := DefaultConfig()
+	blockchain := DefaultBlockchain()
+	db.AddBlockchain(blockchain)
+	store := db.Storage()
+
+	harnessCtx, cancel := context.WithCancel(ctx)
+	h := &Harness{
+		Telegram:   NewFakeTelegram(),
+		Pool:       NewFakePool(blockchain.Name),
+		DB:         db,
+		Languages:  languages,
+		Config:     config,
+		Blockchain: blockchain,
+		cancel:     cancel,
+	}
+
+	h.app, err = app.New(
+		harnessCtx,
+		config,
+		languages,
+		store,
+		app.WithServerURL(h.Telegram.URL()),
+		app.WithDialOptions(h.Pool.DialOptions()...),
+	)
+	if err != nil {
+		h.Close()
+
+		return nil, fmt.Errorf("failed to create app: %w", err)
+	}
+
+	h.Bot = h.app.Bot
+	if err := h.app.Start(harnessCtx); err != nil {
+		h.Close()
+
+		return nil, err
+	}
+
+	return h, nil
+}
@@ -0,0 +1,294 @@
+package e2e
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	poolProto "github.com/grandminingpool/pool-api-proto/generated/pool"
+	poolMinersProto "github.com/grandminingpool/pool-api-proto/generated/pool_miners"
+	poolPayoutsProto "github.com/grandminingpool/pool-api-proto/generated/pool_payouts"
+	filtersProto "github.com/grandminingpool/pool-api-proto/generated/utils/filters"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthProto "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const BUFCONN_SIZE = 1024 * 1024
+
+type FakeWorker struct {
+	Worker      string
+	Region      string
+	Solo        bool
+	ConnectedAt time.Time
+}
+
+type FakePayout struct {
+	TxHash string
+	Amount uint64
+	PaidAt time.Time
+}
+
+type FakeSoloBlock struct {
+	BlockHash string
+	TxHash    string
+	Reward    uint64
+	MinedAt   time.Time
+}
+
+type poolState struct {
+	mu         sync.RWMutex
+	validators map[string]bool
+	workers    map[string][]FakeWorker
+	balances   map[string]uint64
+	payouts    map[string][]FakePayout
+	soloBlocks map[string][]FakeSoloBlock
+}
+
+func inRange(t time.Time, filter *filtersProto.DateTimeRangeFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.Start != nil && t.Before(filter.Start.AsTime()) {
+		return false
+	}
+
+	return filter.End == nil || !t.After(filter.End.AsTime())
+}
+
+type poolMinersServer struct {
+	poolMinersProto.UnimplementedPoolMinersServiceServer
+	state *poolState
+}
+
+func (s *poolMinersServer) ValidateAddress(ctx context.Context, req *poolMinersProto.MinerAddressRequest) (*poolMinersProto.ValidateAddressResponse, error) {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+
+	return &poolMinersProto.ValidateAddressResponse{
+		Valid: s.state.validators[req.Address],
+	}, nil
+}
+
+func (s *poolMinersServer) GetMinersWorkersFromList(ctx context.Context, req *poolMinersProto.MinerAddressesRequest) (*poolMinersProto.MinersWorkersMap, error) {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+
+	//	Like real pool, miners without connected workers are omitted
+	workersMap := make(map[string]*poolMinersProto.MinerWorkers)
+	for _, address := range req.Addresses {
+		workers, ok := s.state.workers[address]
+		if !ok || len(workers) == 0 {
+			continue
+		}
+
+		minerWorkers := &poolMinersProto.MinerWorkers{
+			Workers: make([]*poolMinersProto.MinerWorker, 0, len(workers)),
+		}
+		for _, worker := range workers {
+			minerWorkers.Workers = append(minerWorkers.Workers, &poolMinersProto.MinerWorker{
+				Worker:      worker.Worker,
+				Region:      worker.Region,
+				Solo:        worker.Solo,
+				ConnectedAt: timestamppb.New(worker.ConnectedAt),
+			})
+		}
+
+		workersMap[address] = minerWorkers
+	}
+
+	return &poolMinersProto.MinersWorkersMap{
+		Workers: workersMap,
+	}, nil
+}
+
+type poolPayoutsServer struct {
+	poolPayoutsProto.UnimplementedPoolPayoutsServiceServer
+	state *poolState
+}
+
+func (s *poolPayoutsServer) GetMinersBalancesFromList(ctx context.Context, req *poolMinersProto.MinerAddressesRequest) (*poolPayoutsProto.MinersBalancesMap, error) {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+
+	balancesMap := make(map[string]*poolPayoutsProto.MinerBalance)
+	for _, address := range req.Addresses {
+		if balance, ok := s.state.balances[address]; ok {
+			balancesMap[address] = &poolPayoutsProto.MinerBalance{
+				Balance: balance,
+			}
+		}
+	}
+
+	return &poolPayoutsProto.MinersBalancesMap{
+		Balances: balancesMap,
+	}, nil
+}
+
+func (s *poolPayoutsServer) GetPayoutsFromList(ctx context.Context, req *poolPayoutsProto.GetPayoutsFromListRequest) (*poolPayoutsProto.PayoutsMap, error) {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+
+	var paidAtFilter *filtersProto.DateTimeRangeFilter
+	if req.Filters != nil {
+		paidAtFilter = req.Filters.PaidAt
+	}
+
+	payoutsMap := make(map[string]*poolPayoutsProto.Payouts)
+	for _, miner := range req.Miners {
+		minerPayouts := &poolPayoutsProto.Payouts{}
+		for _, payout := range s.state.payouts[miner] {
+			if inRange(payout.PaidAt, paidAtFilter) {
+				minerPayouts.Payouts = append(minerPayouts.Payouts, &poolPayoutsProto.Payout{
+					Miner:  miner,
+					TxHash: payout.TxHash,
+					Amount: payout.Amount,
+					PaidAt: timestamppb.New(payout.PaidAt),
+				})
+			}
+		}
+
+		if len(minerPayouts.Payouts) > 0 {
+			payoutsMap[miner] = minerPayouts
+		}
+	}
+
+	return &poolPayoutsProto.PayoutsMap{
+		Payouts: payoutsMap,
+	}, nil
+}
+
+func (s *poolPayoutsServer) GetSoloBlocksFromList(ctx context.Context, req *poolPayoutsProto.GetSoloBlocksFromListRequest) (*poolPayoutsProto.MinedSoloBlocksMap, error) {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+
+	var minedAtFilter *filtersProto.DateTimeRangeFilter
+	if req.Filters != nil {
+		minedAtFilter = req.Filters.MinedAt
+	}
+
+	blocksMap := make(map[string]*poolPayoutsProto.MinedSoloBlocks)
+	for _, miner := range req.Miners {
+		minerBlocks := &poolPayoutsProto.MinedSoloBlocks{}
+		for _, block := range s.state.soloBlocks[miner] {
+			if inRange(block.MinedAt, minedAtFilter) {
+				minerBlocks.Blocks = append(minerBlocks.Blocks, &poolPayoutsProto.MinedSoloBlock{
+					Miner:     miner,
+					BlockHash: block.BlockHash,
+					Reward:    block.Reward,
+					TxHash:    block.TxHash,
+					MinedAt:   timestamppb.New(block.MinedAt),
+				})
+			}
+		}
+
+		if len(minerBlocks.Blocks) > 0 {
+			blocksMap[miner] = minerBlocks
+		}
+	}
+
+	return &poolPayoutsProto.MinedSoloBlocksMap{
+		Blocks: blocksMap,
+	}, nil
+}
+
+type poolServer struct {
+	poolProto.UnimplementedPoolServiceServer
+	blockchain string
+}
+
+func (s *poolServer) GetPoolInfo(ctx context.Context, req *emptypb.Empty) (*poolProto.PoolInfo, error) {
+	return &poolProto.PoolInfo{
+		Blockchain: s.blockchain,
+		Host:       "pool.e2e.test",
+		PayoutMode: poolProto.PayoutMode_PPLNS,
+		Solo:       true,
+	}, nil
+}
+
+func (s *poolServer) GetPoolStats(ctx context.Context, req *emptypb.Empty) (*poolProto.PoolStats, error) {
+	return &poolProto.PoolStats{}, nil
+}
+
+type FakePool struct {
+	listener *bufconn.Listener
+	server   *grpc.Server
+	state    *poolState
+}
+
+func (p *FakePool) AddValidWallet(wallet string) {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	p.state.validators[wallet] = true
+}
+
+func (p *FakePool) SetWorkers(wallet string, workers ...FakeWorker) {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	p.state.workers[wallet] = workers
+}
+
+func (p *FakePool) SetBalance(wallet string, balance uint64) {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	p.state.balances[wallet] = balance
+}
+
+func (p *FakePool) AddPayout(wallet string, payout FakePayout) {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	p.state.payouts[wallet] = append(p.state.payouts[wallet], payout)
+}
+
+func (p *FakePool) AddSoloBlock(wallet string, block FakeSoloBlock) {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	p.state.soloBlocks[wallet] = append(p.state.soloBlocks[wallet], block)
+}
+
+func (p *FakePool) DialOptions() []grpc.DialOption {
+	//	Pool api clients are connected to fake pool in memory instead of network
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return p.listener.DialContext(ctx)
+		}),
+	}
+}
+
+func (p *FakePool) Close() {
+	p.server.Stop()
+}
+
+func NewFakePool(blockchain string) *FakePool {
+	state := &poolState{
+		validators: make(map[string]bool),
+		workers:    make(map[string][]FakeWorker),
+		balances:   make(map[string]uint64),
+		payouts:    make(map[string][]FakePayout),
+		soloBlocks: make(map[string][]FakeSoloBlock),
+	}
+	pool := &FakePool{
+		listener: bufconn.Listen(BUFCONN_SIZE),
+		server:   grpc.NewServer(),
+		state:    state,
+	}
+
+	poolMinersProto.RegisterPoolMinersServiceServer(pool.server, &poolMinersServer{state: state})
+	poolPayoutsProto.RegisterPoolPayoutsServiceServer(pool.server, &poolPayoutsServer{state: state})
+	poolProto.RegisterPoolServiceServer(pool.server, &poolServer{blockchain: blockchain})
+	healthProto.RegisterHealthServer(pool.server, health.NewServer())
+
+	go pool.server.Serve(pool.listener)
+
+	return pool
+}
@@ -0,0 +1,366 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	botNotify "github.com/grandminingpool/telegram-bot/internal/notify"
+	memoryStorage "github.com/grandminingpool/telegram-bot/internal/storage/memory"
+	sqlTest "github.com/grandminingpool/telegram-bot/internal/storage/sql/sqltest"
+	storageTest "github.com/grandminingpool/telegram-bot/internal/storage/storagetest"
+	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
+)
+
+const LOCALES_PATH = "../../locales"
+
+var backends = []struct {
+	name  string
+	newDB storageTest.NewDB
+}{
+	{
+		name: "memory",
+		newDB: func(t *testing.T) storageTest.DB {
+			return memoryStorage.New()
+		},
+	},
+	{
+		name: "sqlite",
+		newDB: func(t *testing.T) storageTest.DB {
+			return sqlTest.NewSQLite(t)
+		},
+	},
+}
+
+func newHarness(t *testing.T, db storageTest.DB) *Harness {
+	t.Helper()
+
+	h, err := NewHarness(context.Background(), LOCALES_PATH, db)
+	if err != nil {
+		t.Fatalf("failed to create harness: %v", err)
+	}
+
+	t.Cleanup(h.Close)
+
+	return h
+}
+
+func runScenario(t *testing.T, scenario func(t *testing.T, h *Harness)) {
+	//	Scenario runs on each storage, so bot behaves the same with any of them
+	for _, backend := range backends {
+		backend := backend
+		t.Run(backend.name, func(t *testing.T) {
+			scenario(t, newHarness(t, backend.newDB(t)))
+		})
+	}
+}
+
+func lastMessage(t *testing.T, h *Harness, chatID int64) SentRequest {
+	t.Helper()
+
+	messages := h.Telegram.Messages(chatID)
+	if len(messages) == 0 {
+		t.Fatalf("no messages were sent to chat %d", chatID)
+	}
+
+	return messages[len(messages)-1]
+}
+
+func messageTexts(h *Harness, chatID int64) []string {
+	texts := []string{}
+	for _, message := range h.Telegram.Messages(chatID) {
+		texts = append(texts, message.Text())
+	}
+
+	return texts
+}
+
+func pressButton(t *testing.T, h *Harness, userID int64, button string) {
+	t.Helper()
+
+	buttons := lastMessage(t, h, userID).Buttons()
+	if !slices.Contains(buttons, button) {
+		t.Fatalf("button %q is not in last keyboard %q", button, buttons)
+	}
+
+	h.SendText(context.Background(), userID, button)
+}
+
+func addWallet(t *testing.T, h *Harness, userID int64, wallet string) {
+	t.Helper()
+
+	ctx := context.Background()
+	h.SendText(ctx, userID, "/start")
+	pressButton(t, h, userID, h.Localize("en", "AddWalletButton", nil))
+
+	if text := lastMessage(t, h, userID).Text(); text != h.Localize("en", "SelectBlockchain", nil) {
+		t.Fatalf("expected blockchain select message, got %q", text)
+	}
+
+	pressButton(t, h, userID, h.Blockchain.Name)
+	h.SendText(ctx, userID, wallet)
+
+	localizer := h.Languages.GetLocalizer("en")
+	expected := h.Localize("en", "WalletAdded", map[string]string{
+		"CheckWorkersInterval": formatUtils.NewFormatter(localizer).Minutes(h.Config.Notify.CheckIntervals.Workers),
+	})
+	if text := lastMessage(t, h, userID).Text(); text != expected {
+		t.Fatalf("expected wallet added message, got %q", text)
+	}
+}
+
+func TestAddWallet(t *testing.T) {
+	runScenario(t, testAddWallet)
+}
+
+func testAddWallet(t *testing.T, h *Harness) {
+	ctx := context.Background()
+	userID := int64(1001)
+	wallet := "bc1quser1001wallet"
+
+	h.Pool.AddValidWallet(wallet)
+	addWallet(t, h, userID, wallet)
+
+	//	Invalid wallet keeps user in add wallet action
+	h.SendText(ctx, userID, "/start")
+	pressButton(t, h, userID, h.Localize("en", "AddWalletButton", nil))
+	pressButton(t, h, userID, h.Blockchain.Name)
+	h.SendText(ctx, userID, "not-a-wallet")
+
+	if text := lastMessage(t, h, userID).Text(); text != h.Localize("en", "InvalidWallet", nil) {
+		t.Fatalf("expected invalid wallet message, got %q", text)
+	}
+
+	h.SendText(ctx, userID, wallet)
+
+	if text := lastMessage(t, h, userID).Text(); text != h.Localize("en", "WalletAlreadyAdded", nil) {
+		t.Fatalf("expected wallet already added message, got %q", text)
+	}
+}
+
+func TestWorkerGoesOffline(t *testing.T) {
+	runScenario(t, testWorkerGoesOffline)
+}
+
+func testWorkerGoesOffline(t *testing.T, h *Harness) {
+	ctx := context.Background()
+	connectedAt := time.Now().Add(-time.Hour)
+
+	//	More wallets than fit in one pool request and one notification group
+	userIDs := []int64{2001, 2002, 2003, 2004, 2005}
+	for _, userID := range userIDs {
+		wallet := fmt.Sprintf("bc1quser%dwallet", userID)
+		h.Pool.AddValidWallet(wallet)
+		addWallet(t, h, userID, wallet)
+		h.Pool.SetWorkers(wallet,
+			FakeWorker{Worker: "rig1", Region: "eu", ConnectedAt: connectedAt},
+			FakeWorker{Worker: "rig2", Region: "us", ConnectedAt: connectedAt},
+		)
+	}
+
+	if err := h.RunJob(ctx, botNotify.WORKERS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, userID := range userIDs {
+		wallet := fmt.Sprintf("bc1quser%dwallet", userID)
+		for _, worker := range []string{"rig1", "rig2"} {
+			expected := h.Localize("en", "WorkerActive", map[string]string{
+				"Worker": worker,
+				"Wallet": wallet,
+			})
+			if !slices.ContainsFunc(messageTexts(h, userID), func(text string) bool {
+				return strings.HasPrefix(text, expected)
+			}) {
+				t.Fatalf("user %d was not notified about active worker %s", userID, worker)
+			}
+		}
+	}
+
+	h.Telegram.Reset()
+	for _, userID := range userIDs {
+		h.Pool.SetWorkers(fmt.Sprintf("bc1quser%dwallet", userID), FakeWorker{Worker: "rig1", Region: "eu", ConnectedAt: connectedAt})
+	}
+
+	if err := h.RunJob(ctx, botNotify.WORKERS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, userID := range userIDs {
+		wallet := fmt.Sprintf("bc1quser%dwallet", userID)
+		expected := []string{h.Localize("en", "WorkerInactive", map[string]string{
+			"Worker": "rig2",
+			"Wallet": wallet,
+		})}
+		if texts := messageTexts(h, userID); !slices.Equal(texts, expected) {
+			t.Fatalf("user %d expected only rig2 offline message, got %q", userID, texts)
+		}
+	}
+
+	//	Pool omits miners without connected workers, so last worker goes offline too
+	h.Telegram.Reset()
+	for _, userID := range userIDs {
+		h.Pool.SetWorkers(fmt.Sprintf("bc1quser%dwallet", userID))
+	}
+
+	if err := h.RunJob(ctx, botNotify.WORKERS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, userID := range userIDs {
+		wallet := fmt.Sprintf("bc1quser%dwallet", userID)
+		expected := []string{h.Localize("en", "WorkerInactive", map[string]string{
+			"Worker": "rig1",
+			"Wallet": wallet,
+		})}
+		if texts := messageTexts(h, userID); !slices.Equal(texts, expected) {
+			t.Fatalf("user %d expected only rig1 offline message, got %q", userID, texts)
+		}
+	}
+
+	//	Unchanged workers are not notified again
+	h.Telegram.Reset()
+	if err := h.RunJob(ctx, botNotify.WORKERS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := h.Telegram.Requests(); len(requests) != 0 {
+		t.Fatalf("expected no notifications without changes, got %d requests", len(requests))
+	}
+}
+
+func TestPayoutArrives(t *testing.T) {
+	runScenario(t, testPayoutArrives)
+}
+
+func testPayoutArrives(t *testing.T, h *Harness) {
+	ctx := context.Background()
+
+	userIDs := []int64{3001, 3002, 3003}
+	for _, userID := range userIDs {
+		wallet := fmt.Sprintf("bc1quser%dwallet", userID)
+		h.Pool.AddValidWallet(wallet)
+		addWallet(t, h, userID, wallet)
+
+		//	Payout made before first check is not notified
+		h.Pool.AddPayout(wallet, FakePayout{
+			TxHash: fmt.Sprintf("old-tx-%d", userID),
+			Amount: 10000000,
+			PaidAt: time.Now().Add(-time.Hour),
+		})
+	}
+
+	//	First check only remembers time from which payouts are notified
+	if err := h.RunJob(ctx, botNotify.PAYOUTS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	h.Telegram.Reset()
+	for _, userID := range userIDs {
+		wallet := fmt.Sprintf("bc1quser%dwallet", userID)
+		h.Pool.AddPayout(wallet, FakePayout{
+			TxHash: fmt.Sprintf("tx-%d", userID),
+			Amount: 150000000,
+			PaidAt: time.Now(),
+		})
+		h.Pool.AddSoloBlock(wallet, FakeSoloBlock{
+			BlockHash: fmt.Sprintf("block-%d", userID),
+			TxHash:    fmt.Sprintf("reward-tx-%d", userID),
+			Reward:    312500000,
+			MinedAt:   time.Now(),
+		})
+	}
+
+	if err := h.RunJob(ctx, botNotify.PAYOUTS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	payoutText := h.Localize("en", "NewPayoutReceived", nil)
+	blockText := h.Localize("en", "NewBlockFound", nil)
+	for _, userID := range userIDs {
+		texts := messageTexts(h, userID)
+		if len(texts) != 2 {
+			t.Fatalf("user %d expected payout and block messages, got %q", userID, texts)
+		}
+
+		payoutFound := slices.ContainsFunc(texts, func(text string) bool {
+			return strings.HasPrefix(text, payoutText) && strings.Contains(text, fmt.Sprintf("tx-%d", userID))
+		})
+		blockFound := slices.ContainsFunc(texts, func(text string) bool {
+			return strings.HasPrefix(text, blockText) && strings.Contains(text, fmt.Sprintf("block-%d", userID))
+		})
+		if !payoutFound || !blockFound {
+			t.Fatalf("user %d was not notified about payout and block, got %q", userID, texts)
+		}
+	}
+
+	//	Next check starts from previous one, so same payouts are not sent twice
+	h.Telegram.Reset()
+	if err := h.RunJob(ctx, botNotify.PAYOUTS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := h.Telegram.Requests(); len(requests) != 0 {
+		t.Fatalf("expected no notifications without new payouts, got %d requests", len(requests))
+	}
+}
+
+func TestPausedWalletResumes(t *testing.T) {
+	runScenario(t, testPausedWalletResumes)
+}
+
+func testPausedWalletResumes(t *testing.T, h *Harness) {
+	ctx := context.Background()
+	userID := int64(4001)
+	wallet := "bc1quser4001wallet"
+	connectedAt := time.Now().Add(-time.Hour)
+
+	h.Pool.AddValidWallet(wallet)
+	addWallet(t, h, userID, wallet)
+	h.Pool.SetWorkers(wallet,
+		FakeWorker{Worker: "rig1", Region: "eu", ConnectedAt: connectedAt},
+		FakeWorker{Worker: "rig2", Region: "us", ConnectedAt: connectedAt},
+	)
+
+	if err := h.RunJob(ctx, botNotify.WORKERS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	store := h.DB.Storage()
+	wallets, err := store.Wallets.FindAll(ctx, userID)
+	if err != nil || len(wallets) != 1 {
+		t.Fatalf("expected one user wallet, got %+v and error %v", wallets, err)
+	}
+
+	if err := store.Wallets.SetPaused(ctx, userID, wallets[0].ID, true); err != nil {
+		t.Fatalf("failed to pause wallet: %v", err)
+	}
+
+	//	Workers change while wallet is paused
+	h.Pool.SetWorkers(wallet, FakeWorker{Worker: "rig3", Region: "eu", ConnectedAt: connectedAt})
+	if err := h.RunJob(ctx, botNotify.WORKERS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Wallets.SetPaused(ctx, userID, wallets[0].ID, false); err != nil {
+		t.Fatalf("failed to unpause wallet: %v", err)
+	}
+
+	h.Telegram.Reset()
+	if err := h.RunJob(ctx, botNotify.WORKERS_JOB); err != nil {
+		t.Fatal(err)
+	}
+
+	//	Workers gone during pause are not reported offline after it
+	texts := messageTexts(h, userID)
+	expected := h.Localize("en", "WorkerActive", map[string]string{
+		"Worker": "rig3",
+		"Wallet": wallet,
+	})
+	if len(texts) != 1 || !strings.HasPrefix(texts[0], expected) {
+		t.Fatalf("expected only rig3 active message after unpause, got %q", texts)
+	}
+}
@@ -0,0 +1,158 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	FAKE_BOT_TOKEN    = "123456:e2e-test-token"
+	FAKE_BOT_ID       = 123456
+	FAKE_BOT_USERNAME = "e2e_pool_bot"
+)
+
+type SentRequest struct {
+	Method string
+	Fields map[string]string
+}
+
+func (r SentRequest) ChatID() int64 {
+	chatID, _ := strconv.ParseInt(r.Fields["chat_id"], 10, 64)
+
+	return chatID
+}
+
+func (r SentRequest) Text() string {
+	return r.Fields["text"]
+}
+
+func (r SentRequest) Buttons() []string {
+	var markup models.ReplyKeyboardMarkup
+	if err := json.Unmarshal([]byte(r.Fields["reply_markup"]), &markup); err != nil {
+		return nil
+	}
+
+	buttons := []string{}
+	for _, row := range markup.Keyboard {
+		for _, button := range row {
+			buttons = append(buttons, button.Text)
+		}
+	}
+
+	return buttons
+}
+
+type FakeTelegram struct {
+	server        *httptest.Server
+	mu            sync.Mutex
+	requests      []SentRequest
+	nextMessageID int
+}
+
+func (t *FakeTelegram) URL() string {
+	return t.server.URL
+}
+
+func (t *FakeTelegram) Requests() []SentRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	requests := make([]SentRequest, len(t.requests))
+	copy(requests, t.requests)
+
+	return requests
+}
+
+func (t *FakeTelegram) Messages(chatID int64) []SentRequest {
+	messages := []SentRequest{}
+	for _, request := range t.Requests() {
+		if request.Method == "sendMessage" && request.ChatID() == chatID {
+			messages = append(messages, request)
+		}
+	}
+
+	return messages
+}
+
+func (t *FakeTelegram) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests = nil
+}
+
+func (t *FakeTelegram) Close() {
+	t.server.Close()
+}
+
+func (t *FakeTelegram) result(request SentRequest) any {
+	switch request.Method {
+	case "getMe":
+		return models.User{
+			ID:        FAKE_BOT_ID,
+			IsBot:     true,
+			FirstName: "Pool bot",
+			Username:  FAKE_BOT_USERNAME,
+		}
+	case "sendMessage", "editMessageText":
+		t.nextMessageID++
+
+		return models.Message{
+			ID:   t.nextMessageID,
+			Date: int(time.Now().Unix()),
+			Chat: models.Chat{
+				ID: request.ChatID(),
+			},
+			Text: request.Text(),
+		}
+	default:
+		return true
+	}
+}
+
+func (t *FakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	//	Bot API methods are requested as /bot<token>/<method>
+	path, ok := strings.CutPrefix(r.URL.Path, "/bot"+FAKE_BOT_TOKEN+"/")
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	request := SentRequest{
+		Method: path,
+		Fields: make(map[string]string),
+	}
+	if err := r.ParseMultipartForm(1 << 20); err == nil {
+		for key, values := range r.MultipartForm.Value {
+			if len(values) > 0 {
+				request.Fields[key] = values[0]
+			}
+		}
+	}
+
+	t.mu.Lock()
+	t.requests = append(t.requests, request)
+	result := t.result(request)
+	t.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"ok":     true,
+		"result": result,
+	})
+}
+
+func NewFakeTelegram() *FakeTelegram {
+	fake := &FakeTelegram{}
+	fake.server = httptest.NewServer(fake)
+
+	return fake
+}
@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/grandminingpool/telegram-bot/internal/blockchains"
+	botNotify "github.com/grandminingpool/telegram-bot/internal/notify"
+	"github.com/jmoiron/sqlx"
+)
+
+// Notify job is considered stale when it hasn't completed successfully
+// during this count of its intervals
+const NOTIFY_JOB_STALE_INTERVALS = 2
+
+type DatabaseDetails struct {
+	OpenConnections int    `json:"openConnections"`
+	InUse           int    `json:"inUse"`
+	Idle            int    `json:"idle"`
+	Latency         string `json:"latency"`
+}
+
+type TelegramDetails struct {
+	Username string `json:"username,omitempty"`
+	Latency  string `json:"latency"`
+}
+
+type PoolAPIDetails struct {
+	Pools       []blockchains.PoolState `json:"pools"`
+	Unavailable []string                `json:"unavailable"`
+}
+
+type NotifyDetails struct {
+	StartedAt *time.Time           `json:"startedAt,omitempty"`
+	Jobs      []botNotify.JobState `json:"jobs"`
+}
+
+func DatabaseCheck(driver string, conn *sqlx.DB) Check {
+	return func(ctx context.Context) ComponentHealth {
+		startTime := time.Now()
+		err := conn.PingContext(ctx)
+		stats := conn.Stats()
+		details := &DatabaseDetails{
+			OpenConnections: stats.OpenConnections,
+			InUse:           stats.InUse,
+			Idle:            stats.Idle,
+			Latency:         time.Since(startTime).String(),
+		}
+
+		if err != nil {
+			return Down(fmt.Errorf("failed to ping %s: %w", driver, err), details)
+		}
+
+		return Up(details)
+	}
+}
+
+func PoolAPICheck(blockchainsService *blockchains.Service) Check {
+	return func(ctx context.Context) ComponentHealth {
+		states := blockchainsService.GetPoolStates()
+		details := &PoolAPIDetails{
+			Pools:       states,
+			Unavailable: []string{},
+		}
+		for _, state := range states {
+			if !state.Available {
+				details.Unavailable = append(details.Unavailable, state.Coin)
+			}
+		}
+
+		//	Bot still serves coins with reachable pools, so readiness is lost only without any of them
+		if len(states) > 0 && len(details.Unavailable) == len(states) {
+			return Down(fmt.Errorf("pool api unavailable for all coins: %v", details.Unavailable), details)
+		}
+
+		return Up(details)
+	}
+}
+
+func TelegramCheck(b *bot.Bot) Check {
+	return func(ctx context.Context) ComponentHealth {
+		startTime := time.Now()
+		me, err := b.GetMe(ctx)
+		details := &TelegramDetails{
+			Latency: time.Since(startTime).String(),
+		}
+
+		if err != nil {
+			return Down(fmt.Errorf("failed to get bot info: %w", err), details)
+		}
+
+		details.Username = me.Username
+
+		return Up(details)
+	}
+}
+
+func NotifyCheck(notifyService *botNotify.Service) Check {
+	return func(ctx context.Context) ComponentHealth {
+		startedAt := notifyService.StartedAt()
+		details := &NotifyDetails{
+			Jobs: notifyService.JobStates(),
+		}
+
+		if startedAt.IsZero() {
+			return Down(errors.New("notify service is not started"), details)
+		}
+
+		details.StartedAt = &startedAt
+
+		staleJobs := []string{}
+		for _, job := range details.Jobs {
+			lastSuccessAt := startedAt
+			if job.LastSuccessAt != nil {
+				lastSuccessAt = *job.LastSuccessAt
+			}
+
+			if time.Since(lastSuccessAt) > job.IntervalDuration()*NOTIFY_JOB_STALE_INTERVALS {
+				staleJobs = append(staleJobs, job.Name)
+			}
+		}
+
+		if len(staleJobs) > 0 {
+			return Down(fmt.Errorf("notify jobs haven't completed within expected interval: %v", staleJobs), details)
+		}
+
+		return Up(details)
+	}
+}
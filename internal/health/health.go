@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	STATUS_UP   = "up"
+	STATUS_DOWN = "down"
+)
+
+type ComponentHealth struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+type Check func(ctx context.Context) ComponentHealth
+
+type Response struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentHealth `json:"components,omitempty"`
+}
+
+type Checker struct {
+	mu      sync.RWMutex
+	checks  map[string]Check
+	timeout time.Duration
+}
+
+func Up(details any) ComponentHealth {
+	return ComponentHealth{
+		Status:  STATUS_UP,
+		Details: details,
+	}
+}
+
+func Down(err error, details any) ComponentHealth {
+	return ComponentHealth{
+		Status:  STATUS_DOWN,
+		Error:   err.Error(),
+		Details: details,
+	}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[name] = check
+}
+
+func (c *Checker) Check(ctx context.Context) *Response {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	response := &Response{
+		Status:     STATUS_UP,
+		Components: make(map[string]ComponentHealth, len(c.checks)),
+	}
+	resultsMu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			componentHealth := check(checkCtx)
+
+			resultsMu.Lock()
+			response.Components[name] = componentHealth
+			if componentHealth.Status != STATUS_UP {
+				response.Status = STATUS_DOWN
+			}
+			resultsMu.Unlock()
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	return response
+}
+
+func writeResponse(w http.ResponseWriter, response *Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if response.Status == STATUS_UP {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		zap.L().Warn("failed to write health response", zap.Error(err))
+	}
+}
+
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, &Response{Status: STATUS_UP})
+	})
+}
+
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, c.Check(r.Context()))
+	})
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		checks:  make(map[string]Check),
+		timeout: timeout,
+	}
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/common/types"
+)
+
+const DB_QUERY_START_CTX_KEY types.CtxKey = "dbQueryStart"
+
+type DBHooks struct{}
+
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+func (h *DBHooks) Before(ctx context.Context, query string) context.Context {
+	return context.WithValue(ctx, DB_QUERY_START_CTX_KEY, time.Now())
+}
+
+func (h *DBHooks) After(ctx context.Context, query string, err error) {
+	start, ok := ctx.Value(DB_QUERY_START_CTX_KEY).(time.Time)
+	if ok {
+		DBQueryDuration.WithLabelValues(queryOperation(query), Status(err)).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func UnaryClientInterceptor(coin string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		PoolAPIRequestDuration.WithLabelValues(coin, method).Observe(time.Since(start).Seconds())
+
+		if err != nil {
+			PoolAPIRequestErrors.WithLabelValues(coin, method, status.Code(err).String()).Inc()
+		}
+
+		return err
+	}
+}
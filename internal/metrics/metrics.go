@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const NAMESPACE = "grandpool_bot"
+
+var (
+	UpdatesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: NAMESPACE,
+		Name:      "updates_total",
+		Help:      "Count of incoming telegram updates by handler and update type",
+	}, []string{"handler", "update_type"})
+	HandlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: NAMESPACE,
+		Name:      "handler_duration_seconds",
+		Help:      "Telegram update handler latency",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handler"})
+	PoolAPIRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: NAMESPACE,
+		Name:      "pool_api_request_duration_seconds",
+		Help:      "Pool API RPC latency by coin and method",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"coin", "method"})
+	PoolAPIRequestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: NAMESPACE,
+		Name:      "pool_api_request_errors_total",
+		Help:      "Count of failed pool API RPCs by coin, method and status code",
+	}, []string{"coin", "method", "code"})
+	NotifyJobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: NAMESPACE,
+		Name:      "notify_job_duration_seconds",
+		Help:      "Notify job run duration",
+		Buckets:   []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"job"})
+	NotifyJobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: NAMESPACE,
+		Name:      "notify_job_runs_total",
+		Help:      "Count of notify job runs by outcome",
+	}, []string{"job", "outcome"})
+	MessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: NAMESPACE,
+		Name:      "messages_total",
+		Help:      "Count of messages sent by bot by message type and status",
+	}, []string{"type", "status"})
+	MessageErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: NAMESPACE,
+		Name:      "message_errors_total",
+		Help:      "Count of failed message sends by message type and error kind",
+	}, []string{"type", "kind"})
+	TrackedWallets = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NAMESPACE,
+		Name:      "tracked_wallets",
+		Help:      "Count of tracked wallets per coin",
+	}, []string{"coin"})
+	TrackedWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NAMESPACE,
+		Name:      "tracked_workers",
+		Help:      "Count of tracked active workers per coin",
+	}, []string{"coin"})
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: NAMESPACE,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by operation",
+		Buckets:   []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"operation", "status"})
+)
+
+const (
+	STATUS_OK    = "ok"
+	STATUS_ERROR = "error"
+)
+
+func Status(err error) string {
+	if err != nil {
+		return STATUS_ERROR
+	}
+
+	return STATUS_OK
+}
@@ -0,0 +1,349 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	UP_DIRECTION   = "up"
+	DOWN_DIRECTION = "down"
+)
+
+var (
+	ErrDirtySchema    = errors.New("database schema is dirty")
+	ErrOutdatedSchema = errors.New("database schema is outdated")
+	ErrUnknownSchema  = errors.New("database schema is newer than known migrations")
+	ErrNoMigration    = errors.New("migration not found")
+)
+
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version uint64
+	Dirty   bool
+	Latest  uint64
+	Applied []*Migration
+	Pending []*Migration
+}
+
+type Migrator struct {
+	conn       *sqlx.DB
+	migrations []*Migration
+}
+
+func LoadMigrations(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse migration (file: %s) version: %w", entry.Name(), err)
+		}
+
+		if version == 0 {
+			return nil, fmt.Errorf("migration (file: %s) version must be greater than zero", entry.Name())
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration (file: %s): %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{
+				Version: version,
+				Name:    match[2],
+			}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration (version: %d) has different names: %s, %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == UP_DIRECTION {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration (version: %d) has no up file", migration.Version)
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func (m *Migrator) Latest() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	//	Table layout matches golang-migrate, so its CLI can still be used against the same database
+	if _, err := m.conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		dirty BOOLEAN NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema migrations table: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) Version(ctx context.Context) (uint64, bool, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, false, err
+	}
+
+	var row struct {
+		Version uint64 `db:"version"`
+		Dirty   bool   `db:"dirty"`
+	}
+	err := m.conn.GetContext(ctx, &row, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	return row.Version, row.Dirty, nil
+}
+
+func setVersion(ctx context.Context, tx *sqlx.Tx, version uint64) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return fmt.Errorf("failed to clear schema version: %w", err)
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)"), version); err != nil {
+		return fmt.Errorf("failed to set schema version (version: %d): %w", version, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) apply(ctx context.Context, query string, version uint64) error {
+	tx, err := m.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	if err := setVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) index(version uint64) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (m *Migrator) current(ctx context.Context) (int, error) {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if dirty {
+		return 0, fmt.Errorf("%w (version: %d), fix it manually and run force", ErrDirtySchema, version)
+	}
+
+	if version == 0 {
+		return -1, nil
+	}
+
+	i := m.index(version)
+	if i == -1 {
+		return 0, fmt.Errorf("%w (version: %d)", ErrUnknownSchema, version)
+	}
+
+	return i, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	current, err := m.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []*Migration{}
+	for _, migration := range m.migrations[current+1:] {
+		if err := m.apply(ctx, migration.Up, migration.Version); err != nil {
+			return applied, fmt.Errorf("failed to apply migration (version: %d, name: %s): %w", migration.Version, migration.Name, err)
+		}
+
+		zap.L().Info("applied migration", zap.Uint64("version", migration.Version), zap.String("name", migration.Name))
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	current, err := m.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := []*Migration{}
+	for i := current; i >= 0 && len(reverted) < steps; i-- {
+		migration := m.migrations[i]
+		if migration.Down == "" {
+			return reverted, fmt.Errorf("migration (version: %d, name: %s) has no down file", migration.Version, migration.Name)
+		}
+
+		var previous uint64
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+
+		if err := m.apply(ctx, migration.Down, previous); err != nil {
+			return reverted, fmt.Errorf("failed to revert migration (version: %d, name: %s): %w", migration.Version, migration.Name, err)
+		}
+
+		zap.L().Info("reverted migration", zap.Uint64("version", migration.Version), zap.String("name", migration.Name))
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version != 0 && m.index(version) == -1 {
+		return fmt.Errorf("%w (version: %d)", ErrNoMigration, version)
+	}
+
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	tx, err := m.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	if err := setVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Version: version,
+		Dirty:   dirty,
+		Latest:  m.Latest(),
+		Applied: []*Migration{},
+		Pending: []*Migration{},
+	}
+	for _, migration := range m.migrations {
+		if migration.Version <= version {
+			status.Applied = append(status.Applied, migration)
+		} else {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+
+	return status, nil
+}
+
+func (m *Migrator) CheckVersion(ctx context.Context) error {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	latest := m.Latest()
+	if dirty {
+		return fmt.Errorf("%w (version: %d)", ErrDirtySchema, version)
+	} else if version < latest {
+		return fmt.Errorf("%w (version: %d, latest: %d), run migrate up", ErrOutdatedSchema, version, latest)
+	} else if version > latest {
+		return fmt.Errorf("%w (version: %d, latest: %d)", ErrUnknownSchema, version, latest)
+	}
+
+	return nil
+}
+
+func NewMigrator(conn *sqlx.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		conn:       conn,
+		migrations: migrations,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	postgresConfig "github.com/grandminingpool/telegram-bot/configs/postgres"
+	postgresTest "github.com/grandminingpool/telegram-bot/internal/providers/postgres/postgrestest"
+	sqliteProvider "github.com/grandminingpool/telegram-bot/internal/providers/sqlite"
+	"github.com/grandminingpool/telegram-bot/migrations"
+	sqliteMigrations "github.com/grandminingpool/telegram-bot/migrations/sqlite"
+	"github.com/jmoiron/sqlx"
+)
+
+func newSQLiteMigrator(t *testing.T) (*Migrator, *sqlx.DB) {
+	t.Helper()
+
+	conn, err := sqliteProvider.NewConnection(context.Background(), &postgresConfig.Config{
+		Driver: postgresConfig.SQLITE_DRIVER,
+		Path:   filepath.Join(t.TempDir(), "bot.db"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create sqlite connection: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	migrator, err := NewMigrator(conn, sqliteMigrations.FS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	return migrator, conn
+}
+
+func newPostgresMigrator(t *testing.T) (*Migrator, *sqlx.DB) {
+	t.Helper()
+
+	conn := postgresTest.Connect(t)
+	migrator, err := NewMigrator(conn, migrations.FS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	return migrator, conn
+}
+
+func tableExists(t *testing.T, conn *sqlx.DB, table string) bool {
+	t.Helper()
+
+	query := "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	if conn.DriverName() == "postgres" {
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?"
+	}
+
+	var count int
+	if err := conn.Get(&count, conn.Rebind(query), table); err != nil {
+		t.Fatalf("failed to check table (name: %s): %v", table, err)
+	}
+
+	return count > 0
+}
+
+func checkStatus(t *testing.T, m *Migrator, version uint64, applied, pending int) {
+	t.Helper()
+
+	status, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+
+	if status.Version != version || status.Dirty || status.Latest != m.Latest() {
+		t.Fatalf("expected clean version %d of %d, got %+v", version, m.Latest(), status)
+	}
+
+	if len(status.Applied) != applied || len(status.Pending) != pending {
+		t.Fatalf("expected %d applied and %d pending migrations, got %d and %d", applied, pending, len(status.Applied), len(status.Pending))
+	}
+}
+
+func testUpDown(t *testing.T, m *Migrator, conn *sqlx.DB) {
+	ctx := context.Background()
+	total := len(m.migrations)
+	if total == 0 {
+		t.Fatal("no migrations loaded")
+	}
+
+	checkStatus(t, m, 0, 0, total)
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("failed to run migrations up: %v", err)
+	}
+
+	if len(applied) != total {
+		t.Fatalf("expected %d applied migrations, got %d", total, len(applied))
+	}
+
+	checkStatus(t, m, m.Latest(), total, 0)
+
+	if !tableExists(t, conn, "users") {
+		t.Fatal("users table is not created by up migrations")
+	}
+
+	if err := m.CheckVersion(ctx); err != nil {
+		t.Fatalf("expected latest schema to pass version check, got %v", err)
+	}
+
+	//	Up on latest version has nothing to apply
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("expected no migrations to apply, got %d and error %v", len(applied), err)
+	}
+
+	reverted, err := m.Down(ctx, total)
+	if err != nil {
+		t.Fatalf("failed to run migrations down: %v", err)
+	}
+
+	if len(reverted) != total {
+		t.Fatalf("expected %d reverted migrations, got %d", total, len(reverted))
+	}
+
+	checkStatus(t, m, 0, 0, total)
+
+	if tableExists(t, conn, "users") {
+		t.Fatal("users table is not dropped by down migrations")
+	}
+
+	if err := m.CheckVersion(ctx); !errors.Is(err, ErrOutdatedSchema) {
+		t.Fatalf("expected outdated schema error after down, got %v", err)
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	m, conn := newSQLiteMigrator(t)
+	testUpDown(t, m, conn)
+}
+
+func TestPostgresUpDown(t *testing.T) {
+	m, conn := newPostgresMigrator(t)
+	testUpDown(t, m, conn)
+}
+
+func TestCheckVersion(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("empty schema is outdated", func(t *testing.T) {
+		m, _ := newSQLiteMigrator(t)
+		if err := m.CheckVersion(ctx); !errors.Is(err, ErrOutdatedSchema) {
+			t.Fatalf("expected outdated schema error, got %v", err)
+		}
+	})
+
+	t.Run("dirty schema", func(t *testing.T) {
+		m, conn := newSQLiteMigrator(t)
+		if _, err := m.Up(ctx); err != nil {
+			t.Fatalf("failed to run migrations up: %v", err)
+		}
+
+		//	Dirty flag is left by failed run of golang-migrate, which shares this table
+		if _, err := conn.Exec("UPDATE schema_migrations SET dirty = true"); err != nil {
+			t.Fatalf("failed to mark schema dirty: %v", err)
+		}
+
+		if err := m.CheckVersion(ctx); !errors.Is(err, ErrDirtySchema) {
+			t.Fatalf("expected dirty schema error, got %v", err)
+		}
+
+		if _, err := m.Up(ctx); !errors.Is(err, ErrDirtySchema) {
+			t.Fatalf("expected up to refuse dirty schema, got %v", err)
+		}
+
+		if err := m.Force(ctx, m.Latest()); err != nil {
+			t.Fatalf("failed to force version: %v", err)
+		}
+
+		if err := m.CheckVersion(ctx); err != nil {
+			t.Fatalf("expected forced schema to pass version check, got %v", err)
+		}
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		m, conn := newSQLiteMigrator(t)
+		if err := m.Force(ctx, m.Latest()+1); !errors.Is(err, ErrNoMigration) {
+			t.Fatalf("expected force to reject unknown version, got %v", err)
+		}
+
+		if err := m.ensureTable(ctx); err != nil {
+			t.Fatalf("failed to create schema migrations table: %v", err)
+		}
+
+		if _, err := conn.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", m.Latest()+1); err != nil {
+			t.Fatalf("failed to set schema version: %v", err)
+		}
+
+		if err := m.CheckVersion(ctx); !errors.Is(err, ErrUnknownSchema) {
+			t.Fatalf("expected unknown schema error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package botNotify
+
+func chunk[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		size = len(items)
+	}
+
+	groups := [][]T{}
+	for start := 0; start < len(items); start += size {
+		groups = append(groups, items[start:min(start+size, len(items))])
+	}
+
+	return groups
+}
+
+func spread[T any](items []T, count int) [][]T {
+	if count <= 0 {
+		count = 1
+	}
+
+	//	Items are split to at most count groups of nearly equal size
+	return chunk(items, (len(items)+count-1)/count)
+}
@@ -0,0 +1,57 @@
+package botNotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
+	telegramUtils "github.com/grandminingpool/telegram-bot/internal/utils/telegram"
+	"go.uber.org/zap"
+)
+
+const (
+	WORKER_ACTIVE_MESSAGE   = "worker_active"
+	WORKER_INACTIVE_MESSAGE = "worker_inactive"
+	PAYOUT_MESSAGE          = "payout"
+	SOLO_BLOCK_MESSAGE      = "solo_block"
+)
+
+const MAX_SEND_ATTEMPTS = 3
+
+func sendMessage(ctx context.Context, b *bot.Bot, msgType string, params *bot.SendMessageParams) error {
+	var (
+		err  error
+		kind telegramUtils.SendErrorKind
+	)
+
+	for attempt := 1; ; attempt++ {
+		if _, err = b.SendMessage(ctx, params); err == nil {
+			break
+		}
+
+		kind = telegramUtils.ClassifySendError(err)
+		if !kind.Retryable() || attempt == MAX_SEND_ATTEMPTS {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(telegramUtils.RetryDelay(err, attempt)):
+		}
+	}
+
+	metrics.MessagesTotal.WithLabelValues(msgType, metrics.Status(err)).Inc()
+	if err != nil {
+		metrics.MessageErrors.WithLabelValues(msgType, string(kind)).Inc()
+		zap.L().Warn("failed to send notification message",
+			zap.String("type", msgType),
+			zap.Any("chat_id", params.ChatID),
+			zap.String("error_kind", string(kind)),
+			zap.Error(err),
+		)
+	}
+
+	return err
+}
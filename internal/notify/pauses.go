@@ -0,0 +1,106 @@
+package botNotify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+const GLOBAL_PAUSE_SCOPE = "*"
+
+type Pauses struct {
+	notify storage.NotifyRepository
+	mu     sync.RWMutex
+	scopes map[string]storage.NotifyPauseDB
+}
+
+func pauseScope(coin string) string {
+	if coin == "" {
+		return GLOBAL_PAUSE_SCOPE
+	}
+
+	return coin
+}
+
+func (p *Pauses) Load(ctx context.Context) error {
+	pauses, err := p.notify.FindPauses(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clear(p.scopes)
+	for _, pause := range pauses {
+		p.scopes[pause.Scope] = pause
+	}
+
+	return nil
+}
+
+func (p *Pauses) Pause(ctx context.Context, coin string, pausedBy int64) error {
+	pause := storage.NotifyPauseDB{
+		Scope:    pauseScope(coin),
+		PausedBy: pausedBy,
+		PausedAt: time.Now(),
+	}
+
+	if err := p.notify.AddPause(ctx, &pause); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.scopes[pause.Scope]; !ok {
+		p.scopes[pause.Scope] = pause
+	}
+
+	return nil
+}
+
+func (p *Pauses) Resume(ctx context.Context, coin string) error {
+	scope := pauseScope(coin)
+	if err := p.notify.DeletePause(ctx, scope); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.scopes, scope)
+
+	return nil
+}
+
+func (p *Pauses) IsPaused(coin string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, globalPaused := p.scopes[GLOBAL_PAUSE_SCOPE]
+	_, coinPaused := p.scopes[coin]
+
+	return globalPaused || coinPaused
+}
+
+func (p *Pauses) List() []storage.NotifyPauseDB {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	pauses := make([]storage.NotifyPauseDB, 0, len(p.scopes))
+	for _, pause := range p.scopes {
+		pauses = append(pauses, pause)
+	}
+
+	return pauses
+}
+
+func NewPauses(notify storage.NotifyRepository) *Pauses {
+	return &Pauses{
+		notify: notify,
+		scopes: make(map[string]storage.NotifyPauseDB),
+	}
+}
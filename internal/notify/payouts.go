@@ -3,7 +3,7 @@ package botNotify
 import (
 	"bytes"
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -14,8 +14,11 @@ import (
 	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
 	"github.com/grandminingpool/telegram-bot/internal/blockchains"
 	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/grandminingpool/telegram-bot/internal/tracing"
 	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
-	"github.com/jmoiron/sqlx"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -63,11 +66,17 @@ type PoolSoloPayouts struct {
 	err      error
 }
 
-type UserWallet struct {
-	userInfo *UserInfo
-	id       int64
-	payouts  bool
-	blocks   bool
+type PayoutsSubscriber struct {
+	WalletSubscriber
+	payouts bool
+	blocks  bool
+}
+
+type SubscribedWallet struct {
+	id          int64
+	subscribers []PayoutsSubscriber
+	payouts     bool
+	blocks      bool
 }
 
 type PoolPayoutsRequests struct {
@@ -77,144 +86,107 @@ type PoolPayoutsRequests struct {
 }
 
 type Payouts struct {
-	pgConn             *sqlx.DB
+	notify             storage.NotifyRepository
+	chatTargets        storage.ChatTargetRepository
+	pauses             *Pauses
+	privacyService     *privacy.Service
 	blockchainsService *blockchains.Service
 	languages          *languages.Languages
 	b                  *bot.Bot
 	config             *botConfig.NotifyConfig
 }
 
-func (p *Payouts) getLastExecutionTime(ctx context.Context) (*time.Time, error) {
-	var lastExecutionTime time.Time
-	err := p.pgConn.GetContext(ctx, &lastExecutionTime, `SELECT 
-		executed_at FROM payouts_notifications 
-	ORDER BY executed_at DESC LIMIT 1`)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	} else if err != nil {
-		return nil, fmt.Errorf("failed to query last payouts notification executed time: %w", err)
-	}
-
-	return &lastExecutionTime, nil
-}
-
-func (p *Payouts) addNotification(ctx context.Context) error {
-	if _, err := p.pgConn.ExecContext(ctx, "INSERT INTO payouts_notifications (executed_at) VALUES (NOW())"); err != nil {
-		return fmt.Errorf("failed to create new payouts notification: %w", err)
-	}
-
-	return nil
-}
-
-func (p *Payouts) getWalletsMap(ctx context.Context) (map[string]map[string]*UserWallet, error) {
-	walletsMap := make(map[string]map[string]*UserWallet)
-	rows, err := p.pgConn.QueryContext(ctx, `SELECT
-		user_wallets.user_id,
-		users.chat_id,
-		users.lang,
-		users.payouts_notify,
-		users.blocks_notify,
-		user_wallets.blockchain_coin,
-		user_wallets.id,
-		user_wallets.wallet
-	FROM wallet_workers
-	LEFT JOIN users ON users.id = wallet_workers.user_id
-	WHERE users.blocks_notify = true OR users.payouts_notify = true`)
+func (p *Payouts) getWalletsMap(ctx context.Context) (map[string]map[string]*SubscribedWallet, error) {
+	subscriptions, err := p.notify.FindSubscriptions(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query wallets for payouts notifications: %w", err)
+		return nil, err
 	}
 
-	for rows.Next() {
-		var (
-			userID, chatID, walletID    int64
-			userLang, coin, wallet      string
-			payoutsNotify, blocksNotify bool
-		)
-
-		if err := rows.Scan(
-			&userID,
-			&chatID,
-			&userLang,
-			&payoutsNotify,
-			&blocksNotify,
-			&coin,
-			&walletID,
-			&wallet,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan payouts wallets columns: %w", err)
+	walletsMap := make(map[string]map[string]*SubscribedWallet)
+	for _, subscription := range subscriptions {
+		if !subscription.Notify || !(subscription.PayoutsNotify || subscription.BlocksNotify) {
+			continue
 		}
 
-		_, ok := walletsMap[coin]
+		_, ok := walletsMap[subscription.Coin]
 		if !ok {
-			walletsMap[coin] = make(map[string]*UserWallet)
+			walletsMap[subscription.Coin] = make(map[string]*SubscribedWallet)
 		}
 
-		walletsMap[coin][wallet] = &UserWallet{
-			userInfo: &UserInfo{
-				userID: userID,
-				chatID: chatID,
-				lang:   userLang,
-			},
-			id:      walletID,
-			payouts: payoutsNotify,
-			blocks:  blocksNotify,
+		subscribedWallet, ok := walletsMap[subscription.Coin][subscription.Wallet]
+		if !ok {
+			subscribedWallet = &SubscribedWallet{
+				id:          subscription.WalletID,
+				subscribers: []PayoutsSubscriber{},
+			}
+			walletsMap[subscription.Coin][subscription.Wallet] = subscribedWallet
 		}
+
+		//	Wallet is requested from pool if at least one subscriber wants notifications
+		subscribedWallet.payouts = subscribedWallet.payouts || subscription.PayoutsNotify
+		subscribedWallet.blocks = subscribedWallet.blocks || subscription.BlocksNotify
+		subscribedWallet.subscribers = append(subscribedWallet.subscribers, PayoutsSubscriber{
+			WalletSubscriber: WalletSubscriber{
+				userInfo: UserInfo{
+					userID:       subscription.UserID,
+					chatID:       subscription.ChatID,
+					lang:         subscription.Lang,
+					shortWallets: subscription.ShortWallets,
+				},
+				subscriptionID: subscription.SubscriptionID,
+				label:          subscription.Label,
+			},
+			payouts: subscription.PayoutsNotify,
+			blocks:  subscription.BlocksNotify,
+		})
 	}
 
 	return walletsMap, nil
 }
 
-func (w *Payouts) getPoolRequestsMap(walletsMap map[string]map[string]*UserWallet) (map[string]*PoolPayoutsRequests, int, int, error) {
+func (w *Payouts) getPoolRequestsMap(walletsMap map[string]map[string]*SubscribedWallet) (map[string]*PoolPayoutsRequests, int, int, error) {
 	poolRequestsMap := make(map[string]*PoolPayoutsRequests)
 	requestsCount := 0
 	soloRequestsCount := 0
+	var errs []error
 	for coin, coinWalletsMap := range walletsMap {
+		if !w.blockchainsService.IsAvailable(coin) {
+			zap.L().Warn("pool api is unavailable, payouts check is skipped", zap.String("coin", coin))
+
+			continue
+		}
+
 		conn, err := w.blockchainsService.GetConnection(coin)
 		if err != nil {
-			return nil, 0, 0, err
-		}
+			errs = append(errs, err)
 
-		client := poolPayoutsProto.NewPoolPayoutsServiceClient(conn)
-		poolRequests := &PoolPayoutsRequests{
-			client:      client,
-			wallets:     [][]string{},
-			soloWallets: [][]string{},
+			continue
 		}
 
-		groupNum, soloGroupNum := 0, 0
-		requestsCount++
-		soloRequestsCount++
-		i, j := 0, 0
-		for wallet, userWallet := range coinWalletsMap {
-			if i > w.config.MaxWalletsInPayoutsRequest {
-				groupNum++
-				requestsCount++
-				i = 0
-			}
-
-			if j > w.config.MaxWalletsInWorkersRequest {
-				soloGroupNum++
-				soloRequestsCount++
-				j = 0
+		wallets := []string{}
+		soloWallets := []string{}
+		for wallet, subscribedWallet := range coinWalletsMap {
+			if subscribedWallet.payouts {
+				wallets = append(wallets, wallet)
 			}
 
-			if userWallet.payouts {
-				poolRequests.wallets[groupNum][i] = wallet
-
-				i++
+			if subscribedWallet.blocks {
+				soloWallets = append(soloWallets, wallet)
 			}
+		}
 
-			if userWallet.blocks {
-				poolRequests.soloWallets[soloGroupNum][j] = wallet
-
-				j++
-			}
+		poolRequests := &PoolPayoutsRequests{
+			client:      poolPayoutsProto.NewPoolPayoutsServiceClient(conn),
+			wallets:     chunk(wallets, w.config.MaxWalletsInPayoutsRequest),
+			soloWallets: chunk(soloWallets, w.config.MaxWalletsInPayoutsRequest),
 		}
+		requestsCount += len(poolRequests.wallets)
+		soloRequestsCount += len(poolRequests.soloWallets)
 
 		poolRequestsMap[coin] = poolRequests
 	}
 
-	return poolRequestsMap, requestsCount, soloRequestsCount, nil
+	return poolRequestsMap, requestsCount, soloRequestsCount, errors.Join(errs...)
 }
 
 func (p *Payouts) getSoloPayouts(
@@ -237,7 +209,8 @@ func (p *Payouts) getSoloPayouts(
 			err:      nil,
 		}
 
-		soloPayouts, err := client.GetSoloBlocksFromList(ctx, &poolPayoutsProto.GetSoloBlocksFromListRequest{
+		groupCtx, span := startGroupSpan(ctx, "notify.solo_payouts.group", coin, groupNum, len(wallets))
+		soloPayouts, err := client.GetSoloBlocksFromList(groupCtx, &poolPayoutsProto.GetSoloBlocksFromListRequest{
 			Miners: wallets,
 			Filters: &poolPayoutsProto.MinedSoloBlocksFilters{
 				MinedAt: &filtersProto.DateTimeRangeFilter{
@@ -251,6 +224,8 @@ func (p *Payouts) getSoloPayouts(
 			result.payouts = soloPayouts.Blocks
 		}
 
+		tracing.End(span, result.err)
+
 		resultCh <- result
 	}
 }
@@ -275,7 +250,8 @@ func (p *Payouts) getPayouts(
 			err:      nil,
 		}
 
-		payouts, err := client.GetPayoutsFromList(ctx, &poolPayoutsProto.GetPayoutsFromListRequest{
+		groupCtx, span := startGroupSpan(ctx, "notify.payouts.group", coin, groupNum, len(wallets))
+		payouts, err := client.GetPayoutsFromList(groupCtx, &poolPayoutsProto.GetPayoutsFromListRequest{
 			Miners: wallets,
 			Filters: &poolPayoutsProto.PayoutsFilters{
 				PaidAt: &filtersProto.DateTimeRangeFilter{
@@ -289,6 +265,8 @@ func (p *Payouts) getPayouts(
 			result.payouts = payouts.Payouts
 		}
 
+		tracing.End(span, result.err)
+
 		resultCh <- result
 	}
 }
@@ -296,6 +274,7 @@ func (p *Payouts) getPayouts(
 func (p *Payouts) notifyUsersPayments(
 	ctx context.Context,
 	usersWalletsPayouts []*UserWalletPayouts,
+	chatTargetsMap map[int64][]storage.ChatTarget,
 	wg *sync.WaitGroup,
 ) {
 	defer wg.Done()
@@ -305,7 +284,12 @@ func (p *Payouts) notifyUsersPayments(
 	default:
 		var msgBuf bytes.Buffer
 		for _, userWalletPayouts := range usersWalletsPayouts {
+			if p.pauses.IsPaused(userWalletPayouts.walletInfo.blockchain.Coin) {
+				continue
+			}
+
 			userLocalizer := p.languages.GetLocalizer(userWalletPayouts.userInfo.lang)
+			userFormatter := formatUtils.NewFormatter(userLocalizer)
 
 			for _, userPayoutInfo := range userWalletPayouts.payouts {
 				msgBuf.WriteString(userLocalizer.MustLocalize(&i18n.LocalizeConfig{
@@ -315,7 +299,11 @@ func (p *Payouts) notifyUsersPayments(
 				msgBuf.WriteString(userLocalizer.MustLocalize(&i18n.LocalizeConfig{
 					MessageID: "WalletInfo",
 					TemplateData: map[string]string{
-						"Wallet":             userWalletPayouts.walletInfo.wallet,
+						"Wallet": formatUtils.WalletName(
+							userWalletPayouts.walletInfo.wallet,
+							userWalletPayouts.walletInfo.label,
+							userWalletPayouts.userInfo.shortWallets,
+						),
 						"PoolBlockchainName": userWalletPayouts.walletInfo.blockchain.Name,
 					},
 				}))
@@ -323,17 +311,26 @@ func (p *Payouts) notifyUsersPayments(
 				msgBuf.WriteString(userLocalizer.MustLocalize(&i18n.LocalizeConfig{
 					MessageID: "PayoutInfo",
 					TemplateData: map[string]string{
-						"Amount": formatUtils.WalletBalance(userPayoutInfo.amount, userWalletPayouts.walletInfo.blockchain.AtomicUnit),
+						"Amount": userFormatter.Amount(
+							userPayoutInfo.amount,
+							userWalletPayouts.walletInfo.blockchain.Decimals,
+							userWalletPayouts.walletInfo.blockchain.DisplayPrecision,
+						),
 						"Ticker": userWalletPayouts.walletInfo.blockchain.Ticker,
 						"TxHash": userPayoutInfo.txHash,
-						"PaidAt": userPayoutInfo.paidAt.Format(time.RFC3339),
+						"PaidAt": userFormatter.DateTime(userPayoutInfo.paidAt),
 					},
 				}))
 
-				p.b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID: userWalletPayouts.userInfo.chatID,
-					Text:   msgBuf.String(),
-				})
+				sendTargetsMessage(
+					ctx,
+					p.b,
+					p.privacyService,
+					PAYOUT_MESSAGE,
+					userWalletPayouts.userInfo.chatID,
+					chatTargetsMap[userWalletPayouts.walletInfo.subscriptionID],
+					msgBuf.String(),
+				)
 
 				msgBuf.Reset()
 			}
@@ -344,6 +341,7 @@ func (p *Payouts) notifyUsersPayments(
 func (p *Payouts) notifyUsersSoloPayments(
 	ctx context.Context,
 	usersWalletsSoloPayouts []*UserWalletSoloPayouts,
+	chatTargetsMap map[int64][]storage.ChatTarget,
 	wg *sync.WaitGroup,
 ) {
 	defer wg.Done()
@@ -353,7 +351,12 @@ func (p *Payouts) notifyUsersSoloPayments(
 	default:
 		var msgBuf bytes.Buffer
 		for _, userWalletSoloPayouts := range usersWalletsSoloPayouts {
+			if p.pauses.IsPaused(userWalletSoloPayouts.walletInfo.blockchain.Coin) {
+				continue
+			}
+
 			userLocalizer := p.languages.GetLocalizer(userWalletSoloPayouts.userInfo.lang)
+			userFormatter := formatUtils.NewFormatter(userLocalizer)
 
 			for _, userSoloPayoutInfo := range userWalletSoloPayouts.payouts {
 				msgBuf.WriteString(userLocalizer.MustLocalize(&i18n.LocalizeConfig{
@@ -363,7 +366,11 @@ func (p *Payouts) notifyUsersSoloPayments(
 				msgBuf.WriteString(userLocalizer.MustLocalize(&i18n.LocalizeConfig{
 					MessageID: "WalletInfo",
 					TemplateData: map[string]string{
-						"Wallet":             userWalletSoloPayouts.walletInfo.wallet,
+						"Wallet": formatUtils.WalletName(
+							userWalletSoloPayouts.walletInfo.wallet,
+							userWalletSoloPayouts.walletInfo.label,
+							userWalletSoloPayouts.userInfo.shortWallets,
+						),
 						"PoolBlockchainName": userWalletSoloPayouts.walletInfo.blockchain.Name,
 					},
 				}))
@@ -371,18 +378,27 @@ func (p *Payouts) notifyUsersSoloPayments(
 				msgBuf.WriteString(userLocalizer.MustLocalize(&i18n.LocalizeConfig{
 					MessageID: "SoloPayoutInfo",
 					TemplateData: map[string]string{
-						"Reward":    formatUtils.WalletBalance(userSoloPayoutInfo.reward, userWalletSoloPayouts.walletInfo.blockchain.AtomicUnit),
+						"Reward": userFormatter.Amount(
+							userSoloPayoutInfo.reward,
+							userWalletSoloPayouts.walletInfo.blockchain.Decimals,
+							userWalletSoloPayouts.walletInfo.blockchain.DisplayPrecision,
+						),
 						"Ticker":    userWalletSoloPayouts.walletInfo.blockchain.Ticker,
 						"BlockHash": userSoloPayoutInfo.blockHash,
 						"TxHash":    userSoloPayoutInfo.txHash,
-						"PaidAt":    userSoloPayoutInfo.paidAt.Format(time.RFC3339),
+						"PaidAt":    userFormatter.DateTime(userSoloPayoutInfo.paidAt),
 					},
 				}))
 
-				p.b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID: userWalletSoloPayouts.userInfo.chatID,
-					Text:   msgBuf.String(),
-				})
+				sendTargetsMessage(
+					ctx,
+					p.b,
+					p.privacyService,
+					SOLO_BLOCK_MESSAGE,
+					userWalletSoloPayouts.userInfo.chatID,
+					chatTargetsMap[userWalletSoloPayouts.walletInfo.subscriptionID],
+					msgBuf.String(),
+				)
 
 				msgBuf.Reset()
 			}
@@ -390,42 +406,43 @@ func (p *Payouts) notifyUsersSoloPayments(
 	}
 }
 
-func (p *Payouts) Check(ctx context.Context) {
-	lastExecutionTime, err := p.getLastExecutionTime(ctx)
+func (p *Payouts) Check(ctx context.Context) error {
+	//	Next check requests payouts from start of this one, so payouts made during it are not missed
+	checkedAt := time.Now()
+	lastExecutionTime, err := p.notify.FindLastPayoutsCheck(ctx)
 	if err != nil {
-		zap.L().Error("failed to get last payments notification executed time", zap.Error(err))
-
-		return
+		return fmt.Errorf("failed to get last payments notification executed time: %w", err)
 	}
 
 	if lastExecutionTime == nil {
-		if err := p.addNotification(ctx); err != nil {
-			zap.L().Error("failed to add first payments notification to db", zap.Error(err))
+		if err := p.notify.AddPayoutsCheck(ctx, checkedAt); err != nil {
+			return fmt.Errorf("failed to add first payments notification to db: %w", err)
 		}
 
-		return
+		return nil
 	}
 
 	walletsMap, err := p.getWalletsMap(ctx)
 	defer clear(walletsMap)
 	if err != nil {
-		zap.L().Error("failed to get wallets map", zap.Error(err))
+		return fmt.Errorf("failed to get wallets map: %w", err)
+	}
 
-		return
+	//	Coins without subscribed wallets are set to zero instead of keeping value of previous check
+	for _, blockchain := range p.blockchainsService.GetBlockchainsInfo() {
+		metrics.TrackedWallets.WithLabelValues(blockchain.Coin).Set(float64(len(walletsMap[blockchain.Coin])))
 	}
 
+	//	Failed coins don't stop check of other ones, their errors are returned after notifications are sent
+	var errs []error
 	poolRequestsMap, requestsCount, soloRequestsCount, err := p.getPoolRequestsMap(walletsMap)
 	defer clear(poolRequestsMap)
 	if err != nil {
-		zap.L().Error("failed to create pool requests map", zap.Error(err))
-
-		return
+		errs = append(errs, fmt.Errorf("failed to create pool requests map: %w", err))
 	}
 
 	poolPayoutsCh := make(chan PoolPayouts, requestsCount)
 	poolSoloPayoutsCh := make(chan PoolSoloPayouts, soloRequestsCount)
-	defer close(poolPayoutsCh)
-	defer close(poolSoloPayoutsCh)
 	newCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -464,39 +481,28 @@ func (p *Payouts) Check(ctx context.Context) {
 	for i := 0; i < (requestsCount + soloRequestsCount); i++ {
 		select {
 		case <-ctx.Done():
-			return
+			return ctx.Err()
 		case poolPayouts := <-poolPayoutsCh:
 			if poolPayouts.err != nil {
-				zap.L().Error("get pool payouts error",
-					zap.String("coin", poolPayouts.coin),
-					zap.Int("group_num", poolPayouts.groupNum),
-					zap.Error(poolPayouts.err),
-				)
+				err := fmt.Errorf("failed to get pool payouts (coin: %s, group num: %d): %w", poolPayouts.coin, poolPayouts.groupNum, poolPayouts.err)
+				zap.L().Error("notify payouts group failed", zap.String("coin", poolPayouts.coin), zap.Error(err))
+				errs = append(errs, err)
 
-				return
+				continue
 			}
 
 			blockchain, err := p.blockchainsService.GetInfo(poolPayouts.coin)
 			if err != nil {
-				zap.L().Error("get blockchain info for payouts error",
-					zap.String("coin", poolPayouts.coin),
-					zap.Int("group_num", poolPayouts.groupNum),
-					zap.Error(err),
-				)
+				errs = append(errs, fmt.Errorf("failed to get blockchain info for payouts (group num: %d): %w", poolPayouts.groupNum, err))
 
-				return
+				continue
 			}
 
 			coinWalletsMap, ok := walletsMap[poolPayouts.coin]
 			if ok {
 				for wallet, walletPayouts := range poolPayouts.payouts {
-					userWallet, ok := coinWalletsMap[wallet]
+					subscribedWallet, ok := coinWalletsMap[wallet]
 					if ok {
-						walletInfo := WalletInfo{
-							id:         userWallet.id,
-							wallet:     wallet,
-							blockchain: blockchain,
-						}
 						userWalletPayouts := make([]*PayoutInfo, 0, len(walletPayouts.Payouts))
 						for _, walletPayout := range walletPayouts.Payouts {
 							userWalletPayouts = append(userWalletPayouts, &PayoutInfo{
@@ -506,47 +512,51 @@ func (p *Payouts) Check(ctx context.Context) {
 							})
 						}
 
-						userPayoutsMap, ok := payoutsMap[*userWallet.userInfo]
-						if ok {
+						for _, subscriber := range subscribedWallet.subscribers {
+							if !subscriber.payouts {
+								continue
+							}
+
+							walletInfo := WalletInfo{
+								id:             subscribedWallet.id,
+								subscriptionID: subscriber.subscriptionID,
+								wallet:         wallet,
+								label:          subscriber.label,
+								blockchain:     blockchain,
+							}
+
+							userPayoutsMap, ok := payoutsMap[subscriber.userInfo]
+							if !ok {
+								userPayoutsMap = make(map[WalletInfo][]*PayoutInfo)
+								payoutsMap[subscriber.userInfo] = userPayoutsMap
+							}
+
 							userPayoutsMap[walletInfo] = userWalletPayouts
-						} else {
-							payoutsMap[*userWallet.userInfo][walletInfo] = userWalletPayouts
 						}
 					}
 				}
 			}
 		case poolSoloPayouts := <-poolSoloPayoutsCh:
 			if poolSoloPayouts.err != nil {
-				zap.L().Error("get pool solo payouts error",
-					zap.String("coin", poolSoloPayouts.coin),
-					zap.Int("group_num", poolSoloPayouts.groupNum),
-					zap.Error(poolSoloPayouts.err),
-				)
+				err := fmt.Errorf("failed to get pool solo payouts (coin: %s, group num: %d): %w", poolSoloPayouts.coin, poolSoloPayouts.groupNum, poolSoloPayouts.err)
+				zap.L().Error("notify solo payouts group failed", zap.String("coin", poolSoloPayouts.coin), zap.Error(err))
+				errs = append(errs, err)
 
-				return
+				continue
 			}
 
 			blockchain, err := p.blockchainsService.GetInfo(poolSoloPayouts.coin)
 			if err != nil {
-				zap.L().Error("get blockchain info for solo payouts error",
-					zap.String("coin", poolSoloPayouts.coin),
-					zap.Int("group_num", poolSoloPayouts.groupNum),
-					zap.Error(err),
-				)
+				errs = append(errs, fmt.Errorf("failed to get blockchain info for solo payouts (group num: %d): %w", poolSoloPayouts.groupNum, err))
 
-				return
+				continue
 			}
 
 			coinWalletsMap, ok := walletsMap[poolSoloPayouts.coin]
 			if ok {
 				for wallet, walletSoloPayouts := range poolSoloPayouts.payouts {
-					userWallet, ok := coinWalletsMap[wallet]
+					subscribedWallet, ok := coinWalletsMap[wallet]
 					if ok {
-						walletInfo := WalletInfo{
-							id:         userWallet.id,
-							wallet:     wallet,
-							blockchain: blockchain,
-						}
 						userWalletSoloPayouts := make([]*SoloPayoutInfo, 0, len(walletSoloPayouts.Blocks))
 						for _, walletSoloPayout := range walletSoloPayouts.Blocks {
 							userWalletSoloPayouts = append(userWalletSoloPayouts, &SoloPayoutInfo{
@@ -557,81 +567,87 @@ func (p *Payouts) Check(ctx context.Context) {
 							})
 						}
 
-						userSoloPayoutsMap, ok := soloPayoutsMap[*userWallet.userInfo]
-						if ok {
+						for _, subscriber := range subscribedWallet.subscribers {
+							if !subscriber.blocks {
+								continue
+							}
+
+							walletInfo := WalletInfo{
+								id:             subscribedWallet.id,
+								subscriptionID: subscriber.subscriptionID,
+								wallet:         wallet,
+								label:          subscriber.label,
+								blockchain:     blockchain,
+							}
+
+							userSoloPayoutsMap, ok := soloPayoutsMap[subscriber.userInfo]
+							if !ok {
+								userSoloPayoutsMap = make(map[WalletInfo][]*SoloPayoutInfo)
+								soloPayoutsMap[subscriber.userInfo] = userSoloPayoutsMap
+							}
+
 							userSoloPayoutsMap[walletInfo] = userWalletSoloPayouts
-						} else {
-							soloPayoutsMap[*userWallet.userInfo][walletInfo] = userWalletSoloPayouts
 						}
 					}
 				}
 			}
-		default:
 		}
 	}
 
-	usersWalletsPayoutsGroups := [][]*UserWalletPayouts{}
-	usersWalletsSoloPayoutsGroups := [][]*UserWalletSoloPayouts{}
-	defer func() {
-		usersWalletsPayoutsGroups, usersWalletsSoloPayoutsGroups = nil, nil
-	}()
-	groupNum := 0
-	i := 0
-
+	usersWalletsPayouts := []*UserWalletPayouts{}
 	for userInfo, userPayoutsMap := range payoutsMap {
-		if groupNum > p.config.ParallelNotificationsCount {
-			groupNum++
-			i = 0
-		}
+		userInfo := userInfo
 
 		for walletInfo, userWalletPayouts := range userPayoutsMap {
-			usersWalletsPayoutsGroups[groupNum][i] = &UserWalletPayouts{
+			walletInfo := walletInfo
+			usersWalletsPayouts = append(usersWalletsPayouts, &UserWalletPayouts{
 				UserPayouts: UserPayouts{
 					userInfo:   &userInfo,
 					walletInfo: &walletInfo,
 				},
 				payouts: userWalletPayouts,
-			}
-
-			i++
+			})
 		}
 	}
 
-	soloGroupNum := 0
-	j := 0
+	usersWalletsSoloPayouts := []*UserWalletSoloPayouts{}
 	for userInfo, userSoloPayoutsMap := range soloPayoutsMap {
-		if groupNum > p.config.ParallelNotificationsCount {
-			soloGroupNum++
-			j = 0
-		}
+		userInfo := userInfo
 
 		for walletInfo, userWalletSoloPayouts := range userSoloPayoutsMap {
-			usersWalletsSoloPayoutsGroups[groupNum][j] = &UserWalletSoloPayouts{
+			walletInfo := walletInfo
+			usersWalletsSoloPayouts = append(usersWalletsSoloPayouts, &UserWalletSoloPayouts{
 				UserPayouts: UserPayouts{
 					userInfo:   &userInfo,
 					walletInfo: &walletInfo,
 				},
 				payouts: userWalletSoloPayouts,
-			}
-
-			j++
+			})
 		}
 	}
 
+	chatTargetsMap, err := p.chatTargets.FindAllWalletTargets(ctx)
+	if err != nil {
+		return err
+	}
+
 	wg := sync.WaitGroup{}
-	for _, usersWalletsPayouts := range usersWalletsPayoutsGroups {
+	for _, usersWalletsPayoutsGroup := range spread(usersWalletsPayouts, p.config.ParallelNotificationsCount) {
 		wg.Add(1)
-		go p.notifyUsersPayments(ctx, usersWalletsPayouts, &wg)
+		go p.notifyUsersPayments(ctx, usersWalletsPayoutsGroup, chatTargetsMap, &wg)
 	}
 
-	for _, usersWalletsSoloPayouts := range usersWalletsSoloPayoutsGroups {
+	for _, usersWalletsSoloPayoutsGroup := range spread(usersWalletsSoloPayouts, p.config.ParallelNotificationsCount) {
 		wg.Add(1)
-		go p.notifyUsersSoloPayments(ctx, usersWalletsSoloPayouts, &wg)
+		go p.notifyUsersSoloPayments(ctx, usersWalletsSoloPayoutsGroup, chatTargetsMap, &wg)
 	}
 
 	wg.Wait()
 
-	if err := p.addNotification(ctx); err != nil {
-		zap.L().Error("failed to add payments notification to db", zap.Error(err))
+	//	Check time is saved even when some coins failed, otherwise payouts of healthy coins would be sent again
+	if err := p.notify.AddPayoutsCheck(ctx, checkedAt); err != nil {
+		errs = append(errs, fmt.Errorf("failed to add payments notification to db: %w", err))
 	}
+
+	return errors.Join(errs...)
 }
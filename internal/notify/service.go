@@ -4,103 +4,254 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/go-co-op/gocron/v2"
 	"github.com/go-telegram/bot"
 	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
 	"github.com/grandminingpool/telegram-bot/internal/blockchains"
 	"github.com/grandminingpool/telegram-bot/internal/common/languages"
-	"github.com/jmoiron/sqlx"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/grandminingpool/telegram-bot/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	WORKERS_JOB = "workers"
+	PAYOUTS_JOB = "payouts"
+)
+
+type JobState struct {
+	Name          string     `json:"name"`
+	Interval      string     `json:"interval"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	interval      time.Duration
+}
+
+func (s *JobState) IntervalDuration() time.Duration {
+	return s.interval
+}
+
+var (
+	ErrServiceNotStarted = errors.New("notify service is not started")
+	ErrJobNotFound       = errors.New("notify job not found")
 )
 
 type PlannedJob struct {
+	name       string
 	definition gocron.JobDefinition
 	task       gocron.Task
 }
 
 type Service struct {
+	mu        sync.Mutex
 	scd       gocron.Scheduler
 	ctxCancel context.CancelFunc
 	workers   *Workers
 	payouts   *Payouts
 	config    *botConfig.NotifyConfig
-	jobs      []gocron.Job
+	jobs      map[string]gocron.Job
+	pauses    *Pauses
+	startedAt time.Time
+	statesMu  sync.RWMutex
+	states    map[string]*JobState
+}
+
+func startGroupSpan(ctx context.Context, name, coin string, groupNum, walletsCount int) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name,
+		attribute.String("pool.coin", coin),
+		attribute.Int("notify.group_num", groupNum),
+		attribute.Int("notify.wallets_count", walletsCount),
+	)
+}
+
+func (s *Service) runJob(ctx context.Context, name string, check func(ctx context.Context) error) {
+	jobCtx, span := tracing.Start(ctx, "notify."+name)
+	startTime := time.Now()
+	err := check(jobCtx)
+	tracing.End(span, err)
+	metrics.NotifyJobDuration.WithLabelValues(name).Observe(time.Since(startTime).Seconds())
+	metrics.NotifyJobRuns.WithLabelValues(name, metrics.Status(err)).Inc()
+
+	s.statesMu.Lock()
+	if state, ok := s.states[name]; ok {
+		state.LastRunAt = &startTime
+		if err != nil {
+			state.LastError = err.Error()
+		} else {
+			state.LastSuccessAt = &startTime
+			state.LastError = ""
+		}
+	}
+	s.statesMu.Unlock()
+
+	if err != nil {
+		zap.L().Error("notify job failed", zap.String("job", name), zap.Error(err))
+	}
+}
+
+func (s *Service) StartedAt() time.Time {
+	s.statesMu.RLock()
+	defer s.statesMu.RUnlock()
+
+	return s.startedAt
+}
+
+func (s *Service) JobStates() []JobState {
+	s.statesMu.RLock()
+	defer s.statesMu.RUnlock()
+
+	states := make([]JobState, 0, len(s.states))
+	for _, state := range s.states {
+		states = append(states, *state)
+	}
+
+	return states
+}
+
+func (s *Service) Pauses() *Pauses {
+	return s.pauses
+}
+
+func (s *Service) RunNow(name string) error {
+	s.mu.Lock()
+	if s.scd == nil {
+		s.mu.Unlock()
+
+		return ErrServiceNotStarted
+	}
+
+	job, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+
+	if err := job.RunNow(); err != nil {
+		return fmt.Errorf("failed to run notify job (name: %s): %w", name, err)
+	}
+
+	return nil
 }
 
 func (s *Service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.scd != nil {
 		return errors.New("notify has been already started")
 	}
 
+	if err := s.pauses.Load(ctx); err != nil {
+		return err
+	}
+
 	scd, err := gocron.NewScheduler()
 	if err != nil {
 		return fmt.Errorf("failed to create notify scheduler: %w", err)
 	}
 
-	s.scd = scd
-
 	serviceCtx, cancel := context.WithCancel(ctx)
-	s.ctxCancel = cancel
 
 	plannedJobs := []PlannedJob{
 		{
+			name:       WORKERS_JOB,
 			definition: gocron.DurationJob(s.config.CheckIntervals.WorkersDuration()),
-			task:       gocron.NewTask(s.workers.Check, serviceCtx),
+			task:       gocron.NewTask(s.runJob, serviceCtx, WORKERS_JOB, s.workers.Check),
 		},
 		{
+			name:       PAYOUTS_JOB,
 			definition: gocron.DurationJob(s.config.CheckIntervals.PayoutsDuration()),
-			task:       gocron.NewTask(s.payouts.Check, serviceCtx),
+			task:       gocron.NewTask(s.runJob, serviceCtx, PAYOUTS_JOB, s.payouts.Check),
 		},
 	}
 
 	for _, pj := range plannedJobs {
-		job, err := scd.NewJob(pj.definition, pj.task)
+		//	Forced runs must not overlap with scheduled ones
+		job, err := scd.NewJob(pj.definition, pj.task, gocron.WithSingletonMode(gocron.LimitModeReschedule))
 		if err != nil {
+			cancel()
 			scd.Shutdown()
+			clear(s.jobs)
 
-			return fmt.Errorf("failed to create notify job: %w", err)
+			return fmt.Errorf("failed to create notify job (name: %s): %w", pj.name, err)
 		}
 
-		s.jobs = append(s.jobs, job)
+		s.jobs[pj.name] = job
 	}
 
+	s.scd = scd
+	s.ctxCancel = cancel
+
+	s.statesMu.Lock()
+	s.startedAt = time.Now()
+	s.statesMu.Unlock()
+
 	scd.Start()
 
 	return nil
 }
 
 func (s *Service) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.scd == nil && s.ctxCancel == nil {
 		return errors.New("service is not started")
 	}
 
+	//	Context is cancelled first, so shutdown doesn't wait for running checks to finish all requests
+	s.ctxCancel()
+
 	if err := s.scd.Shutdown(); err != nil {
 		return fmt.Errorf("failed to shutdown notify scheduler: %w", err)
 	}
 
 	s.scd = nil
 	s.ctxCancel = nil
-	s.jobs = nil
+	clear(s.jobs)
+
+	s.statesMu.Lock()
+	s.startedAt = time.Time{}
+	s.statesMu.Unlock()
 
 	return nil
 }
 
 func NewService(
-	pgConn *sqlx.DB,
+	notify storage.NotifyRepository,
+	wallets storage.WalletRepository,
+	chatTargets storage.ChatTargetRepository,
 	blockchainsService *blockchains.Service,
 	b *bot.Bot,
+	privacyService *privacy.Service,
 	languages *languages.Languages,
 	config *botConfig.NotifyConfig,
 ) *Service {
+	pauses := NewPauses(notify)
 	workers := &Workers{
-		pgConn:             pgConn,
+		notify:             notify,
+		wallets:            wallets,
+		chatTargets:        chatTargets,
+		pauses:             pauses,
+		privacyService:     privacyService,
 		blockchainsService: blockchainsService,
 		b:                  b,
 		languages:          languages,
 		config:             config,
 	}
 	payouts := &Payouts{
-		pgConn:             pgConn,
+		notify:             notify,
+		chatTargets:        chatTargets,
+		pauses:             pauses,
+		privacyService:     privacyService,
 		blockchainsService: blockchainsService,
 		b:                  b,
 		languages:          languages,
@@ -111,6 +262,19 @@ func NewService(
 		workers: workers,
 		payouts: payouts,
 		config:  config,
-		jobs:    []gocron.Job{},
+		jobs:    make(map[string]gocron.Job),
+		pauses:  pauses,
+		states: map[string]*JobState{
+			WORKERS_JOB: {
+				Name:     WORKERS_JOB,
+				Interval: config.CheckIntervals.WorkersDuration().String(),
+				interval: config.CheckIntervals.WorkersDuration(),
+			},
+			PAYOUTS_JOB: {
+				Name:     PAYOUTS_JOB,
+				Interval: config.CheckIntervals.PayoutsDuration().String(),
+				interval: config.CheckIntervals.PayoutsDuration(),
+			},
+		},
 	}
 }
@@ -0,0 +1,43 @@
+package botNotify
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	telegramUtils "github.com/grandminingpool/telegram-bot/internal/utils/telegram"
+	"go.uber.org/zap"
+)
+
+func sendTargetsMessage(
+	ctx context.Context,
+	b *bot.Bot,
+	privacyService *privacy.Service,
+	msgType string,
+	chatID int64,
+	targets []storage.ChatTarget,
+	text string,
+) {
+	err := sendMessage(ctx, b, msgType, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+	if kind := telegramUtils.ClassifySendError(err); err != nil && kind.Unreachable() {
+		//	User is not notified until starts bot again, so linked targets are skipped too
+		if err := privacyService.Deactivate(ctx, chatID, kind); err != nil {
+			zap.L().Error("deactivate user error", zap.Int64("chat_id", chatID), zap.Error(err))
+		}
+
+		return
+	}
+
+	//	Group and channel targets receive same message, in forum topic if it was linked from it
+	for _, target := range targets {
+		sendMessage(ctx, b, msgType, &bot.SendMessageParams{
+			ChatID:          target.ChatID,
+			MessageThreadID: int(target.ThreadID),
+			Text:            text,
+		})
+	}
+}
@@ -3,6 +3,7 @@ package botNotify
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,15 +13,16 @@ import (
 	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
 	"github.com/grandminingpool/telegram-bot/internal/blockchains"
 	"github.com/grandminingpool/telegram-bot/internal/common/languages"
+	"github.com/grandminingpool/telegram-bot/internal/metrics"
+	"github.com/grandminingpool/telegram-bot/internal/privacy"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/grandminingpool/telegram-bot/internal/tracing"
 	formatUtils "github.com/grandminingpool/telegram-bot/internal/utils/format"
 	"github.com/hashicorp/go-set/v2"
-	"github.com/jmoiron/sqlx"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"go.uber.org/zap"
 )
 
-const REMOWED_WORKERS_TEMP_TABLE_NAME = "wallet_workers_to_be_removed"
-
 type WorkerInfo struct {
 	worker      string
 	region      string
@@ -37,39 +39,32 @@ type PoolWorkersRequests struct {
 	wallets [][]string
 }
 
-type RemovalWorkerDB struct {
-	WalletID int64  `json:"wallet_id"`
-	Worker   string `json:"worker"`
-}
-
-type WorkerDB struct {
-	RemovalWorkerDB
-	Region      string    `json:"region"`
-	Solo        bool      `json:"solo"`
-	ConnectedAt time.Time `json:"connected_at"`
-}
-
-type ChangedWorkersDB struct {
-	added   []WorkerDB
-	removed []RemovalWorkerDB
+type UserInfo struct {
+	userID       int64
+	chatID       int64
+	lang         string
+	shortWallets bool
 }
 
-type UserInfo struct {
-	userID int64
-	chatID int64
-	lang   string
+type WalletSubscriber struct {
+	userInfo       UserInfo
+	subscriptionID int64
+	label          string
+	notify         bool
 }
 
 type WalletInfo struct {
-	id         int64
-	wallet     string
-	blockchain *blockchains.BlockchainInfo
+	id             int64
+	subscriptionID int64
+	wallet         string
+	label          string
+	blockchain     *blockchains.BlockchainInfo
 }
 
-type UserWalletWorkers struct {
-	userInfo *UserInfo
-	id       int64
-	workers  *set.HashSet[*WorkerInfo, string]
+type WalletWorkers struct {
+	id          int64
+	subscribers []WalletSubscriber
+	workers     *set.HashSet[*WorkerInfo, string]
 }
 
 type UserChangedWorkers struct {
@@ -80,6 +75,7 @@ type UserChangedWorkers struct {
 type PoolWorkers struct {
 	groupNum int
 	coin     string
+	wallets  []string
 	workers  map[string]*poolMinersProto.MinerWorkers
 	err      error
 }
@@ -96,121 +92,116 @@ type ChangedUserWorkers struct {
 }
 
 type Workers struct {
-	pgConn             *sqlx.DB
+	notify             storage.NotifyRepository
+	wallets            storage.WalletRepository
+	chatTargets        storage.ChatTargetRepository
+	pauses             *Pauses
+	privacyService     *privacy.Service
 	blockchainsService *blockchains.Service
 	b                  *bot.Bot
 	languages          *languages.Languages
 	config             *botConfig.NotifyConfig
 }
 
-func (w *Workers) getWorkersMap(ctx context.Context) (map[string]map[string]*UserWalletWorkers, error) {
-	workersMap := make(map[string]map[string]*UserWalletWorkers)
-	rows, err := w.pgConn.QueryContext(ctx, `SELECT
-		user_wallets.user_id,
-		users.chat_id,
-		users.lang,
-		user_wallets.blockchain_coin,
-		user_wallets.id,
-		user_wallets.wallet,
-		wallet_workers.worker,
-		wallet_workers.region,
-		wallet_workers.solo,
-		wallet_workers.connected_at
-	FROM wallet_workers
-	LEFT JOIN users ON users.id = wallet_workers.user_id
-	LEFT JOIN user_wallets ON user_wallets.id = wallet_workers.wallet_id`)
+func (w *Workers) getWorkersMap(ctx context.Context) (map[string]map[string]*WalletWorkers, []storage.WalletWorkerDB, error) {
+	subscriptions, err := w.notify.FindSubscriptions(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query workers: %w", err)
+		return nil, nil, err
 	}
-	set.New[string](10)
-	for rows.Next() {
-		var (
-			userID, chatID, walletID               int64
-			userLang, coin, wallet, worker, region string
-			solo                                   bool
-			connectedAt                            time.Time
-		)
-
-		if err := rows.Scan(
-			&userID,
-			&chatID,
-			&userLang,
-			&coin,
-			&walletID,
-			&wallet,
-			&worker,
-			&region,
-			&solo,
-			&connectedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan workers columns: %w", err)
-		}
 
-		_, ok := workersMap[coin]
+	workersMap := make(map[string]map[string]*WalletWorkers)
+	walletsMap := make(map[int64]*WalletWorkers)
+	for _, subscription := range subscriptions {
+		_, ok := workersMap[subscription.Coin]
 		if !ok {
-			workersMap[coin] = make(map[string]*UserWalletWorkers)
+			workersMap[subscription.Coin] = make(map[string]*WalletWorkers)
 		}
 
-		workerInfo := &WorkerInfo{
-			worker,
-			region,
-			solo,
-			connectedAt,
-		}
-		coinWorkersWalletsMap, ok := workersMap[coin][wallet]
+		walletWorkers, ok := workersMap[subscription.Coin][subscription.Wallet]
 		if !ok {
-			set.NewHashSet[*WorkerInfo, string](0)
-			workersMap[coin][wallet] = &UserWalletWorkers{
-				userInfo: &UserInfo{
-					userID: userID,
-					chatID: chatID,
-					lang:   userLang,
-				},
-				id:      walletID,
-				workers: set.HashSetFrom[*WorkerInfo, string]([]*WorkerInfo{workerInfo}),
+			walletWorkers = &WalletWorkers{
+				id:          subscription.WalletID,
+				subscribers: []WalletSubscriber{},
+				workers:     set.NewHashSet[*WorkerInfo, string](0),
 			}
+			workersMap[subscription.Coin][subscription.Wallet] = walletWorkers
+			walletsMap[subscription.WalletID] = walletWorkers
+		}
+
+		walletWorkers.subscribers = append(walletWorkers.subscribers, WalletSubscriber{
+			userInfo: UserInfo{
+				userID:       subscription.UserID,
+				chatID:       subscription.ChatID,
+				lang:         subscription.Lang,
+				shortWallets: subscription.ShortWallets,
+			},
+			subscriptionID: subscription.SubscriptionID,
+			label:          subscription.Label,
+			notify:         subscription.Notify,
+		})
+	}
+
+	workers, err := w.notify.FindWorkers(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//	Stored workers of wallets without active subscribers, for example paused ones, are not tracked
+	//	and get outdated, so they are removed and wallet starts from current workers when tracked again
+	untrackedWorkers := []storage.WalletWorkerDB{}
+	for _, worker := range workers {
+		if walletWorkers, ok := walletsMap[worker.WalletID]; ok {
+			walletWorkers.workers.Insert(&WorkerInfo{
+				worker.Worker,
+				worker.Region,
+				worker.Solo,
+				worker.ConnectedAt,
+			})
 		} else {
-			coinWorkersWalletsMap.workers.Insert(workerInfo)
+			untrackedWorkers = append(untrackedWorkers, storage.WalletWorkerDB{
+				WalletID: worker.WalletID,
+				Worker:   worker.Worker,
+			})
 		}
 	}
 
-	return workersMap, nil
+	return workersMap, untrackedWorkers, nil
 }
 
-func (w *Workers) getPoolRequestsMap(workersMap map[string]map[string]*UserWalletWorkers) (map[string]*PoolWorkersRequests, int, error) {
+func (w *Workers) getPoolRequestsMap(workersMap map[string]map[string]*WalletWorkers) (map[string]*PoolWorkersRequests, int, error) {
 	poolRequestsMap := make(map[string]*PoolWorkersRequests)
 	requestsCount := 0
+	var errs []error
 	for coin, coinWorkersMap := range workersMap {
+		//	Workers of unavailable pool are kept as is and compared again when pool is back
+		if !w.blockchainsService.IsAvailable(coin) {
+			zap.L().Warn("pool api is unavailable, workers check is skipped", zap.String("coin", coin))
+
+			continue
+		}
+
 		conn, err := w.blockchainsService.GetConnection(coin)
 		if err != nil {
-			return nil, 0, err
-		}
+			errs = append(errs, err)
 
-		client := poolMinersProto.NewPoolMinersServiceClient(conn)
-		poolRequests := &PoolWorkersRequests{
-			client:  client,
-			wallets: [][]string{},
+			continue
 		}
 
-		groupNum := 0
-		requestsCount++
-		i := 0
+		wallets := make([]string, 0, len(coinWorkersMap))
 		for wallet := range coinWorkersMap {
-			if i > w.config.MaxWalletsInWorkersRequest {
-				groupNum++
-				requestsCount++
-				i = 0
-			}
-
-			poolRequests.wallets[groupNum][i] = wallet
+			wallets = append(wallets, wallet)
+		}
 
-			i++
+		poolRequests := &PoolWorkersRequests{
+			client:  poolMinersProto.NewPoolMinersServiceClient(conn),
+			wallets: chunk(wallets, w.config.MaxWalletsInWorkersRequest),
 		}
+		requestsCount += len(poolRequests.wallets)
 
 		poolRequestsMap[coin] = poolRequests
 	}
 
-	return poolRequestsMap, requestsCount, nil
+	return poolRequestsMap, requestsCount, errors.Join(errs...)
 }
 
 func (w *Workers) getWorkers(
@@ -228,10 +219,12 @@ func (w *Workers) getWorkers(
 		result := PoolWorkers{
 			groupNum: groupNum,
 			coin:     coin,
+			wallets:  wallets,
 			workers:  nil,
 			err:      nil,
 		}
-		workers, err := client.GetMinersWorkersFromList(ctx, &poolMinersProto.MinerAddressesRequest{
+		groupCtx, span := startGroupSpan(ctx, "notify.workers.group", coin, groupNum, len(wallets))
+		workers, err := client.GetMinersWorkersFromList(groupCtx, &poolMinersProto.MinerAddressesRequest{
 			Addresses: wallets,
 		})
 		if err != nil {
@@ -240,44 +233,17 @@ func (w *Workers) getWorkers(
 			result.workers = workers.Workers
 		}
 
-		resultCh <- result
-	}
-}
+		tracing.End(span, result.err)
 
-func (w *Workers) addWorkers(ctx context.Context, tx *sqlx.Tx, groupNum int, addedWorkers []WorkerDB, errCh chan<- error) {
-	select {
-	case <-ctx.Done():
-		return
-	default:
-		if _, err := tx.NamedExecContext(ctx, `INSERT INTO wallet_workers (
-		   wallet_id, 
-		   worker, 
-		   region, 
-		   solo, 
-		   connected_at
-	   ) VALUES (:wallet_id, :worker, :region, :solo, :connected_at)`, addedWorkers); err != nil {
-			errCh <- fmt.Errorf("failed to insert added workers batch (group num: %d, batch length: %d), error: %w", groupNum, len(addedWorkers), err)
-		}
-	}
-}
-
-func (w *Workers) removeWorkers(ctx context.Context, tx *sqlx.Tx, groupNum int, removedWorkers []RemovalWorkerDB, errCh chan<- error) {
-	select {
-	case <-ctx.Done():
-		return
-	default:
-		if _, err := tx.NamedExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (
-			wallet_id, 
-			worker
-		) VALUES (:wallet_id, :worker)`, REMOWED_WORKERS_TEMP_TABLE_NAME), removedWorkers); err != nil {
-			errCh <- fmt.Errorf("failed to insert removed workers batch to temp table (group num: %d, batch length: %d), error: %w", groupNum, len(removedWorkers), err)
-		}
+		resultCh <- result
 	}
 }
 
 func (w *Workers) notifyUsers(
 	ctx context.Context,
 	changedUsersWorkers []*ChangedUserWorkers,
+	chatTargetsMap map[int64][]storage.ChatTarget,
+	workerAliasesMap map[int64]map[string]string,
 	wg *sync.WaitGroup,
 ) {
 	defer wg.Done()
@@ -288,12 +254,25 @@ func (w *Workers) notifyUsers(
 		var msgBuf bytes.Buffer
 		for _, changedUserWorkers := range changedUsersWorkers {
 			userLocalizer := w.languages.GetLocalizer(changedUserWorkers.userInfo.lang)
+			userFormatter := formatUtils.NewFormatter(userLocalizer)
 
 			for _, addedWorker := range changedUserWorkers.added {
+				if w.pauses.IsPaused(addedWorker.wallet.blockchain.Coin) {
+					continue
+				}
+
 				msgBuf.WriteString(userLocalizer.MustLocalize(&i18n.LocalizeConfig{
 					MessageID: "WorkerActive",
 					TemplateData: map[string]string{
-						"Worker": addedWorker.worker.worker,
+						"Worker": formatUtils.WorkerName(
+							addedWorker.worker.worker,
+							workerAliasesMap[addedWorker.wallet.subscriptionID][addedWorker.worker.worker],
+						),
+						"Wallet": formatUtils.WalletName(
+							addedWorker.wallet.wallet,
+							addedWorker.wallet.label,
+							changedUserWorkers.userInfo.shortWallets,
+						),
 					},
 				}))
 				msgBuf.WriteString("\n\n")
@@ -302,52 +281,82 @@ func (w *Workers) notifyUsers(
 					TemplateData: map[string]string{
 						"Region":      addedWorker.worker.region,
 						"Solo":        formatUtils.BoolText(addedWorker.worker.solo, userLocalizer),
-						"ConnectedAt": addedWorker.worker.connectedAt.Format(time.Kitchen),
+						"ConnectedAt": userFormatter.Time(addedWorker.worker.connectedAt),
 					},
 				}))
 
-				w.b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID: changedUserWorkers.userInfo.chatID,
-					Text:   msgBuf.String(),
-				})
+				sendTargetsMessage(
+					ctx,
+					w.b,
+					w.privacyService,
+					WORKER_ACTIVE_MESSAGE,
+					changedUserWorkers.userInfo.chatID,
+					chatTargetsMap[addedWorker.wallet.subscriptionID],
+					msgBuf.String(),
+				)
 
 				msgBuf.Reset()
 			}
 
 			for _, removedWorker := range changedUserWorkers.removed {
-				w.b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID: changedUserWorkers.userInfo.chatID,
-					Text: userLocalizer.MustLocalize(&i18n.LocalizeConfig{
+				if w.pauses.IsPaused(removedWorker.wallet.blockchain.Coin) {
+					continue
+				}
+
+				sendTargetsMessage(
+					ctx,
+					w.b,
+					w.privacyService,
+					WORKER_INACTIVE_MESSAGE,
+					changedUserWorkers.userInfo.chatID,
+					chatTargetsMap[removedWorker.wallet.subscriptionID],
+					userLocalizer.MustLocalize(&i18n.LocalizeConfig{
 						MessageID: "WorkerInactive",
 						TemplateData: map[string]string{
-							"Worker": removedWorker.worker.worker,
+							"Worker": formatUtils.WorkerName(
+								removedWorker.worker.worker,
+								workerAliasesMap[removedWorker.wallet.subscriptionID][removedWorker.worker.worker],
+							),
+							"Wallet": formatUtils.WalletName(
+								removedWorker.wallet.wallet,
+								removedWorker.wallet.label,
+								changedUserWorkers.userInfo.shortWallets,
+							),
 						},
 					}),
-				})
+				)
 			}
 		}
 	}
 }
 
-func (w *Workers) Check(ctx context.Context) {
-	workersMap, err := w.getWorkersMap(ctx)
+func (w *Workers) Check(ctx context.Context) error {
+	workersMap, untrackedWorkers, err := w.getWorkersMap(ctx)
 	defer clear(workersMap)
 	if err != nil {
-		zap.L().Error("failed to create workers map", zap.Error(err))
+		return fmt.Errorf("failed to create workers map: %w", err)
+	}
 
-		return
+	//	Every known coin is set, so gauge drops to zero when coin has no tracked workers left
+	for _, blockchain := range w.blockchainsService.GetBlockchainsInfo() {
+		workersCount := 0
+		for _, walletWorkers := range workersMap[blockchain.Coin] {
+			workersCount += walletWorkers.workers.Size()
+		}
+
+		metrics.TrackedWorkers.WithLabelValues(blockchain.Coin).Set(float64(workersCount))
 	}
 
+	//	Failed coins don't stop check of other ones, their errors are returned after notifications are sent
+	var errs []error
 	poolRequestsMap, requestsCount, err := w.getPoolRequestsMap(workersMap)
 	defer clear(poolRequestsMap)
 	if err != nil {
-		zap.L().Error("failed to create pool requests map", zap.Error(err))
-
-		return
+		errs = append(errs, fmt.Errorf("failed to create pool requests map: %w", err))
 	}
 
+	//	Channel is buffered for every request, so groups left after early return don't block on send
 	poolWorkersCh := make(chan PoolWorkers, requestsCount)
-	defer close(poolWorkersCh)
 	newCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -359,209 +368,126 @@ func (w *Workers) Check(ctx context.Context) {
 		}
 	}
 
+	changedWalletsMap := make(map[WalletInfo]*UserChangedWorkers)
 	changedWorkersMap := make(map[UserInfo]map[WalletInfo]*UserChangedWorkers)
+	defer clear(changedWalletsMap)
 	defer clear(changedWorkersMap)
 	for i := 0; i < requestsCount; i++ {
 		select {
 		case <-ctx.Done():
-			return
+			return ctx.Err()
 		case poolWorkers := <-poolWorkersCh:
 			if poolWorkers.err != nil {
-				zap.L().Error("get pool workers error",
-					zap.String("coin", poolWorkers.coin),
-					zap.Int("group_num", poolWorkers.groupNum),
-					zap.Error(poolWorkers.err),
-				)
+				zap.L().Error("notify workers group failed", zap.String("coin", poolWorkers.coin), zap.Error(poolWorkers.err))
+				errs = append(errs, poolWorkers.err)
 
-				return
+				continue
 			}
 
 			blockchain, err := w.blockchainsService.GetInfo(poolWorkers.coin)
 			if err != nil {
-				zap.L().Error("get blockchain info for workers error",
-					zap.String("coin", poolWorkers.coin),
-					zap.Int("group_num", poolWorkers.groupNum),
-					zap.Error(err),
-				)
+				errs = append(errs, fmt.Errorf("failed to get blockchain info for workers (group num: %d): %w", poolWorkers.groupNum, err))
 
-				return
+				continue
 			}
 
 			coinWorkersMap, ok := workersMap[poolWorkers.coin]
 			if ok {
-				for wallet, walletWorkers := range poolWorkers.workers {
-					userWalletWorkers, ok := coinWorkersMap[wallet]
+				//	Wallet missing in pool response has no connected workers, so all tracked ones are removed
+				for _, wallet := range poolWorkers.wallets {
+					trackedWallet, ok := coinWorkersMap[wallet]
 					if ok {
-						walletWorkersSet := set.NewHashSet[*WorkerInfo, string](len(walletWorkers.Workers))
-						for _, mw := range walletWorkers.Workers {
-							walletWorkersSet.Insert(&WorkerInfo{
-								worker:      mw.Worker,
-								region:      mw.Region,
-								solo:        mw.Solo,
-								connectedAt: mw.ConnectedAt.AsTime(),
-							})
+						walletWorkersSet := set.NewHashSet[*WorkerInfo, string](0)
+						if walletWorkers, ok := poolWorkers.workers[wallet]; ok {
+							for _, mw := range walletWorkers.Workers {
+								walletWorkersSet.Insert(&WorkerInfo{
+									worker:      mw.Worker,
+									region:      mw.Region,
+									solo:        mw.Solo,
+									connectedAt: mw.ConnectedAt.AsTime(),
+								})
+							}
 						}
 
-						walletInfo := WalletInfo{
-							id:         userWalletWorkers.id,
-							wallet:     wallet,
-							blockchain: blockchain,
-						}
 						userChangedWorkers := &UserChangedWorkers{
-							added:   walletWorkersSet.Difference(userWalletWorkers.workers).Slice(),
-							removed: userWalletWorkers.workers.Difference(walletWorkersSet).Slice(),
+							added:   walletWorkersSet.Difference(trackedWallet.workers).Slice(),
+							removed: trackedWallet.workers.Difference(walletWorkersSet).Slice(),
 						}
+						if len(userChangedWorkers.added) == 0 && len(userChangedWorkers.removed) == 0 {
+							continue
+						}
+
+						changedWalletsMap[WalletInfo{
+							id:         trackedWallet.id,
+							wallet:     wallet,
+							blockchain: blockchain,
+						}] = userChangedWorkers
+
+						//	Wallet is requested from pool once, changes are sent to every subscriber
+						for _, subscriber := range trackedWallet.subscribers {
+							//	Workers of wallet with disabled notifications are still tracked to not notify about old changes later
+							if !subscriber.notify {
+								continue
+							}
+
+							walletInfo := WalletInfo{
+								id:             trackedWallet.id,
+								subscriptionID: subscriber.subscriptionID,
+								wallet:         wallet,
+								label:          subscriber.label,
+								blockchain:     blockchain,
+							}
+
+							changedUserWorkersMap, ok := changedWorkersMap[subscriber.userInfo]
+							if !ok {
+								changedUserWorkersMap = make(map[WalletInfo]*UserChangedWorkers)
+								changedWorkersMap[subscriber.userInfo] = changedUserWorkersMap
+							}
 
-						changedUserWorkersMap, ok := changedWorkersMap[*userWalletWorkers.userInfo]
-						if ok {
 							changedUserWorkersMap[walletInfo] = userChangedWorkers
-						} else {
-							changedWorkersMap[*userWalletWorkers.userInfo][walletInfo] = userChangedWorkers
 						}
 					}
 				}
 			}
-		default:
 		}
 	}
 
-	tx, err := w.pgConn.BeginTxx(ctx, nil)
-	if err != nil {
-		zap.L().Error("failed to create transaction to update workers in database", zap.Error(err))
-
-		return
-	}
-
-	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`CREATE TEMP TABLE %s (
-			wallet_id BIGINT NOT NULL,
-			worker TEXT NOT NULL,
-			PRIMARY KEY(wallet_id, worker)
-		)`, REMOWED_WORKERS_TEMP_TABLE_NAME)); err != nil {
-		tx.Rollback()
-
-		zap.L().Error("failed to create temp table for removed workers",
-			zap.String("temp_table_name", REMOWED_WORKERS_TEMP_TABLE_NAME),
-			zap.Error(err),
-		)
-
-		return
-	}
-
-	changedWorkersGroups := []*ChangedWorkersDB{}
-	defer func() {
-		changedWorkersGroups = nil
-	}()
-	groupNum := 0
-	i := 0
-	j := 0
-
-	for _, changedUserWorkersMap := range changedWorkersMap {
-		if groupNum > w.config.MaxUsersDBChangesLimit {
-			groupNum++
-			i = 0
-			j = 0
+	addedWorkers := []storage.WalletWorkerDB{}
+	removedWorkers := untrackedWorkers
+	for walletInfo, walletChangedWorkers := range changedWalletsMap {
+		for _, workerInfo := range walletChangedWorkers.added {
+			addedWorkers = append(addedWorkers, storage.WalletWorkerDB{
+				WalletID:    walletInfo.id,
+				Worker:      workerInfo.worker,
+				Region:      workerInfo.region,
+				Solo:        workerInfo.solo,
+				ConnectedAt: workerInfo.connectedAt,
+			})
 		}
 
-		for walletInfo, userChangedWorkers := range changedUserWorkersMap {
-			for _, workerInfo := range userChangedWorkers.added {
-				changedWorkersGroups[groupNum].added[i] = WorkerDB{
-					RemovalWorkerDB: RemovalWorkerDB{
-						WalletID: walletInfo.id,
-						Worker:   workerInfo.worker,
-					},
-					Region:      workerInfo.region,
-					Solo:        workerInfo.solo,
-					ConnectedAt: workerInfo.connectedAt,
-				}
-
-				i++
-			}
-
-			for _, workerInfo := range userChangedWorkers.removed {
-				changedWorkersGroups[groupNum].removed[j] = RemovalWorkerDB{
-					WalletID: walletInfo.id,
-					Worker:   workerInfo.worker,
-				}
-
-				j++
-			}
-		}
-	}
-
-	changedWorkersGroupsLen := len(changedWorkersGroups)
-	changeWorkersErrCh := make(chan error, 2*changedWorkersGroupsLen)
-	defer close(changeWorkersErrCh)
-	for groupNum, changedWorkers := range changedWorkersGroups {
-		go w.addWorkers(newCtx, tx, groupNum, changedWorkers.added, changeWorkersErrCh)
-		go w.removeWorkers(newCtx, tx, groupNum, changedWorkers.removed, changeWorkersErrCh)
-	}
-
-	for i := 0; i < changedWorkersGroupsLen; i++ {
-		select {
-		case <-ctx.Done():
-			tx.Rollback()
-
-			return
-		case err := <-changeWorkersErrCh:
-			tx.Rollback()
-
-			zap.L().Error("failed to change workers rows in database", zap.Error(err))
-
-			return
-		default:
+		for _, workerInfo := range walletChangedWorkers.removed {
+			removedWorkers = append(removedWorkers, storage.WalletWorkerDB{
+				WalletID: walletInfo.id,
+				Worker:   workerInfo.worker,
+			})
 		}
 	}
 
-	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM wallet_workers 
-			USING %s
-			WHERE wallet_workers.wallet_id = %s.wallet_id AND wallet_workers.worker = %s.worker`,
-		REMOWED_WORKERS_TEMP_TABLE_NAME,
-		REMOWED_WORKERS_TEMP_TABLE_NAME,
-		REMOWED_WORKERS_TEMP_TABLE_NAME,
-	)); err != nil {
-		tx.Rollback()
-
-		zap.L().Error("failed to delete removed workers rows from database", zap.Error(err))
-
-		return
-	}
-
-	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE %s", REMOWED_WORKERS_TEMP_TABLE_NAME)); err != nil {
-		tx.Rollback()
-
-		zap.L().Error("failed to drop temp table for removed workers",
-			zap.String("temp_table_name", REMOWED_WORKERS_TEMP_TABLE_NAME),
-			zap.Error(err),
-		)
-
-		return
-	}
-
-	if err := tx.Commit(); err != nil {
-		zap.L().Error("failed to commit workers changes in database", zap.Error(err))
-
-		return
+	if err := w.notify.UpdateWorkers(ctx, addedWorkers, removedWorkers, w.config.MaxUsersDBChangesLimit); err != nil {
+		return err
 	}
 
-	changedUsersWorkersGroups := [][]*ChangedUserWorkers{}
-	defer func() {
-		changedUsersWorkersGroups = nil
-	}()
-	groupNum = 0
-	i = 0
-
+	changedUsersWorkers := make([]*ChangedUserWorkers, 0, len(changedWorkersMap))
 	for userInfo, changedUserWorkersMap := range changedWorkersMap {
-		if groupNum > w.config.ParallelNotificationsCount {
-			groupNum++
-			i = 0
+		userInfo := userInfo
+		changedUserWorkers := &ChangedUserWorkers{
+			userInfo: &userInfo,
+			added:    []ChangedUserWorker{},
+			removed:  []ChangedUserWorker{},
 		}
 
 		for walletInfo, userChangedWorkers := range changedUserWorkersMap {
-			changedUserWorkers := &ChangedUserWorkers{
-				userInfo: &userInfo,
-				added:    make([]ChangedUserWorker, 0, len(userChangedWorkers.added)),
-				removed:  make([]ChangedUserWorker, 0, len(userChangedWorkers.removed)),
-			}
+			walletInfo := walletInfo
 
 			for _, workerInfo := range userChangedWorkers.added {
 				changedUserWorkers.added = append(changedUserWorkers.added, ChangedUserWorker{
@@ -576,17 +502,28 @@ func (w *Workers) Check(ctx context.Context) {
 					worker: workerInfo,
 				})
 			}
-
-			changedUsersWorkersGroups[groupNum][i] = changedUserWorkers
-			i++
 		}
+
+		changedUsersWorkers = append(changedUsersWorkers, changedUserWorkers)
+	}
+
+	chatTargetsMap, err := w.chatTargets.FindAllWalletTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	workerAliasesMap, err := w.wallets.FindAllAliases(ctx)
+	if err != nil {
+		return err
 	}
 
 	wg := sync.WaitGroup{}
-	for _, changedUsersWorkers := range changedUsersWorkersGroups {
+	for _, changedUsersWorkersGroup := range spread(changedUsersWorkers, w.config.ParallelNotificationsCount) {
 		wg.Add(1)
-		go w.notifyUsers(ctx, changedUsersWorkers, &wg)
+		go w.notifyUsers(ctx, changedUsersWorkersGroup, chatTargetsMap, workerAliasesMap, &wg)
 	}
 
 	wg.Wait()
+
+	return errors.Join(errs...)
 }
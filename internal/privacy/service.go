@@ -0,0 +1,128 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	botConfig "github.com/grandminingpool/telegram-bot/configs/bot"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	telegramUtils "github.com/grandminingpool/telegram-bot/internal/utils/telegram"
+	"go.uber.org/zap"
+)
+
+type DeletionReason string
+
+const (
+	DeletionUserRequest DeletionReason = "user_request"
+	DeletionInactive    DeletionReason = "inactive"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+type Service struct {
+	privacy storage.PrivacyRepository
+	config  *botConfig.PrivacyConfig
+	wg      sync.WaitGroup
+}
+
+func (s *Service) Export(ctx context.Context, userID int64) ([]byte, error) {
+	export, err := s.privacy.Export(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if export == nil {
+		return nil, ErrUserNotFound
+	}
+
+	export.ExportedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user (id: %d) data export: %w", userID, err)
+	}
+
+	return data, nil
+}
+
+func (s *Service) Delete(ctx context.Context, userID int64, reason DeletionReason) error {
+	counts, err := s.privacy.Delete(ctx, userID, string(reason))
+	if err != nil {
+		return err
+	} else if counts == nil {
+		return ErrUserNotFound
+	}
+
+	zap.L().Info("user data deleted",
+		zap.String("reason", string(reason)),
+		zap.Int("wallets_count", counts.Wallets),
+		zap.Int("chat_targets_count", counts.ChatTargets),
+		zap.Int("tickets_count", counts.Tickets),
+	)
+
+	return nil
+}
+
+func (s *Service) Deactivate(ctx context.Context, chatID int64, reason telegramUtils.SendErrorKind) error {
+	return s.privacy.Deactivate(ctx, chatID, string(reason))
+}
+
+func (s *Service) Reactivate(ctx context.Context, chatID int64) error {
+	return s.privacy.Reactivate(ctx, chatID)
+}
+
+func (s *Service) DeleteInactive(ctx context.Context) error {
+	ids, err := s.privacy.FindInactive(ctx, time.Now().AddDate(0, 0, -s.config.InactiveRetention))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.Delete(ctx, id, DeletionInactive); err != nil && !errors.Is(err, ErrUserNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) cleanup(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.CleanupIntervalDuration())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.DeleteInactive(ctx); err != nil && ctx.Err() == nil {
+				zap.L().Error("failed to delete inactive users data", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Service) Start(ctx context.Context) {
+	//	Inactive users are kept until retention is over to not lose data of users who unblocked bot
+	if s.config.InactiveRetention <= 0 {
+		return
+	}
+
+	s.wg.Add(1)
+	go s.cleanup(ctx)
+}
+
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+func NewService(privacy storage.PrivacyRepository, config *botConfig.PrivacyConfig) *Service {
+	return &Service{
+		privacy: privacy,
+		config:  config,
+	}
+}
@@ -2,20 +2,21 @@ package postgresProvider
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	postgresConfig "github.com/grandminingpool/telegram-bot/configs/postgres"
+	"github.com/grandminingpool/telegram-bot/internal/providers/sqlhooks"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
-func NewConnection(ctx context.Context, config *postgresConfig.Config) (*sqlx.DB, error) {
-	conn, err := sqlx.Connect("postgres", config.DSN())
-	if err != nil {
-		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
-	}
+func NewConnection(ctx context.Context, config *postgresConfig.Config, hooks ...sqlhooks.Hooks) (*sqlx.DB, error) {
+	conn := sqlx.NewDb(sql.OpenDB(sqlhooks.NewConnector(config.DSN(), &pq.Driver{}, hooks...)), "postgres")
+
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
 
-	if err = conn.PingContext(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ping postgres connection: %w", err)
 	}
 
@@ -0,0 +1,80 @@
+package postgresTest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// DSN_ENV is environment variable with connection string of postgres database for tests,
+// tests which need postgres are skipped when it is not set
+const DSN_ENV = "POSTGRES_TEST_DSN"
+
+var schemaCounter atomic.Int64
+
+func withSearchPath(dsn, schema string) (string, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		converted, err := pq.ParseURL(dsn)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse dsn url: %w", err)
+		}
+
+		dsn = converted
+	}
+
+	//	Unknown connection parameters are sent by pq as run-time parameters of session
+	return fmt.Sprintf("%s search_path=%s", dsn, schema), nil
+}
+
+// Connect returns connection to empty schema, created for test in database from DSN_ENV and dropped on cleanup
+func Connect(t testing.TB) *sqlx.DB {
+	t.Helper()
+
+	dsn, ok := os.LookupEnv(DSN_ENV)
+	if !ok || dsn == "" {
+		t.Skipf("%s is not set", DSN_ENV)
+	}
+
+	adminConn, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to create postgres connection: %v", err)
+	}
+
+	t.Cleanup(func() {
+		adminConn.Close()
+	})
+
+	schema := fmt.Sprintf("test_%d_%d", time.Now().UnixNano(), schemaCounter.Add(1))
+	if _, err := adminConn.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema (name: %s): %v", schema, err)
+	}
+
+	t.Cleanup(func() {
+		if _, err := adminConn.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Errorf("failed to drop schema (name: %s): %v", schema, err)
+		}
+	})
+
+	schemaDSN, err := withSearchPath(dsn, schema)
+	if err != nil {
+		t.Fatalf("failed to set search path: %v", err)
+	}
+
+	conn, err := sqlx.Connect("postgres", schemaDSN)
+	if err != nil {
+		t.Fatalf("failed to create postgres connection (schema: %s): %v", schema, err)
+	}
+
+	//	Registered after schema drop, so connection is closed before schema is dropped
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return conn
+}
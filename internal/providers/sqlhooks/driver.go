@@ -0,0 +1,200 @@
+package sqlhooks
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+type Hooks interface {
+	Before(ctx context.Context, query string) context.Context
+	After(ctx context.Context, query string, err error)
+}
+
+type hooksChain []Hooks
+
+func (h hooksChain) before(ctx context.Context, query string) context.Context {
+	for _, hook := range h {
+		ctx = hook.Before(ctx, query)
+	}
+
+	return ctx
+}
+
+func (h hooksChain) after(ctx context.Context, query string, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i].After(ctx, query, err)
+	}
+}
+
+type Driver struct {
+	driver.Driver
+	hooks hooksChain
+}
+
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conn{Conn: c, hooks: d.hooks}, nil
+}
+
+type Connector struct {
+	dsn    string
+	driver *Driver
+}
+
+func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *Connector) Driver() driver.Driver {
+	return c.driver
+}
+
+type conn struct {
+	driver.Conn
+	hooks hooksChain
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx = c.hooks.before(ctx, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	c.hooks.after(ctx, query, err)
+
+	return result, err
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx = c.hooks.before(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.hooks.after(ctx, query, err)
+
+	return rows, err
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		s   driver.Stmt
+		err error
+	)
+
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		s, err = preparer.PrepareContext(ctx, query)
+	} else {
+		s, err = c.Conn.Prepare(query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &stmt{Stmt: s, query: query, hooks: c.hooks}, nil
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin()
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+type stmt struct {
+	driver.Stmt
+	query string
+	hooks hooksChain
+}
+
+func namedValuesToValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	return values
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	var (
+		result driver.Result
+		err    error
+	)
+
+	ctx = s.hooks.before(ctx, s.query)
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		result, err = s.Stmt.Exec(namedValuesToValues(args))
+	}
+	s.hooks.after(ctx, s.query, err)
+
+	return result, err
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	var (
+		rows driver.Rows
+		err  error
+	)
+
+	ctx = s.hooks.before(ctx, s.query)
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		rows, err = s.Stmt.Query(namedValuesToValues(args))
+	}
+	s.hooks.after(ctx, s.query, err)
+
+	return rows, err
+}
+
+func NewConnector(dsn string, d driver.Driver, hooks ...Hooks) *Connector {
+	return &Connector{
+		dsn: dsn,
+		driver: &Driver{
+			Driver: d,
+			hooks:  hooks,
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package sqliteProvider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	postgresConfig "github.com/grandminingpool/telegram-bot/configs/postgres"
+	"github.com/grandminingpool/telegram-bot/internal/providers/sqlhooks"
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+)
+
+func NewConnection(ctx context.Context, config *postgresConfig.Config, hooks ...sqlhooks.Hooks) (*sqlx.DB, error) {
+	conn := sqlx.NewDb(sql.OpenDB(sqlhooks.NewConnector(config.SQLiteDSN(), &sqlite3.SQLiteDriver{}, hooks...)), "sqlite3")
+
+	//	SQLite allows only one writer, so single connection avoids busy errors between pool connections
+	conn.SetMaxOpenConns(1)
+
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to ping sqlite connection: %w", err)
+	}
+
+	return conn, nil
+}
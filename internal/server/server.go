@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	serverConfig "github.com/grandminingpool/telegram-bot/configs/server"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	mux        *http.ServeMux
+	httpServer *http.Server
+	config     *serverConfig.Config
+}
+
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) Start() {
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Error("http server error", zap.String("address", s.config.Address()), zap.Error(err))
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	shutdownCtx, cancel := context.WithTimeout(ctx, s.config.ShutdownTimeoutDuration())
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown http server: %w", err)
+	}
+
+	return nil
+}
+
+func NewServer(config *serverConfig.Config) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(config.MetricsPath, promhttp.Handler())
+
+	return &Server{
+		mux: mux,
+		httpServer: &http.Server{
+			Addr:              config.Address(),
+			Handler:           mux,
+			ReadHeaderTimeout: config.ReadTimeoutDuration(),
+			ReadTimeout:       config.ReadTimeoutDuration(),
+		},
+		config: config,
+	}
+}
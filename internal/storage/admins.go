@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type CoinCountDB struct {
+	Coin  string `db:"coin"`
+	Count int64  `db:"count"`
+}
+
+type AdminUserWalletDB struct {
+	Coin         string    `db:"blockchain_coin"`
+	Wallet       string    `db:"wallet"`
+	Role         string    `db:"role"`
+	AddedAt      time.Time `db:"added_at"`
+	WorkersCount int64     `db:"workers_count"`
+}
+
+type AdminRepository interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+	FindIDs(ctx context.Context) ([]int64, error)
+	CountUsers(ctx context.Context) (int64, error)
+	//	Counts are ordered by coin
+	CountWallets(ctx context.Context) ([]CoinCountDB, error)
+	CountWorkers(ctx context.Context) ([]CoinCountDB, error)
+	FindUserWallets(ctx context.Context, userID int64) ([]AdminUserWalletDB, error)
+}
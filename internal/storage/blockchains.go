@@ -0,0 +1,27 @@
+package storage
+
+import "context"
+
+type PoolAPIDB struct {
+	URL        string  `db:"pool_api_url"`
+	TLSCA      string  `db:"pool_api_tls_ca"`
+	TLSCert    *string `db:"pool_api_tls_cert"`
+	TLSKey     *string `db:"pool_api_tls_key"`
+	ServerName string  `db:"pool_api_server_name"`
+	Token      *string `db:"pool_api_token"`
+	Insecure   bool    `db:"pool_api_insecure"`
+}
+
+type BlockchainDB struct {
+	Coin             string `db:"coin"`
+	Name             string `db:"name"`
+	Ticker           string `db:"ticker"`
+	Decimals         uint8  `db:"decimals"`
+	DisplayPrecision *uint8 `db:"display_precision"`
+	ExampleWallet    string `db:"example_wallet"`
+	PoolAPIDB
+}
+
+type BlockchainRepository interface {
+	FindAll(ctx context.Context) ([]BlockchainDB, error)
+}
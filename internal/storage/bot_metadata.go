@@ -0,0 +1,14 @@
+package storage
+
+import "context"
+
+type BotMetadataDB struct {
+	Key  string `db:"key"`
+	Hash string `db:"hash"`
+}
+
+type BotMetadataRepository interface {
+	FindHashes(ctx context.Context) (map[string]string, error)
+	SaveHash(ctx context.Context, key, hash string) error
+	DeleteHash(ctx context.Context, key string) error
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+type BroadcastStatus string
+
+const (
+	BroadcastDraft     BroadcastStatus = "draft"
+	BroadcastSending   BroadcastStatus = "sending"
+	BroadcastSent      BroadcastStatus = "sent"
+	BroadcastCancelled BroadcastStatus = "cancelled"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	DeliveryFailed  DeliveryStatus = "failed"
+	DeliverySkipped DeliveryStatus = "skipped"
+)
+
+type BroadcastDB struct {
+	ID          int64           `db:"id"`
+	CreatedBy   int64           `db:"created_by"`
+	Status      BroadcastStatus `db:"status"`
+	DefaultLang *string         `db:"default_lang"`
+	CreatedAt   time.Time       `db:"created_at"`
+	StartedAt   *time.Time      `db:"started_at"`
+	FinishedAt  *time.Time      `db:"finished_at"`
+}
+
+type Broadcast struct {
+	BroadcastDB
+	Messages map[string]string
+	Coins    []string
+}
+
+func (b *Broadcast) Text(lang string) string {
+	if text, ok := b.Messages[lang]; ok {
+		return text
+	}
+
+	if base, _, ok := strings.Cut(lang, "-"); ok {
+		if text, ok := b.Messages[base]; ok {
+			return text
+		}
+	}
+
+	if b.DefaultLang != nil {
+		return b.Messages[*b.DefaultLang]
+	}
+
+	return ""
+}
+
+type DeliveryStats struct {
+	Pending int64 `db:"pending"`
+	Sent    int64 `db:"sent"`
+	Failed  int64 `db:"failed"`
+	Skipped int64 `db:"skipped"`
+}
+
+type DeliveryDB struct {
+	UserID           int64  `db:"user_id"`
+	ChatID           int64  `db:"chat_id"`
+	Lang             string `db:"lang"`
+	BroadcastsNotify bool   `db:"broadcasts_notify"`
+	Active           bool   `db:"active"`
+}
+
+type BroadcastRepository interface {
+	Find(ctx context.Context, id int64) (*Broadcast, error)
+	FindDraft(ctx context.Context, adminID int64) (*Broadcast, error)
+	FindLatest(ctx context.Context, adminID int64) (*Broadcast, error)
+	FindSendingIDs(ctx context.Context) ([]int64, error)
+	//	Previous draft of admin is cancelled, so admin has only one draft at a time
+	CreateDraft(ctx context.Context, adminID int64) (*Broadcast, error)
+	SetMessage(ctx context.Context, id int64, lang, text string) error
+	SetCoins(ctx context.Context, id int64, coins []string) error
+	Cancel(ctx context.Context, id int64) error
+	CountRecipients(ctx context.Context, id int64) (int64, error)
+	Stats(ctx context.Context, id int64) (*DeliveryStats, error)
+	//	Draft is switched to sending with pending delivery for each recipient, false is returned when it's not a draft
+	Start(ctx context.Context, id int64) (int64, bool, error)
+	FindPendingDeliveries(ctx context.Context, id int64, limit int) ([]DeliveryDB, error)
+	SetDeliveryStatus(ctx context.Context, id, userID int64, status DeliveryStatus, errText *string) error
+	Finish(ctx context.Context, id int64) error
+}
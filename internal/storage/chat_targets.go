@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type ChatTargetDB struct {
+	ID       int64     `db:"id"`
+	UserID   int64     `db:"user_id"`
+	ChatID   int64     `db:"chat_id"`
+	ThreadID int64     `db:"thread_id"`
+	ChatType string    `db:"chat_type"`
+	Title    string    `db:"title"`
+	AddedAt  time.Time `db:"added_at"`
+}
+
+type ChatTargetWalletDB struct {
+	ID      int64  `db:"id"`
+	Coin    string `db:"blockchain_coin"`
+	Wallet  string `db:"wallet"`
+	Label   string `db:"label"`
+	Enabled bool   `db:"enabled"`
+}
+
+type ChatTarget struct {
+	ChatID   int64 `db:"chat_id"`
+	ThreadID int64 `db:"thread_id"`
+}
+
+type ChatTargetRepository interface {
+	Link(ctx context.Context, target *ChatTargetDB) (*ChatTargetDB, error)
+	Find(ctx context.Context, userID, id int64) (*ChatTargetDB, error)
+	FindByUser(ctx context.Context, userID int64) ([]ChatTargetDB, error)
+	Unlink(ctx context.Context, userID, id int64) error
+	FindWallets(ctx context.Context, userID, targetID int64) ([]ChatTargetWalletDB, error)
+	SetWallet(ctx context.Context, targetID, walletID int64, enabled bool) error
+	//	Targets of all wallets by subscription id
+	FindAllWalletTargets(ctx context.Context) (map[int64][]ChatTarget, error)
+}
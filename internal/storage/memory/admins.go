@@ -0,0 +1,108 @@
+package memoryStorage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type AdminRepository struct {
+	db *DB
+}
+
+func (r *AdminRepository) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	_, ok := r.db.admins[userID]
+
+	return ok, nil
+}
+
+func (r *AdminRepository) FindIDs(ctx context.Context) ([]int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	ids := make([]int64, 0, len(r.db.admins))
+	for id := range r.db.admins {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (r *AdminRepository) CountUsers(ctx context.Context) (int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return int64(len(r.db.users)), nil
+}
+
+func coinCounts(counts map[string]int64) []storage.CoinCountDB {
+	coinCounts := make([]storage.CoinCountDB, 0, len(counts))
+	for coin, count := range counts {
+		coinCounts = append(coinCounts, storage.CoinCountDB{
+			Coin:  coin,
+			Count: count,
+		})
+	}
+
+	sort.Slice(coinCounts, func(i, j int) bool {
+		return coinCounts[i].Coin < coinCounts[j].Coin
+	})
+
+	return coinCounts
+}
+
+func (r *AdminRepository) CountWallets(ctx context.Context) ([]storage.CoinCountDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	for _, wallet := range r.db.wallets {
+		counts[wallet.coin]++
+	}
+
+	return coinCounts(counts), nil
+}
+
+func (r *AdminRepository) CountWorkers(ctx context.Context) ([]storage.CoinCountDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	for walletID, workers := range r.db.walletWorkers {
+		if wallet, ok := r.db.wallets[walletID]; ok && len(workers) > 0 {
+			counts[wallet.coin] += int64(len(workers))
+		}
+	}
+
+	return coinCounts(counts), nil
+}
+
+func (r *AdminRepository) FindUserWallets(ctx context.Context, userID int64) ([]storage.AdminUserWalletDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rows := r.db.userWalletRows(userID)
+	wallets := make([]storage.AdminUserWalletDB, 0, len(rows))
+	for _, row := range rows {
+		wallet := r.db.wallets[row.walletID]
+		wallets = append(wallets, storage.AdminUserWalletDB{
+			Coin:         wallet.coin,
+			Wallet:       wallet.wallet,
+			Role:         string(row.role),
+			AddedAt:      row.addedAt,
+			WorkersCount: int64(len(r.db.walletWorkers[row.walletID])),
+		})
+	}
+
+	return wallets, nil
+}
+
+func NewAdminRepository(db *DB) *AdminRepository {
+	return &AdminRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,34 @@
+package memoryStorage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type BlockchainRepository struct {
+	db *DB
+}
+
+func (r *BlockchainRepository) FindAll(ctx context.Context) ([]storage.BlockchainDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	blockchains := make([]storage.BlockchainDB, 0, len(r.db.blockchains))
+	for _, blockchain := range r.db.blockchains {
+		blockchains = append(blockchains, blockchain)
+	}
+
+	sort.Slice(blockchains, func(i, j int) bool {
+		return blockchains[i].Coin < blockchains[j].Coin
+	})
+
+	return blockchains, nil
+}
+
+func NewBlockchainRepository(db *DB) *BlockchainRepository {
+	return &BlockchainRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,41 @@
+package memoryStorage
+
+import (
+	"context"
+	"maps"
+)
+
+type BotMetadataRepository struct {
+	db *DB
+}
+
+func (r *BotMetadataRepository) FindHashes(ctx context.Context) (map[string]string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return maps.Clone(r.db.botMetadata), nil
+}
+
+func (r *BotMetadataRepository) SaveHash(ctx context.Context, key, hash string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.botMetadata[key] = hash
+
+	return nil
+}
+
+func (r *BotMetadataRepository) DeleteHash(ctx context.Context, key string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	delete(r.db.botMetadata, key)
+
+	return nil
+}
+
+func NewBotMetadataRepository(db *DB) *BotMetadataRepository {
+	return &BotMetadataRepository{
+		db: db,
+	}
+}
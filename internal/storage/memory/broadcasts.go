@@ -0,0 +1,299 @@
+package memoryStorage
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type BroadcastRepository struct {
+	db *DB
+}
+
+func copyBroadcast(broadcast *storage.Broadcast) *storage.Broadcast {
+	return &storage.Broadcast{
+		BroadcastDB: broadcast.BroadcastDB,
+		Messages:    maps.Clone(broadcast.Messages),
+		Coins:       slices.Clone(broadcast.Coins),
+	}
+}
+
+func (r *BroadcastRepository) findLatest(adminID int64, match func(status storage.BroadcastStatus) bool) *storage.Broadcast {
+	var latest *storage.Broadcast
+	for _, broadcast := range r.db.broadcasts {
+		if broadcast.CreatedBy != adminID || !match(broadcast.Status) {
+			continue
+		}
+
+		if latest == nil || addedBefore(latest.CreatedAt, latest.ID, broadcast.CreatedAt, broadcast.ID) {
+			latest = broadcast
+		}
+	}
+
+	if latest == nil {
+		return nil
+	}
+
+	return copyBroadcast(latest)
+}
+
+func (r *BroadcastRepository) Find(ctx context.Context, id int64) (*storage.Broadcast, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	broadcast, ok := r.db.broadcasts[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return copyBroadcast(broadcast), nil
+}
+
+func (r *BroadcastRepository) FindDraft(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.findLatest(adminID, func(status storage.BroadcastStatus) bool {
+		return status == storage.BroadcastDraft
+	}), nil
+}
+
+func (r *BroadcastRepository) FindLatest(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.findLatest(adminID, func(status storage.BroadcastStatus) bool {
+		return status != storage.BroadcastDraft
+	}), nil
+}
+
+func (r *BroadcastRepository) FindSendingIDs(ctx context.Context) ([]int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	ids := []int64{}
+	for id, broadcast := range r.db.broadcasts {
+		if broadcast.Status == storage.BroadcastSending {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+func (r *BroadcastRepository) CreateDraft(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, broadcast := range r.db.broadcasts {
+		if broadcast.CreatedBy == adminID && broadcast.Status == storage.BroadcastDraft {
+			broadcast.Status = storage.BroadcastCancelled
+		}
+	}
+
+	broadcast := &storage.Broadcast{
+		BroadcastDB: storage.BroadcastDB{
+			ID:        r.db.nextID(),
+			CreatedBy: adminID,
+			Status:    storage.BroadcastDraft,
+			CreatedAt: time.Now(),
+		},
+		Messages: make(map[string]string),
+		Coins:    []string{},
+	}
+	r.db.broadcasts[broadcast.ID] = broadcast
+
+	return copyBroadcast(broadcast), nil
+}
+
+func (r *BroadcastRepository) SetMessage(ctx context.Context, id int64, lang, text string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	broadcast, ok := r.db.broadcasts[id]
+	if !ok {
+		return nil
+	}
+
+	broadcast.Messages[lang] = text
+	if broadcast.DefaultLang == nil {
+		broadcast.DefaultLang = &lang
+	}
+
+	return nil
+}
+
+func (r *BroadcastRepository) SetCoins(ctx context.Context, id int64, coins []string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	broadcast, ok := r.db.broadcasts[id]
+	if !ok {
+		return nil
+	}
+
+	broadcast.Coins = append([]string{}, coins...)
+	slices.Sort(broadcast.Coins)
+	broadcast.Coins = slices.Compact(broadcast.Coins)
+
+	return nil
+}
+
+func (r *BroadcastRepository) Cancel(ctx context.Context, id int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if broadcast, ok := r.db.broadcasts[id]; ok && broadcast.Status == storage.BroadcastDraft {
+		broadcast.Status = storage.BroadcastCancelled
+	}
+
+	return nil
+}
+
+// Recipients are users who didn't opt out from broadcasts and,
+// when broadcast targets specific coins, have wallets on them
+func (r *BroadcastRepository) recipients(id int64) []int64 {
+	broadcast, ok := r.db.broadcasts[id]
+	if !ok {
+		return []int64{}
+	}
+
+	recipients := []int64{}
+	for _, user := range r.db.users {
+		if !user.BroadcastsNotify || !user.Active {
+			continue
+		}
+
+		if len(broadcast.Coins) > 0 && !slices.ContainsFunc(r.db.userWalletRows(user.ID), func(row *userWalletRow) bool {
+			return slices.Contains(broadcast.Coins, r.db.wallets[row.walletID].coin)
+		}) {
+			continue
+		}
+
+		recipients = append(recipients, user.ID)
+	}
+
+	return recipients
+}
+
+func (r *BroadcastRepository) CountRecipients(ctx context.Context, id int64) (int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return int64(len(r.recipients(id))), nil
+}
+
+func (r *BroadcastRepository) Stats(ctx context.Context, id int64) (*storage.DeliveryStats, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	stats := &storage.DeliveryStats{}
+	for _, delivery := range r.db.deliveries[id] {
+		switch delivery.status {
+		case storage.DeliveryPending:
+			stats.Pending++
+		case storage.DeliverySent:
+			stats.Sent++
+		case storage.DeliveryFailed:
+			stats.Failed++
+		case storage.DeliverySkipped:
+			stats.Skipped++
+		}
+	}
+
+	return stats, nil
+}
+
+func (r *BroadcastRepository) Start(ctx context.Context, id int64) (int64, bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	broadcast, ok := r.db.broadcasts[id]
+	if !ok || broadcast.Status != storage.BroadcastDraft {
+		return 0, false, nil
+	}
+
+	now := time.Now()
+	broadcast.Status = storage.BroadcastSending
+	broadcast.StartedAt = &now
+
+	recipients := r.recipients(id)
+	deliveries := make(map[int64]*deliveryRow, len(recipients))
+	for _, userID := range recipients {
+		deliveries[userID] = &deliveryRow{
+			userID:    userID,
+			status:    storage.DeliveryPending,
+			updatedAt: now,
+		}
+	}
+	r.db.deliveries[id] = deliveries
+
+	return int64(len(recipients)), true, nil
+}
+
+func (r *BroadcastRepository) FindPendingDeliveries(ctx context.Context, id int64, limit int) ([]storage.DeliveryDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	deliveries := []storage.DeliveryDB{}
+	for userID, delivery := range r.db.deliveries[id] {
+		user, ok := r.db.users[userID]
+		if !ok || delivery.status != storage.DeliveryPending {
+			continue
+		}
+
+		deliveries = append(deliveries, storage.DeliveryDB{
+			UserID:           user.ID,
+			ChatID:           user.ChatID,
+			Lang:             user.Lang,
+			BroadcastsNotify: user.BroadcastsNotify,
+			Active:           user.Active,
+		})
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].UserID < deliveries[j].UserID
+	})
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+func (r *BroadcastRepository) SetDeliveryStatus(ctx context.Context, id, userID int64, status storage.DeliveryStatus, errText *string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if delivery, ok := r.db.deliveries[id][userID]; ok {
+		delivery.status = status
+		delivery.err = errText
+		delivery.updatedAt = time.Now()
+	}
+
+	return nil
+}
+
+func (r *BroadcastRepository) Finish(ctx context.Context, id int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if broadcast, ok := r.db.broadcasts[id]; ok {
+		now := time.Now()
+		broadcast.Status = storage.BroadcastSent
+		broadcast.FinishedAt = &now
+	}
+
+	return nil
+}
+
+func NewBroadcastRepository(db *DB) *BroadcastRepository {
+	return &BroadcastRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,156 @@
+package memoryStorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type ChatTargetRepository struct {
+	db *DB
+}
+
+func (r *ChatTargetRepository) Link(ctx context.Context, target *storage.ChatTargetDB) (*storage.ChatTargetDB, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, linkedTarget := range r.db.chatTargets {
+		if linkedTarget.UserID == target.UserID && linkedTarget.ChatID == target.ChatID && linkedTarget.ThreadID == target.ThreadID {
+			linkedTarget.ChatType = target.ChatType
+			linkedTarget.Title = target.Title
+			targetCopy := *linkedTarget
+
+			return &targetCopy, nil
+		}
+	}
+
+	linkedTarget := &storage.ChatTargetDB{
+		ID:       r.db.nextID(),
+		UserID:   target.UserID,
+		ChatID:   target.ChatID,
+		ThreadID: target.ThreadID,
+		ChatType: target.ChatType,
+		Title:    target.Title,
+		AddedAt:  time.Now(),
+	}
+	r.db.chatTargets[linkedTarget.ID] = linkedTarget
+	targetCopy := *linkedTarget
+
+	return &targetCopy, nil
+}
+
+func (r *ChatTargetRepository) Find(ctx context.Context, userID, id int64) (*storage.ChatTargetDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	target, ok := r.db.chatTargets[id]
+	if !ok || target.UserID != userID {
+		return nil, nil
+	}
+
+	targetCopy := *target
+
+	return &targetCopy, nil
+}
+
+func (r *ChatTargetRepository) FindByUser(ctx context.Context, userID int64) ([]storage.ChatTargetDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	targets := []storage.ChatTargetDB{}
+	for _, target := range r.db.chatTargets {
+		if target.UserID == userID {
+			targets = append(targets, *target)
+		}
+	}
+
+	sortRows(targets, func(a, b storage.ChatTargetDB) bool {
+		return addedBefore(a.AddedAt, a.ID, b.AddedAt, b.ID)
+	})
+
+	return targets, nil
+}
+
+func (r *ChatTargetRepository) Unlink(ctx context.Context, userID, id int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if target, ok := r.db.chatTargets[id]; ok && target.UserID == userID {
+		r.db.deleteChatTarget(id)
+	}
+
+	return nil
+}
+
+func (r *ChatTargetRepository) FindWallets(ctx context.Context, userID, targetID int64) ([]storage.ChatTargetWalletDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rows := r.db.userWalletRows(userID)
+	wallets := make([]storage.ChatTargetWalletDB, 0, len(rows))
+	for _, row := range rows {
+		wallet := r.db.wallets[row.walletID]
+		_, enabled := r.db.walletChatTargets[row.id][targetID]
+		wallets = append(wallets, storage.ChatTargetWalletDB{
+			ID:      row.id,
+			Coin:    wallet.coin,
+			Wallet:  wallet.wallet,
+			Label:   row.label,
+			Enabled: enabled,
+		})
+	}
+
+	return wallets, nil
+}
+
+func (r *ChatTargetRepository) SetWallet(ctx context.Context, targetID, walletID int64, enabled bool) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if !enabled {
+		delete(r.db.walletChatTargets[walletID], targetID)
+
+		return nil
+	}
+
+	_, walletOk := r.db.userWallets[walletID]
+	_, targetOk := r.db.chatTargets[targetID]
+	if !walletOk || !targetOk {
+		return fmt.Errorf("failed to set chat target (id: %d) wallet (id: %d) to %t: %w", targetID, walletID, enabled, errNotFound)
+	}
+
+	if _, ok := r.db.walletChatTargets[walletID]; !ok {
+		r.db.walletChatTargets[walletID] = make(map[int64]struct{})
+	}
+
+	r.db.walletChatTargets[walletID][targetID] = struct{}{}
+
+	return nil
+}
+
+func (r *ChatTargetRepository) FindAllWalletTargets(ctx context.Context) (map[int64][]storage.ChatTarget, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	targetsMap := make(map[int64][]storage.ChatTarget)
+	for subscriptionID, targetIDs := range r.db.walletChatTargets {
+		for targetID := range targetIDs {
+			if target, ok := r.db.chatTargets[targetID]; ok {
+				targetsMap[subscriptionID] = append(targetsMap[subscriptionID], storage.ChatTarget{
+					ChatID:   target.ChatID,
+					ThreadID: target.ThreadID,
+				})
+			}
+		}
+	}
+
+	return targetsMap, nil
+}
+
+func NewChatTargetRepository(db *DB) *ChatTargetRepository {
+	return &ChatTargetRepository{
+		db: db,
+	}
+}
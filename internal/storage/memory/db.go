@@ -0,0 +1,267 @@
+package memoryStorage
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+var (
+	errNotFound        = errors.New("row not found")
+	errUniqueViolation = errors.New("duplicate key value violates unique constraint")
+)
+
+type walletRow struct {
+	id     int64
+	coin   string
+	wallet string
+}
+
+type userWalletRow struct {
+	id       int64
+	userID   int64
+	walletID int64
+	label    string
+	role     storage.WalletRole
+	notify   bool
+	paused   bool
+	addedAt  time.Time
+}
+
+type walletInviteRow struct {
+	token     string
+	walletID  int64
+	createdBy int64
+	role      storage.WalletRole
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+type supportMessageRow struct {
+	id       int64
+	ticketID int64
+	storage.SupportMessage
+	createdAt time.Time
+}
+
+type deliveryRow struct {
+	userID    int64
+	status    storage.DeliveryStatus
+	err       *string
+	updatedAt time.Time
+}
+
+type deletionRow struct {
+	reason    string
+	counts    storage.DeletionCounts
+	deletedAt time.Time
+}
+
+// DB keeps all tables in process memory, it's used to run bot without database
+// and in tests, so data is lost on restart
+type DB struct {
+	mu                sync.RWMutex
+	sequence          int64
+	blockchains       map[string]storage.BlockchainDB
+	admins            map[int64]time.Time
+	users             map[int64]*storage.UserDB
+	userActions       map[int64]storage.UserActionDB
+	wallets           map[int64]*walletRow
+	userWallets       map[int64]*userWalletRow
+	walletWorkers     map[int64]map[string]storage.WalletWorkerDB
+	workerAliases     map[int64]map[string]string
+	walletInvites     map[string]*walletInviteRow
+	chatTargets       map[int64]*storage.ChatTargetDB
+	walletChatTargets map[int64]map[int64]struct{}
+	supportTickets    map[int64]*storage.SupportTicketDB
+	supportMessages   map[int64]*supportMessageRow
+	botMetadata       map[string]string
+	broadcasts        map[int64]*storage.Broadcast
+	deliveries        map[int64]map[int64]*deliveryRow
+	notifyPauses      map[string]storage.NotifyPauseDB
+	payoutsChecks     []time.Time
+	deletions         []deletionRow
+}
+
+func (db *DB) nextID() int64 {
+	db.sequence++
+
+	return db.sequence
+}
+
+func (db *DB) AddBlockchain(blockchain storage.BlockchainDB) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.blockchains[blockchain.Coin] = blockchain
+}
+
+func (db *DB) AddAdmin(userID int64) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.admins[userID]; !ok {
+		db.admins[userID] = time.Now()
+	}
+}
+
+func (db *DB) Storage() *storage.Storage {
+	return &storage.Storage{
+		Users:         NewUserRepository(db),
+		UserActions:   NewUserActionRepository(db),
+		Wallets:       NewWalletRepository(db),
+		WalletInvites: NewWalletInviteRepository(db),
+		ChatTargets:   NewChatTargetRepository(db),
+		Support:       NewSupportRepository(db),
+		Admins:        NewAdminRepository(db),
+		BotMetadata:   NewBotMetadataRepository(db),
+		Privacy:       NewPrivacyRepository(db),
+		Broadcasts:    NewBroadcastRepository(db),
+		Blockchains:   NewBlockchainRepository(db),
+		Notify:        NewNotifyRepository(db),
+	}
+}
+
+func (db *DB) findWallet(coin, wallet string) *walletRow {
+	for _, row := range db.wallets {
+		if row.coin == coin && row.wallet == wallet {
+			return row
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) upsertWallet(coin, wallet string) int64 {
+	//	Same wallet is stored once and shared between all subscribed users
+	if row := db.findWallet(coin, wallet); row != nil {
+		return row.id
+	}
+
+	row := &walletRow{
+		id:     db.nextID(),
+		coin:   coin,
+		wallet: wallet,
+	}
+	db.wallets[row.id] = row
+
+	return row.id
+}
+
+func (db *DB) findUserWallet(userID, walletID int64) *userWalletRow {
+	for _, row := range db.userWallets {
+		if row.userID == userID && row.walletID == walletID {
+			return row
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) insertUserWallet(row *userWalletRow) error {
+	if db.findUserWallet(row.userID, row.walletID) != nil {
+		return errUniqueViolation
+	}
+
+	row.id = db.nextID()
+	row.addedAt = time.Now()
+	db.userWallets[row.id] = row
+
+	return nil
+}
+
+func (db *DB) deleteUnusedWallet(walletID int64) {
+	//	Wallet without subscribers is not tracked anymore
+	for _, row := range db.userWallets {
+		if row.walletID == walletID {
+			return
+		}
+	}
+
+	delete(db.wallets, walletID)
+	delete(db.walletWorkers, walletID)
+	for token, invite := range db.walletInvites {
+		if invite.walletID == walletID {
+			delete(db.walletInvites, token)
+		}
+	}
+}
+
+func (db *DB) deleteUserWallet(id int64) (int64, bool) {
+	row, ok := db.userWallets[id]
+	if !ok {
+		return 0, false
+	}
+
+	delete(db.userWallets, id)
+	delete(db.workerAliases, id)
+	delete(db.walletChatTargets, id)
+
+	return row.walletID, true
+}
+
+func (db *DB) deleteChatTarget(id int64) {
+	delete(db.chatTargets, id)
+	for _, targets := range db.walletChatTargets {
+		delete(targets, id)
+	}
+}
+
+func (db *DB) userWallet(row *userWalletRow) storage.UserWalletDB {
+	wallet := db.wallets[row.walletID]
+
+	return storage.UserWalletDB{
+		ID:      row.id,
+		Coin:    wallet.coin,
+		Wallet:  wallet.wallet,
+		Label:   row.label,
+		Role:    row.role,
+		Notify:  row.notify,
+		Paused:  row.paused,
+		AddedAt: row.addedAt,
+	}
+}
+
+// Wallets are ordered by coin and time they were added like in postgres queries
+func (db *DB) userWalletRows(userID int64) []*userWalletRow {
+	rows := []*userWalletRow{}
+	for _, row := range db.userWallets {
+		if row.userID == userID {
+			rows = append(rows, row)
+		}
+	}
+
+	sortRows(rows, func(a, b *userWalletRow) bool {
+		if coinA, coinB := db.wallets[a.walletID].coin, db.wallets[b.walletID].coin; coinA != coinB {
+			return coinA < coinB
+		}
+
+		return addedBefore(a.addedAt, a.id, b.addedAt, b.id)
+	})
+
+	return rows
+}
+
+func New() *DB {
+	return &DB{
+		blockchains:       make(map[string]storage.BlockchainDB),
+		admins:            make(map[int64]time.Time),
+		users:             make(map[int64]*storage.UserDB),
+		userActions:       make(map[int64]storage.UserActionDB),
+		wallets:           make(map[int64]*walletRow),
+		userWallets:       make(map[int64]*userWalletRow),
+		walletWorkers:     make(map[int64]map[string]storage.WalletWorkerDB),
+		workerAliases:     make(map[int64]map[string]string),
+		walletInvites:     make(map[string]*walletInviteRow),
+		chatTargets:       make(map[int64]*storage.ChatTargetDB),
+		walletChatTargets: make(map[int64]map[int64]struct{}),
+		supportTickets:    make(map[int64]*storage.SupportTicketDB),
+		supportMessages:   make(map[int64]*supportMessageRow),
+		botMetadata:       make(map[string]string),
+		broadcasts:        make(map[int64]*storage.Broadcast),
+		deliveries:        make(map[int64]map[int64]*deliveryRow),
+		notifyPauses:      make(map[string]storage.NotifyPauseDB),
+	}
+}
@@ -0,0 +1,153 @@
+package memoryStorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type NotifyRepository struct {
+	db *DB
+}
+
+func (r *NotifyRepository) FindPauses(ctx context.Context) ([]storage.NotifyPauseDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	pauses := make([]storage.NotifyPauseDB, 0, len(r.db.notifyPauses))
+	for _, pause := range r.db.notifyPauses {
+		pauses = append(pauses, pause)
+	}
+
+	return pauses, nil
+}
+
+func (r *NotifyRepository) AddPause(ctx context.Context, pause *storage.NotifyPauseDB) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.notifyPauses[pause.Scope]; !ok {
+		r.db.notifyPauses[pause.Scope] = *pause
+	}
+
+	return nil
+}
+
+func (r *NotifyRepository) DeletePause(ctx context.Context, scope string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	delete(r.db.notifyPauses, scope)
+
+	return nil
+}
+
+func (r *NotifyRepository) FindLastPayoutsCheck(ctx context.Context) (*time.Time, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var last *time.Time
+	for i, executedAt := range r.db.payoutsChecks {
+		if last == nil || executedAt.After(*last) {
+			last = &r.db.payoutsChecks[i]
+		}
+	}
+
+	if last == nil {
+		return nil, nil
+	}
+
+	lastCopy := *last
+
+	return &lastCopy, nil
+}
+
+func (r *NotifyRepository) AddPayoutsCheck(ctx context.Context, executedAt time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.payoutsChecks = append(r.db.payoutsChecks, executedAt)
+
+	return nil
+}
+
+func (r *NotifyRepository) FindSubscriptions(ctx context.Context) ([]storage.WalletSubscriptionDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	subscriptions := []storage.WalletSubscriptionDB{}
+	for _, row := range r.db.userWallets {
+		user, ok := r.db.users[row.userID]
+		if !ok || !user.Active || row.paused {
+			continue
+		}
+
+		wallet := r.db.wallets[row.walletID]
+		subscriptions = append(subscriptions, storage.WalletSubscriptionDB{
+			WalletID:       wallet.id,
+			Coin:           wallet.coin,
+			Wallet:         wallet.wallet,
+			SubscriptionID: row.id,
+			Label:          row.label,
+			Notify:         row.notify,
+			UserID:         user.ID,
+			ChatID:         user.ChatID,
+			Lang:           user.Lang,
+			ShortWallets:   user.ShortWallets,
+			PayoutsNotify:  user.PayoutsNotify,
+			BlocksNotify:   user.BlocksNotify,
+		})
+	}
+
+	return subscriptions, nil
+}
+
+func (r *NotifyRepository) FindWorkers(ctx context.Context) ([]storage.WalletWorkerDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	workers := []storage.WalletWorkerDB{}
+	for _, walletWorkers := range r.db.walletWorkers {
+		for _, worker := range walletWorkers {
+			workers = append(workers, worker)
+		}
+	}
+
+	return workers, nil
+}
+
+func (r *NotifyRepository) UpdateWorkers(ctx context.Context, added, removed []storage.WalletWorkerDB, batchSize int) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	//	Changes are checked before applying to keep them atomic like in database transaction
+	for _, worker := range added {
+		if _, ok := r.db.wallets[worker.WalletID]; !ok {
+			return fmt.Errorf("failed to insert added worker (wallet id: %d, worker: %s), error: %w", worker.WalletID, worker.Worker, errNotFound)
+		} else if _, ok := r.db.walletWorkers[worker.WalletID][worker.Worker]; ok {
+			return fmt.Errorf("failed to insert added worker (wallet id: %d, worker: %s), error: %w", worker.WalletID, worker.Worker, errUniqueViolation)
+		}
+	}
+
+	for _, worker := range added {
+		if _, ok := r.db.walletWorkers[worker.WalletID]; !ok {
+			r.db.walletWorkers[worker.WalletID] = make(map[string]storage.WalletWorkerDB)
+		}
+
+		r.db.walletWorkers[worker.WalletID][worker.Worker] = worker
+	}
+
+	for _, worker := range removed {
+		delete(r.db.walletWorkers[worker.WalletID], worker.Worker)
+	}
+
+	return nil
+}
+
+func NewNotifyRepository(db *DB) *NotifyRepository {
+	return &NotifyRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,354 @@
+package memoryStorage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type PrivacyRepository struct {
+	db *DB
+}
+
+func (r *PrivacyRepository) exportWallets(userID int64) []storage.ExportWallet {
+	rows := []*userWalletRow{}
+	for _, row := range r.db.userWallets {
+		if row.userID == userID {
+			rows = append(rows, row)
+		}
+	}
+
+	sortRows(rows, func(a, b *userWalletRow) bool {
+		return addedBefore(a.addedAt, a.id, b.addedAt, b.id)
+	})
+
+	wallets := make([]storage.ExportWallet, 0, len(rows))
+	for _, row := range rows {
+		wallet := storage.ExportWallet{
+			ID:      row.id,
+			Coin:    r.db.wallets[row.walletID].coin,
+			Wallet:  r.db.wallets[row.walletID].wallet,
+			Role:    string(row.role),
+			Notify:  row.notify,
+			Paused:  row.paused,
+			AddedAt: row.addedAt,
+			Workers: []storage.ExportWorker{},
+		}
+		if row.label != "" {
+			label := row.label
+			wallet.Label = &label
+		}
+
+		for _, worker := range r.db.walletWorkers[row.walletID] {
+			exportWorker := storage.ExportWorker{
+				SubscriptionID: row.id,
+				Worker:         worker.Worker,
+				Region:         worker.Region,
+				Solo:           worker.Solo,
+				ConnectedAt:    worker.ConnectedAt,
+			}
+			if alias, ok := r.db.workerAliases[row.id][worker.Worker]; ok {
+				exportWorker.Alias = &alias
+			}
+
+			wallet.Workers = append(wallet.Workers, exportWorker)
+		}
+
+		sort.Slice(wallet.Workers, func(i, j int) bool {
+			return wallet.Workers[i].Worker < wallet.Workers[j].Worker
+		})
+
+		wallets = append(wallets, wallet)
+	}
+
+	return wallets
+}
+
+func (r *PrivacyRepository) exportChatTargets(userID int64) []storage.ExportChatTarget {
+	targets := []storage.ExportChatTarget{}
+	for _, target := range r.db.chatTargets {
+		if target.UserID != userID {
+			continue
+		}
+
+		walletIDs := []int64{}
+		for walletID, targetIDs := range r.db.walletChatTargets {
+			if _, ok := targetIDs[target.ID]; ok {
+				walletIDs = append(walletIDs, walletID)
+			}
+		}
+
+		sort.Slice(walletIDs, func(i, j int) bool {
+			return walletIDs[i] < walletIDs[j]
+		})
+
+		targets = append(targets, storage.ExportChatTarget{
+			ID:        target.ID,
+			ChatID:    target.ChatID,
+			ThreadID:  target.ThreadID,
+			ChatType:  target.ChatType,
+			Title:     target.Title,
+			AddedAt:   target.AddedAt,
+			WalletIDs: walletIDs,
+		})
+	}
+
+	sortRows(targets, func(a, b storage.ExportChatTarget) bool {
+		return addedBefore(a.AddedAt, a.ID, b.AddedAt, b.ID)
+	})
+
+	return targets
+}
+
+func (r *PrivacyRepository) exportTickets(userID int64) []storage.ExportTicket {
+	tickets := []storage.ExportTicket{}
+	for _, ticket := range r.db.supportTickets {
+		if ticket.UserID != userID {
+			continue
+		}
+
+		messages := []*supportMessageRow{}
+		for _, message := range r.db.supportMessages {
+			if message.ticketID == ticket.ID {
+				messages = append(messages, message)
+			}
+		}
+
+		sortRows(messages, func(a, b *supportMessageRow) bool {
+			return addedBefore(a.createdAt, a.id, b.createdAt, b.id)
+		})
+
+		//	Support agents ids are not personal data of user, so they are not exported
+		exportTicket := storage.ExportTicket{
+			ID:        ticket.ID,
+			Status:    string(ticket.Status),
+			CreatedAt: ticket.CreatedAt,
+			UpdatedAt: ticket.UpdatedAt,
+			ClosedAt:  ticket.ClosedAt,
+			Messages:  make([]storage.ExportTicketMessage, 0, len(messages)),
+		}
+		for _, message := range messages {
+			exportTicket.Messages = append(exportTicket.Messages, storage.ExportTicketMessage{
+				TicketID:       ticket.ID,
+				Direction:      string(message.Direction),
+				Text:           message.Text,
+				AttachmentType: message.AttachmentType,
+				CreatedAt:      message.createdAt,
+			})
+		}
+
+		tickets = append(tickets, exportTicket)
+	}
+
+	sortRows(tickets, func(a, b storage.ExportTicket) bool {
+		return addedBefore(a.CreatedAt, a.ID, b.CreatedAt, b.ID)
+	})
+
+	return tickets
+}
+
+func (r *PrivacyRepository) exportInvites(userID int64) []storage.ExportInvite {
+	invites := []storage.ExportInvite{}
+	for _, invite := range r.db.walletInvites {
+		if invite.createdBy != userID {
+			continue
+		}
+
+		wallet, ok := r.db.wallets[invite.walletID]
+		if !ok {
+			continue
+		}
+
+		//	Invite tokens are secrets, so only invites metadata is exported
+		invites = append(invites, storage.ExportInvite{
+			Coin:      wallet.coin,
+			Wallet:    wallet.wallet,
+			Role:      string(invite.role),
+			CreatedAt: invite.createdAt,
+			ExpiresAt: invite.expiresAt,
+		})
+	}
+
+	sortRows(invites, func(a, b storage.ExportInvite) bool {
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+
+	return invites
+}
+
+func (r *PrivacyRepository) exportDeliveries(userID int64) []storage.ExportDelivery {
+	deliveries := []storage.ExportDelivery{}
+	for broadcastID, broadcastDeliveries := range r.db.deliveries {
+		if delivery, ok := broadcastDeliveries[userID]; ok {
+			deliveries = append(deliveries, storage.ExportDelivery{
+				BroadcastID: broadcastID,
+				Status:      string(delivery.status),
+				UpdatedAt:   delivery.updatedAt,
+			})
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].BroadcastID < deliveries[j].BroadcastID
+	})
+
+	return deliveries
+}
+
+func (r *PrivacyRepository) Export(ctx context.Context, userID int64) (*storage.Export, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	user, ok := r.db.users[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	_, isAdmin := r.db.admins[userID]
+	export := &storage.Export{
+		User: storage.ExportUser{
+			ID:               user.ID,
+			ChatID:           user.ChatID,
+			Lang:             user.Lang,
+			Username:         user.Username,
+			PayoutsNotify:    user.PayoutsNotify,
+			BlocksNotify:     user.BlocksNotify,
+			BroadcastsNotify: user.BroadcastsNotify,
+			ShortWallets:     user.ShortWallets,
+			Active:           user.Active,
+			InactiveSince:    user.InactiveSince,
+			Admin:            isAdmin,
+		},
+		Wallets:     r.exportWallets(userID),
+		ChatTargets: r.exportChatTargets(userID),
+		Tickets:     r.exportTickets(userID),
+		Invites:     r.exportInvites(userID),
+		Deliveries:  r.exportDeliveries(userID),
+	}
+
+	if action, ok := r.db.userActions[userID]; ok {
+		export.Action = &storage.ExportAction{
+			Action:  string(action.Action),
+			Payload: action.Payload,
+		}
+	}
+
+	return export, nil
+}
+
+func (r *PrivacyRepository) Delete(ctx context.Context, userID int64, reason string) (*storage.DeletionCounts, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.users[userID]; !ok {
+		return nil, nil
+	}
+
+	counts := &storage.DeletionCounts{}
+	for id, row := range r.db.userWallets {
+		if row.userID == userID {
+			r.db.deleteUserWallet(id)
+			//	Shared wallets are kept while they have other subscribers
+			r.db.deleteUnusedWallet(row.walletID)
+			counts.Wallets++
+		}
+	}
+
+	for id, target := range r.db.chatTargets {
+		if target.UserID == userID {
+			r.db.deleteChatTarget(id)
+			counts.ChatTargets++
+		}
+	}
+
+	for id, ticket := range r.db.supportTickets {
+		if ticket.UserID != userID {
+			continue
+		}
+
+		delete(r.db.supportTickets, id)
+		for messageID, message := range r.db.supportMessages {
+			if message.ticketID == id {
+				delete(r.db.supportMessages, messageID)
+			}
+		}
+
+		counts.Tickets++
+	}
+
+	for token, invite := range r.db.walletInvites {
+		if invite.createdBy == userID {
+			delete(r.db.walletInvites, token)
+		}
+	}
+
+	for _, broadcastDeliveries := range r.db.deliveries {
+		delete(broadcastDeliveries, userID)
+	}
+
+	delete(r.db.userActions, userID)
+	delete(r.db.admins, userID)
+	delete(r.db.users, userID)
+
+	//	Audit log keeps only reason and amounts of deleted data to not retain personal data
+	r.db.deletions = append(r.db.deletions, deletionRow{
+		reason:    reason,
+		counts:    *counts,
+		deletedAt: time.Now(),
+	})
+
+	return counts, nil
+}
+
+func (r *PrivacyRepository) Deactivate(ctx context.Context, chatID int64, reason string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, user := range r.db.users {
+		if user.ChatID == chatID && user.Active {
+			now := time.Now()
+			user.Active = false
+			user.InactiveReason = &reason
+			user.InactiveSince = &now
+		}
+	}
+
+	return nil
+}
+
+func (r *PrivacyRepository) Reactivate(ctx context.Context, chatID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, user := range r.db.users {
+		if user.ChatID == chatID && !user.Active {
+			user.Active = true
+			user.InactiveReason = nil
+			user.InactiveSince = nil
+		}
+	}
+
+	return nil
+}
+
+func (r *PrivacyRepository) FindInactive(ctx context.Context, before time.Time) ([]int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	ids := []int64{}
+	for _, user := range r.db.users {
+		if !user.Active && user.InactiveSince != nil && user.InactiveSince.Before(before) {
+			ids = append(ids, user.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func NewPrivacyRepository(db *DB) *PrivacyRepository {
+	return &PrivacyRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,21 @@
+package memoryStorage
+
+import (
+	"sort"
+	"time"
+)
+
+func sortRows[T any](rows []T, less func(a, b T) bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return less(rows[i], rows[j])
+	})
+}
+
+// Rows added at the same time keep insertion order, ids are taken from one sequence
+func addedBefore(a time.Time, aID int64, b time.Time, bID int64) bool {
+	if !a.Equal(b) {
+		return a.Before(b)
+	}
+
+	return aID < bID
+}
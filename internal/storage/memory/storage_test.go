@@ -0,0 +1,13 @@
+package memoryStorage
+
+import (
+	"testing"
+
+	storageTest "github.com/grandminingpool/telegram-bot/internal/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storageTest.Run(t, func(t *testing.T) storageTest.DB {
+		return New()
+	})
+}
@@ -0,0 +1,199 @@
+package memoryStorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type SupportRepository struct {
+	db *DB
+}
+
+func (r *SupportRepository) addMessage(ticketID int64, message *storage.SupportMessage, createdAt time.Time) int64 {
+	row := &supportMessageRow{
+		id:             r.db.nextID(),
+		ticketID:       ticketID,
+		SupportMessage: *message,
+		createdAt:      createdAt,
+	}
+	r.db.supportMessages[row.id] = row
+
+	return row.id
+}
+
+func (r *SupportRepository) Create(ctx context.Context, userID int64, message *storage.SupportMessage) (*storage.SupportTicketDB, int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.users[userID]; !ok {
+		return nil, 0, fmt.Errorf("failed to create user (id: %d) support ticket: %w", userID, errNotFound)
+	}
+
+	now := time.Now()
+	ticket := &storage.SupportTicketDB{
+		ID:        r.db.nextID(),
+		UserID:    userID,
+		Status:    storage.SupportTicketOpen,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.db.supportTickets[ticket.ID] = ticket
+	messageID := r.addMessage(ticket.ID, message, now)
+	ticketCopy := *ticket
+
+	return &ticketCopy, messageID, nil
+}
+
+func (r *SupportRepository) AddMessage(
+	ctx context.Context,
+	ticketID int64,
+	message *storage.SupportMessage,
+	status storage.SupportTicketStatus,
+) (int64, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	ticket, ok := r.db.supportTickets[ticketID]
+	if !ok {
+		return 0, fmt.Errorf("failed to add support ticket (id: %d) message: %w", ticketID, errNotFound)
+	}
+
+	now := time.Now()
+	messageID := r.addMessage(ticketID, message, now)
+	ticket.Status = status
+	ticket.UpdatedAt = now
+	ticket.ClosedAt = nil
+
+	return messageID, nil
+}
+
+func (r *SupportRepository) SetTicketSupportMessageID(ctx context.Context, ticketID, supportMessageID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if ticket, ok := r.db.supportTickets[ticketID]; ok {
+		ticket.SupportMessageID = &supportMessageID
+	}
+
+	return nil
+}
+
+func (r *SupportRepository) SetUserMessageID(ctx context.Context, messageID, userMessageID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if message, ok := r.db.supportMessages[messageID]; ok {
+		message.UserMessageID = &userMessageID
+	}
+
+	return nil
+}
+
+func (r *SupportRepository) SetSupportMessageID(ctx context.Context, messageID, supportMessageID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if message, ok := r.db.supportMessages[messageID]; ok {
+		message.SupportMessageID = &supportMessageID
+	}
+
+	return nil
+}
+
+func (r *SupportRepository) findTicket(match func(ticket *storage.SupportTicketDB) bool) *storage.SupportTicketDB {
+	var found *storage.SupportTicketDB
+	for _, ticket := range r.db.supportTickets {
+		if match(ticket) && (found == nil || ticket.ID < found.ID) {
+			found = ticket
+		}
+	}
+
+	if found == nil {
+		return nil
+	}
+
+	ticketCopy := *found
+
+	return &ticketCopy
+}
+
+func (r *SupportRepository) hasMessage(ticketID int64, match func(message *supportMessageRow) bool) bool {
+	for _, message := range r.db.supportMessages {
+		if message.ticketID == ticketID && match(message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *SupportRepository) FindBySupportMessage(ctx context.Context, supportMessageID int64) (*storage.SupportTicketDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.findTicket(func(ticket *storage.SupportTicketDB) bool {
+		if ticket.SupportMessageID != nil && *ticket.SupportMessageID == supportMessageID {
+			return true
+		}
+
+		return r.hasMessage(ticket.ID, func(message *supportMessageRow) bool {
+			return message.SupportMessageID != nil && *message.SupportMessageID == supportMessageID
+		})
+	}), nil
+}
+
+func (r *SupportRepository) FindByUserMessage(ctx context.Context, userID, userMessageID int64) (*storage.SupportTicketDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return r.findTicket(func(ticket *storage.SupportTicketDB) bool {
+		return ticket.UserID == userID && r.hasMessage(ticket.ID, func(message *supportMessageRow) bool {
+			return message.UserMessageID != nil && *message.UserMessageID == userMessageID
+		})
+	}), nil
+}
+
+func (r *SupportRepository) FindFirstUserMessageID(ctx context.Context, ticketID int64) (*int64, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var first *supportMessageRow
+	for _, message := range r.db.supportMessages {
+		if message.ticketID != ticketID || message.Direction != storage.SupportMessageFromUser {
+			continue
+		}
+
+		if first == nil || addedBefore(message.createdAt, message.id, first.createdAt, first.id) {
+			first = message
+		}
+	}
+
+	if first == nil {
+		return nil, nil
+	}
+
+	return first.UserMessageID, nil
+}
+
+func (r *SupportRepository) Close(ctx context.Context, ticketID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if ticket, ok := r.db.supportTickets[ticketID]; ok {
+		now := time.Now()
+		ticket.Status = storage.SupportTicketClosed
+		ticket.UpdatedAt = now
+		ticket.ClosedAt = &now
+	}
+
+	return nil
+}
+
+func NewSupportRepository(db *DB) *SupportRepository {
+	return &SupportRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,51 @@
+package memoryStorage
+
+import (
+	"context"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type UserActionRepository struct {
+	db *DB
+}
+
+func (r *UserActionRepository) Set(ctx context.Context, userID int64, action storage.UserAction, payload *string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	r.db.userActions[userID] = storage.UserActionDB{
+		UserID:  userID,
+		Action:  action,
+		Payload: payload,
+	}
+
+	return nil
+}
+
+func (r *UserActionRepository) Get(ctx context.Context, userID int64) (*storage.UserActionDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	userAction, ok := r.db.userActions[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &userAction, nil
+}
+
+func (r *UserActionRepository) Clear(ctx context.Context, userID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	delete(r.db.userActions, userID)
+
+	return nil
+}
+
+func NewUserActionRepository(db *DB) *UserActionRepository {
+	return &UserActionRepository{
+		db: db,
+	}
+}
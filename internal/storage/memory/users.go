@@ -0,0 +1,160 @@
+package memoryStorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type UserRepository struct {
+	db *DB
+}
+
+func (r *UserRepository) Find(ctx context.Context, id int64) (*storage.UserDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	user, ok := r.db.users[id]
+	if !ok {
+		return nil, nil
+	}
+
+	userCopy := *user
+
+	return &userCopy, nil
+}
+
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*storage.UserDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, user := range r.db.users {
+		if user.Username != nil && strings.EqualFold(*user.Username, username) {
+			userCopy := *user
+
+			return &userCopy, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *storage.UserDB) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.users[user.ID]; ok {
+		return fmt.Errorf("failed to create user: %w", errUniqueViolation)
+	}
+
+	for _, existingUser := range r.db.users {
+		if existingUser.ChatID == user.ChatID {
+			return fmt.Errorf("failed to create user: %w", errUniqueViolation)
+		}
+	}
+
+	r.db.users[user.ID] = &storage.UserDB{
+		ID:               user.ID,
+		ChatID:           user.ChatID,
+		Lang:             user.Lang,
+		PayoutsNotify:    user.PayoutsNotify,
+		BlocksNotify:     user.BlocksNotify,
+		Username:         user.Username,
+		BroadcastsNotify: true,
+		Active:           true,
+	}
+
+	return nil
+}
+
+func (r *UserRepository) update(id int64, update func(user *storage.UserDB)) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if user, ok := r.db.users[id]; ok {
+		update(user)
+	}
+}
+
+func (r *UserRepository) SetChatID(ctx context.Context, id, chatID int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, user := range r.db.users {
+		if user.ID != id && user.ChatID == chatID {
+			return fmt.Errorf("failed to update user (id: %d) chat id (new value: %d), error: %w", id, chatID, errUniqueViolation)
+		}
+	}
+
+	if user, ok := r.db.users[id]; ok {
+		user.ChatID = chatID
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetUsername(ctx context.Context, id int64, username *string) error {
+	r.update(id, func(user *storage.UserDB) {
+		user.Username = username
+	})
+
+	return nil
+}
+
+func (r *UserRepository) SetLang(ctx context.Context, id int64, lang string) error {
+	r.update(id, func(user *storage.UserDB) {
+		user.Lang = lang
+	})
+
+	return nil
+}
+
+func (r *UserRepository) SetPayoutsNotify(ctx context.Context, id int64, value bool) error {
+	r.update(id, func(user *storage.UserDB) {
+		user.PayoutsNotify = value
+	})
+
+	return nil
+}
+
+func (r *UserRepository) SetBlocksNotify(ctx context.Context, id int64, value bool) error {
+	r.update(id, func(user *storage.UserDB) {
+		user.BlocksNotify = value
+	})
+
+	return nil
+}
+
+func (r *UserRepository) SetBroadcastsNotify(ctx context.Context, id int64, value bool) error {
+	r.update(id, func(user *storage.UserDB) {
+		user.BroadcastsNotify = value
+	})
+
+	return nil
+}
+
+func (r *UserRepository) SetShortWallets(ctx context.Context, id int64, value bool) error {
+	r.update(id, func(user *storage.UserDB) {
+		user.ShortWallets = value
+	})
+
+	return nil
+}
+
+func (r *UserRepository) Reactivate(ctx context.Context, id int64) error {
+	r.update(id, func(user *storage.UserDB) {
+		user.Active = true
+		user.InactiveReason = nil
+		user.InactiveSince = nil
+	})
+
+	return nil
+}
+
+func NewUserRepository(db *DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
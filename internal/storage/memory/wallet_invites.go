@@ -0,0 +1,109 @@
+package memoryStorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type WalletInviteRepository struct {
+	db *DB
+}
+
+func (r *WalletInviteRepository) Create(
+	ctx context.Context,
+	token string,
+	userID, subscriptionID int64,
+	role storage.WalletRole,
+	expiresAt time.Time,
+) (bool, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	row, ok := r.db.userWallets[subscriptionID]
+	if !ok || row.userID != userID || row.role != storage.WalletOwnerRole {
+		return false, nil
+	}
+
+	if _, ok := r.db.walletInvites[token]; ok {
+		return false, fmt.Errorf("failed to create user (id: %d) wallet (id: %d) invite: %w", userID, subscriptionID, errUniqueViolation)
+	}
+
+	r.db.walletInvites[token] = &walletInviteRow{
+		token:     token,
+		walletID:  row.walletID,
+		createdBy: userID,
+		role:      role,
+		createdAt: time.Now(),
+		expiresAt: expiresAt,
+	}
+
+	return true, nil
+}
+
+func (r *WalletInviteRepository) Find(ctx context.Context, token string) (*storage.WalletInviteDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	invite, ok := r.db.walletInvites[token]
+	if !ok || !invite.expiresAt.After(time.Now()) {
+		return nil, nil
+	}
+
+	wallet, walletOk := r.db.wallets[invite.walletID]
+	creator, creatorOk := r.db.users[invite.createdBy]
+	if !walletOk || !creatorOk {
+		return nil, nil
+	}
+
+	return &storage.WalletInviteDB{
+		Token:         invite.token,
+		WalletID:      invite.walletID,
+		Role:          invite.role,
+		Coin:          wallet.coin,
+		Wallet:        wallet.wallet,
+		CreatedBy:     invite.createdBy,
+		CreatorChatID: creator.ChatID,
+		CreatorLang:   creator.Lang,
+		ExpiresAt:     invite.expiresAt,
+	}, nil
+}
+
+func (r *WalletInviteRepository) Accept(ctx context.Context, userID int64, invite *storage.WalletInviteDB) (storage.WalletInviteAcceptStatus, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.wallets[invite.WalletID]; !ok {
+		return "", fmt.Errorf("failed to accept user (id: %d) wallet (id: %d) invite: %w", userID, invite.WalletID, errNotFound)
+	}
+
+	if r.db.findUserWallet(userID, invite.WalletID) != nil {
+		return storage.WalletInviteAlreadyAdded, nil
+	}
+
+	row, ok := r.db.walletInvites[invite.Token]
+	if !ok || !row.expiresAt.After(time.Now()) {
+		return storage.WalletInviteUsed, nil
+	}
+
+	if err := r.db.insertUserWallet(&userWalletRow{
+		userID:   userID,
+		walletID: invite.WalletID,
+		role:     invite.Role,
+		notify:   true,
+	}); err != nil {
+		return "", fmt.Errorf("failed to accept user (id: %d) wallet (id: %d) invite: %w", userID, invite.WalletID, err)
+	}
+
+	delete(r.db.walletInvites, invite.Token)
+
+	return storage.WalletInviteAccepted, nil
+}
+
+func NewWalletInviteRepository(db *DB) *WalletInviteRepository {
+	return &WalletInviteRepository{
+		db: db,
+	}
+}
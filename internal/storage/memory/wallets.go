@@ -0,0 +1,237 @@
+package memoryStorage
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+type WalletRepository struct {
+	db *DB
+}
+
+func (r *WalletRepository) Find(ctx context.Context, userID, id int64) (*storage.UserWalletDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	row, ok := r.db.userWallets[id]
+	if !ok || row.userID != userID {
+		return nil, nil
+	}
+
+	wallet := r.db.userWallet(row)
+
+	return &wallet, nil
+}
+
+func (r *WalletRepository) FindAll(ctx context.Context, userID int64) ([]storage.UserWalletDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	rows := r.db.userWalletRows(userID)
+	wallets := make([]storage.UserWalletDB, 0, len(rows))
+	for _, row := range rows {
+		wallets = append(wallets, r.db.userWallet(row))
+	}
+
+	return wallets, nil
+}
+
+func (r *WalletRepository) Count(ctx context.Context, userID int64, coin string) (int, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	count := 0
+	for _, row := range r.db.userWallets {
+		if row.userID == userID && r.db.wallets[row.walletID].coin == coin {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *WalletRepository) Exists(ctx context.Context, userID int64, coin, wallet string) (bool, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	walletRow := r.db.findWallet(coin, wallet)
+
+	return walletRow != nil && r.db.findUserWallet(userID, walletRow.id) != nil, nil
+}
+
+func (r *WalletRepository) Add(ctx context.Context, userID int64, coin, wallet, label string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	walletID := r.db.upsertWallet(coin, wallet)
+	if err := r.db.insertUserWallet(&userWalletRow{
+		userID:   userID,
+		walletID: walletID,
+		label:    label,
+		role:     storage.WalletOwnerRole,
+		notify:   true,
+	}); err != nil {
+		return fmt.Errorf("failed to add user wallet (id: %d, coin: %s,  wallet: %s), error: %w", userID, coin, wallet, err)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) Remove(ctx context.Context, id int64) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	walletID, ok := r.db.deleteUserWallet(id)
+	if !ok {
+		return fmt.Errorf("failed to remove user wallet (id: %d), error: %w", id, errNotFound)
+	}
+
+	r.db.deleteUnusedWallet(walletID)
+
+	return nil
+}
+
+func (r *WalletRepository) Restore(ctx context.Context, userID int64, wallet *storage.UserWalletDB, aliases map[string]string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	//	Wallet could be added again before undo, then nothing is restored
+	walletID := r.db.upsertWallet(wallet.Coin, wallet.Wallet)
+	if r.db.findUserWallet(userID, walletID) != nil {
+		return nil
+	}
+
+	row := &userWalletRow{
+		userID:   userID,
+		walletID: walletID,
+		label:    wallet.Label,
+		role:     wallet.Role,
+		notify:   wallet.Notify,
+		paused:   wallet.Paused,
+	}
+	if err := r.db.insertUserWallet(row); err != nil {
+		return fmt.Errorf("failed to restore user wallet (id: %d, coin: %s, wallet: %s), error: %w", userID, wallet.Coin, wallet.Wallet, err)
+	}
+
+	if len(aliases) > 0 {
+		r.db.workerAliases[row.id] = maps.Clone(aliases)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) update(userID, id int64, update func(row *userWalletRow)) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if row, ok := r.db.userWallets[id]; ok && row.userID == userID {
+		update(row)
+	}
+}
+
+func (r *WalletRepository) SetLabel(ctx context.Context, userID, id int64, label string) error {
+	r.update(userID, id, func(row *userWalletRow) {
+		row.label = label
+	})
+
+	return nil
+}
+
+func (r *WalletRepository) SetNotify(ctx context.Context, userID, id int64, notify bool) error {
+	r.update(userID, id, func(row *userWalletRow) {
+		row.notify = notify
+	})
+
+	return nil
+}
+
+func (r *WalletRepository) SetPaused(ctx context.Context, userID, id int64, paused bool) error {
+	r.update(userID, id, func(row *userWalletRow) {
+		row.paused = paused
+	})
+
+	return nil
+}
+
+func (r *WalletRepository) FindAliases(ctx context.Context, userID int64) (map[int64]map[string]string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	aliasesMap := make(map[int64]map[string]string)
+	for subscriptionID, aliases := range r.db.workerAliases {
+		if row, ok := r.db.userWallets[subscriptionID]; ok && row.userID == userID {
+			aliasesMap[subscriptionID] = maps.Clone(aliases)
+		}
+	}
+
+	return aliasesMap, nil
+}
+
+func (r *WalletRepository) FindAllAliases(ctx context.Context) (map[int64]map[string]string, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	aliasesMap := make(map[int64]map[string]string, len(r.db.workerAliases))
+	for subscriptionID, aliases := range r.db.workerAliases {
+		aliasesMap[subscriptionID] = maps.Clone(aliases)
+	}
+
+	return aliasesMap, nil
+}
+
+func (r *WalletRepository) FindWorkerAliases(ctx context.Context, userID, id int64) ([]storage.WorkerAliasDB, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	aliases := []storage.WorkerAliasDB{}
+	row, ok := r.db.userWallets[id]
+	if !ok || row.userID != userID {
+		return aliases, nil
+	}
+
+	for worker := range r.db.walletWorkers[row.walletID] {
+		aliases = append(aliases, storage.WorkerAliasDB{
+			Worker: worker,
+			Alias:  r.db.workerAliases[id][worker],
+		})
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Worker < aliases[j].Worker
+	})
+
+	return aliases, nil
+}
+
+func (r *WalletRepository) SetWorkerAlias(ctx context.Context, userID, id int64, worker, alias string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if row, ok := r.db.userWallets[id]; !ok || row.userID != userID {
+		return nil
+	}
+
+	if alias == "" {
+		delete(r.db.workerAliases[id], worker)
+
+		return nil
+	}
+
+	if _, ok := r.db.workerAliases[id]; !ok {
+		r.db.workerAliases[id] = make(map[string]string)
+	}
+
+	r.db.workerAliases[id][worker] = alias
+
+	return nil
+}
+
+func NewWalletRepository(db *DB) *WalletRepository {
+	return &WalletRepository{
+		db: db,
+	}
+}
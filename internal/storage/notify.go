@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type NotifyPauseDB struct {
+	Scope    string    `db:"scope"`
+	PausedBy int64     `db:"paused_by"`
+	PausedAt time.Time `db:"paused_at"`
+}
+
+type WalletSubscriptionDB struct {
+	WalletID       int64  `db:"wallet_id"`
+	Coin           string `db:"blockchain_coin"`
+	Wallet         string `db:"wallet"`
+	SubscriptionID int64  `db:"subscription_id"`
+	Label          string `db:"label"`
+	Notify         bool   `db:"notify"`
+	UserID         int64  `db:"user_id"`
+	ChatID         int64  `db:"chat_id"`
+	Lang           string `db:"lang"`
+	ShortWallets   bool   `db:"short_wallets"`
+	PayoutsNotify  bool   `db:"payouts_notify"`
+	BlocksNotify   bool   `db:"blocks_notify"`
+}
+
+type WalletWorkerDB struct {
+	WalletID    int64     `db:"wallet_id"`
+	Worker      string    `db:"worker"`
+	Region      string    `db:"region"`
+	Solo        bool      `db:"solo"`
+	ConnectedAt time.Time `db:"connected_at"`
+}
+
+type NotifyRepository interface {
+	FindPauses(ctx context.Context) ([]NotifyPauseDB, error)
+	AddPause(ctx context.Context, pause *NotifyPauseDB) error
+	DeletePause(ctx context.Context, scope string) error
+	FindLastPayoutsCheck(ctx context.Context) (*time.Time, error)
+	AddPayoutsCheck(ctx context.Context, executedAt time.Time) error
+	//	Subscriptions of active users which are not paused
+	FindSubscriptions(ctx context.Context) ([]WalletSubscriptionDB, error)
+	FindWorkers(ctx context.Context) ([]WalletWorkerDB, error)
+	//	Changes are applied in one transaction by batches of limited size
+	UpdateWorkers(ctx context.Context, added, removed []WalletWorkerDB, batchSize int) error
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type ExportUser struct {
+	ID               int64      `db:"id" json:"id"`
+	ChatID           int64      `db:"chat_id" json:"chatId"`
+	Lang             string     `db:"lang" json:"lang"`
+	Username         *string    `db:"username" json:"username,omitempty"`
+	PayoutsNotify    bool       `db:"payouts_notify" json:"payoutsNotify"`
+	BlocksNotify     bool       `db:"blocks_notify" json:"blocksNotify"`
+	BroadcastsNotify bool       `db:"broadcasts_notify" json:"broadcastsNotify"`
+	ShortWallets     bool       `db:"short_wallets" json:"shortWallets"`
+	Active           bool       `db:"active" json:"active"`
+	InactiveSince    *time.Time `db:"inactive_since" json:"inactiveSince,omitempty"`
+	Admin            bool       `db:"-" json:"admin"`
+}
+
+type ExportAction struct {
+	Action  string  `db:"action" json:"action"`
+	Payload *string `db:"payload" json:"payload,omitempty"`
+}
+
+type ExportWorker struct {
+	SubscriptionID int64     `db:"subscription_id" json:"-"`
+	Worker         string    `db:"worker" json:"worker"`
+	Alias          *string   `db:"alias" json:"alias,omitempty"`
+	Region         string    `db:"region" json:"region"`
+	Solo           bool      `db:"solo" json:"solo"`
+	ConnectedAt    time.Time `db:"connected_at" json:"connectedAt"`
+}
+
+type ExportWallet struct {
+	ID      int64          `db:"id" json:"id"`
+	Coin    string         `db:"blockchain_coin" json:"coin"`
+	Wallet  string         `db:"wallet" json:"wallet"`
+	Label   *string        `db:"label" json:"label,omitempty"`
+	Role    string         `db:"role" json:"role"`
+	Notify  bool           `db:"notify" json:"notify"`
+	Paused  bool           `db:"paused" json:"paused"`
+	AddedAt time.Time      `db:"added_at" json:"addedAt"`
+	Workers []ExportWorker `db:"-" json:"workers"`
+}
+
+type ExportChatTarget struct {
+	ID        int64     `db:"id" json:"id"`
+	ChatID    int64     `db:"chat_id" json:"chatId"`
+	ThreadID  int64     `db:"thread_id" json:"threadId,omitempty"`
+	ChatType  string    `db:"chat_type" json:"chatType"`
+	Title     string    `db:"title" json:"title"`
+	AddedAt   time.Time `db:"added_at" json:"addedAt"`
+	WalletIDs []int64   `db:"-" json:"walletIds"`
+}
+
+type ExportTicketMessage struct {
+	TicketID       int64     `db:"ticket_id" json:"-"`
+	Direction      string    `db:"direction" json:"direction"`
+	Text           string    `db:"text" json:"text"`
+	AttachmentType *string   `db:"attachment_type" json:"attachmentType,omitempty"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+}
+
+type ExportTicket struct {
+	ID        int64                 `db:"id" json:"id"`
+	Status    string                `db:"status" json:"status"`
+	CreatedAt time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time             `db:"updated_at" json:"updatedAt"`
+	ClosedAt  *time.Time            `db:"closed_at" json:"closedAt,omitempty"`
+	Messages  []ExportTicketMessage `db:"-" json:"messages"`
+}
+
+type ExportInvite struct {
+	Coin      string    `db:"blockchain_coin" json:"coin"`
+	Wallet    string    `db:"wallet" json:"wallet"`
+	Role      string    `db:"role" json:"role"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	ExpiresAt time.Time `db:"expires_at" json:"expiresAt"`
+}
+
+type ExportDelivery struct {
+	BroadcastID int64     `db:"broadcast_id" json:"broadcastId"`
+	Status      string    `db:"status" json:"status"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type Export struct {
+	ExportedAt  time.Time          `json:"exportedAt"`
+	User        ExportUser         `json:"user"`
+	Action      *ExportAction      `json:"action,omitempty"`
+	Wallets     []ExportWallet     `json:"wallets"`
+	ChatTargets []ExportChatTarget `json:"chatTargets"`
+	Tickets     []ExportTicket     `json:"supportTickets"`
+	Invites     []ExportInvite     `json:"walletInvites"`
+	Deliveries  []ExportDelivery   `json:"broadcastDeliveries"`
+}
+
+type DeletionCounts struct {
+	Wallets     int `db:"-"`
+	ChatTargets int `db:"chat_targets_count"`
+	Tickets     int `db:"tickets_count"`
+}
+
+type PrivacyRepository interface {
+	//	Nil export is returned when user is not found
+	Export(ctx context.Context, userID int64) (*Export, error)
+	//	Nil counts are returned when user is not found
+	Delete(ctx context.Context, userID int64, reason string) (*DeletionCounts, error)
+	Deactivate(ctx context.Context, chatID int64, reason string) error
+	Reactivate(ctx context.Context, chatID int64) error
+	FindInactive(ctx context.Context, before time.Time) ([]int64, error)
+}
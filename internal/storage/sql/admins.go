@@ -0,0 +1,95 @@
+package sqlStorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type AdminRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *AdminRepository) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	var exists bool
+	if err := r.conn.GetContext(ctx, &exists, r.conn.Rebind("SELECT EXISTS(SELECT 1 FROM admins WHERE user_id = ?)"), userID); err != nil {
+		return false, fmt.Errorf("failed to check user (id: %d) admin role: %w", userID, err)
+	}
+
+	return exists, nil
+}
+
+func (r *AdminRepository) FindIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	if err := r.conn.SelectContext(ctx, &ids, "SELECT user_id FROM admins"); err != nil {
+		return nil, fmt.Errorf("failed to query admins: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *AdminRepository) CountUsers(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.conn.GetContext(ctx, &count, "SELECT COUNT(*) FROM users"); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *AdminRepository) CountWallets(ctx context.Context) ([]storage.CoinCountDB, error) {
+	counts := []storage.CoinCountDB{}
+	if err := r.conn.SelectContext(ctx, &counts, `SELECT 
+		blockchain_coin AS coin, 
+		COUNT(*) AS count 
+	FROM wallets 
+	GROUP BY blockchain_coin 
+	ORDER BY blockchain_coin`); err != nil {
+		return nil, fmt.Errorf("failed to count wallets per blockchain: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *AdminRepository) CountWorkers(ctx context.Context) ([]storage.CoinCountDB, error) {
+	counts := []storage.CoinCountDB{}
+	if err := r.conn.SelectContext(ctx, &counts, `SELECT 
+		wallets.blockchain_coin AS coin, 
+		COUNT(*) AS count 
+	FROM wallet_workers 
+	INNER JOIN wallets ON wallets.id = wallet_workers.wallet_id 
+	GROUP BY wallets.blockchain_coin 
+	ORDER BY wallets.blockchain_coin`); err != nil {
+		return nil, fmt.Errorf("failed to count active workers per blockchain: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *AdminRepository) FindUserWallets(ctx context.Context, userID int64) ([]storage.AdminUserWalletDB, error) {
+	wallets := []storage.AdminUserWalletDB{}
+	if err := r.conn.SelectContext(ctx, &wallets, r.conn.Rebind(`SELECT 
+		wallets.blockchain_coin, 
+		wallets.wallet, 
+		user_wallets.role, 
+		user_wallets.added_at, 
+		COUNT(wallet_workers.worker) AS workers_count 
+	FROM user_wallets 
+	INNER JOIN wallets ON wallets.id = user_wallets.wallet_id 
+	LEFT JOIN wallet_workers ON wallet_workers.wallet_id = user_wallets.wallet_id 
+	WHERE user_wallets.user_id = ? 
+	GROUP BY user_wallets.id, wallets.id 
+	ORDER BY wallets.blockchain_coin, user_wallets.added_at, user_wallets.id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) wallets: %w", userID, err)
+	}
+
+	return wallets, nil
+}
+
+func NewAdminRepository(conn *sqlx.DB) *AdminRepository {
+	return &AdminRepository{
+		conn: conn,
+	}
+}
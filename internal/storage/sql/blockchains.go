@@ -0,0 +1,29 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type BlockchainRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *BlockchainRepository) FindAll(ctx context.Context) ([]storage.BlockchainDB, error) {
+	blockchains := []storage.BlockchainDB{}
+	if err := r.conn.SelectContext(ctx, &blockchains, "SELECT * FROM blockchains"); err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to query blockchains: %w", err)
+	}
+
+	return blockchains, nil
+}
+
+func NewBlockchainRepository(conn *sqlx.DB) *BlockchainRepository {
+	return &BlockchainRepository{
+		conn: conn,
+	}
+}
@@ -0,0 +1,50 @@
+package sqlStorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type BotMetadataRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *BotMetadataRepository) FindHashes(ctx context.Context) (map[string]string, error) {
+	items := []storage.BotMetadataDB{}
+	if err := r.conn.SelectContext(ctx, &items, "SELECT key, hash FROM bot_metadata"); err != nil {
+		return nil, fmt.Errorf("failed to query bot metadata hashes: %w", err)
+	}
+
+	hashes := make(map[string]string, len(items))
+	for _, item := range items {
+		hashes[item.Key] = item.Hash
+	}
+
+	return hashes, nil
+}
+
+func (r *BotMetadataRepository) SaveHash(ctx context.Context, key, hash string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`INSERT INTO bot_metadata (key, hash) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET hash = EXCLUDED.hash, synced_at = CURRENT_TIMESTAMP`), key, hash); err != nil {
+		return fmt.Errorf("failed to save bot metadata (key: %s) hash: %w", key, err)
+	}
+
+	return nil
+}
+
+func (r *BotMetadataRepository) DeleteHash(ctx context.Context, key string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("DELETE FROM bot_metadata WHERE key = ?"), key); err != nil {
+		return fmt.Errorf("failed to delete bot metadata (key: %s) hash: %w", key, err)
+	}
+
+	return nil
+}
+
+func NewBotMetadataRepository(conn *sqlx.DB) *BotMetadataRepository {
+	return &BotMetadataRepository{
+		conn: conn,
+	}
+}
@@ -0,0 +1,303 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Recipients are users who didn't opt out from broadcasts and,
+// when broadcast targets specific coins, have wallets on them, both placeholders are broadcast id
+const recipientsQuery = `FROM users
+	WHERE users.broadcasts_notify = true AND users.active = true AND (
+		NOT EXISTS (SELECT 1 FROM broadcast_coins WHERE broadcast_coins.broadcast_id = ?)
+		OR EXISTS (
+			SELECT 1 FROM user_wallets
+			INNER JOIN wallets ON wallets.id = user_wallets.wallet_id
+			INNER JOIN broadcast_coins ON broadcast_coins.blockchain_coin = wallets.blockchain_coin
+			WHERE broadcast_coins.broadcast_id = ? AND user_wallets.user_id = users.id
+		)
+	)`
+
+type BroadcastRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *BroadcastRepository) findBroadcast(ctx context.Context, query string, args ...any) (*storage.Broadcast, error) {
+	var broadcastDB storage.BroadcastDB
+	err := r.conn.GetContext(ctx, &broadcastDB, r.conn.Rebind(query), args...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find broadcast: %w", err)
+	}
+
+	broadcast := &storage.Broadcast{
+		BroadcastDB: broadcastDB,
+		Messages:    make(map[string]string),
+		Coins:       []string{},
+	}
+
+	rows, err := r.conn.QueryContext(ctx, r.conn.Rebind("SELECT lang, text FROM broadcast_messages WHERE broadcast_id = ?"), broadcast.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query broadcast (id: %d) messages: %w", broadcast.ID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var lang, text string
+		if err := rows.Scan(&lang, &text); err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast (id: %d) message: %w", broadcast.ID, err)
+		}
+
+		broadcast.Messages[lang] = text
+	}
+
+	if err := r.conn.SelectContext(ctx, &broadcast.Coins, r.conn.Rebind(`SELECT
+		blockchain_coin
+	FROM broadcast_coins
+	WHERE broadcast_id = ?
+	ORDER BY blockchain_coin`), broadcast.ID); err != nil {
+		return nil, fmt.Errorf("failed to query broadcast (id: %d) coins: %w", broadcast.ID, err)
+	}
+
+	return broadcast, nil
+}
+
+func (r *BroadcastRepository) Find(ctx context.Context, id int64) (*storage.Broadcast, error) {
+	return r.findBroadcast(ctx, "SELECT * FROM broadcasts WHERE id = ?", id)
+}
+
+func (r *BroadcastRepository) FindDraft(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	return r.findBroadcast(ctx, `SELECT * FROM broadcasts
+	WHERE created_by = ? AND status = ?
+	ORDER BY created_at DESC, id DESC LIMIT 1`, adminID, storage.BroadcastDraft)
+}
+
+func (r *BroadcastRepository) FindLatest(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	return r.findBroadcast(ctx, `SELECT * FROM broadcasts
+	WHERE created_by = ? AND status <> ?
+	ORDER BY created_at DESC, id DESC LIMIT 1`, adminID, storage.BroadcastDraft)
+}
+
+func (r *BroadcastRepository) FindSendingIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	if err := r.conn.SelectContext(ctx, &ids, r.conn.Rebind("SELECT id FROM broadcasts WHERE status = ?"), storage.BroadcastSending); err != nil {
+		return nil, fmt.Errorf("failed to query sending broadcasts: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *BroadcastRepository) CreateDraft(ctx context.Context, adminID int64) (*storage.Broadcast, error) {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction to create broadcast draft: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind("UPDATE broadcasts SET status = ? WHERE created_by = ? AND status = ?"), storage.BroadcastCancelled, adminID, storage.BroadcastDraft); err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to cancel previous admin (id: %d) broadcast drafts: %w", adminID, err)
+	}
+
+	var broadcastDB storage.BroadcastDB
+	if err := tx.GetContext(ctx, &broadcastDB, tx.Rebind("INSERT INTO broadcasts (created_by) VALUES (?) RETURNING *"), adminID); err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to create admin (id: %d) broadcast draft: %w", adminID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit broadcast draft: %w", err)
+	}
+
+	return &storage.Broadcast{
+		BroadcastDB: broadcastDB,
+		Messages:    make(map[string]string),
+		Coins:       []string{},
+	}, nil
+}
+
+func (r *BroadcastRepository) SetMessage(ctx context.Context, id int64, lang, text string) error {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction to set broadcast message: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO broadcast_messages (
+		broadcast_id,
+		lang,
+		text
+	) VALUES (?, ?, ?) ON CONFLICT (broadcast_id, lang) DO UPDATE SET text = EXCLUDED.text`), id, lang, text); err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("failed to set broadcast (id: %d) message (lang: %s): %w", id, lang, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind("UPDATE broadcasts SET default_lang = ? WHERE id = ? AND default_lang IS NULL"), lang, id); err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("failed to set broadcast (id: %d) default lang: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit broadcast (id: %d) message: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *BroadcastRepository) SetCoins(ctx context.Context, id int64, coins []string) error {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction to set broadcast coins: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM broadcast_coins WHERE broadcast_id = ?"), id); err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("failed to clear broadcast (id: %d) coins: %w", id, err)
+	}
+
+	for _, coin := range coins {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO broadcast_coins (
+			broadcast_id,
+			blockchain_coin
+		) VALUES (?, ?) ON CONFLICT DO NOTHING`), id, coin); err != nil {
+			tx.Rollback()
+
+			return fmt.Errorf("failed to add broadcast (id: %d) coin (coin: %s): %w", id, coin, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit broadcast (id: %d) coins: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *BroadcastRepository) Cancel(ctx context.Context, id int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE broadcasts SET status = ? WHERE id = ? AND status = ?"), storage.BroadcastCancelled, id, storage.BroadcastDraft); err != nil {
+		return fmt.Errorf("failed to cancel broadcast (id: %d): %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *BroadcastRepository) CountRecipients(ctx context.Context, id int64) (int64, error) {
+	var count int64
+	if err := r.conn.GetContext(ctx, &count, r.conn.Rebind("SELECT COUNT(*) "+recipientsQuery), id, id); err != nil {
+		return 0, fmt.Errorf("failed to count broadcast (id: %d) recipients: %w", id, err)
+	}
+
+	return count, nil
+}
+
+func (r *BroadcastRepository) Stats(ctx context.Context, id int64) (*storage.DeliveryStats, error) {
+	var stats storage.DeliveryStats
+	if err := r.conn.GetContext(ctx, &stats, r.conn.Rebind(`SELECT
+		COUNT(*) FILTER (WHERE status = ?) AS pending,
+		COUNT(*) FILTER (WHERE status = ?) AS sent,
+		COUNT(*) FILTER (WHERE status = ?) AS failed,
+		COUNT(*) FILTER (WHERE status = ?) AS skipped
+	FROM broadcast_deliveries WHERE broadcast_id = ?`),
+		storage.DeliveryPending,
+		storage.DeliverySent,
+		storage.DeliveryFailed,
+		storage.DeliverySkipped,
+		id,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get broadcast (id: %d) delivery stats: %w", id, err)
+	}
+
+	return &stats, nil
+}
+
+func (r *BroadcastRepository) Start(ctx context.Context, id int64) (int64, bool, error) {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create transaction to send broadcast: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, tx.Rebind("UPDATE broadcasts SET status = ?, started_at = CURRENT_TIMESTAMP WHERE id = ? AND status = ?"), storage.BroadcastSending, id, storage.BroadcastDraft)
+	if err != nil {
+		tx.Rollback()
+
+		return 0, false, fmt.Errorf("failed to start broadcast (id: %d): %w", id, err)
+	} else if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		tx.Rollback()
+
+		return 0, false, nil
+	}
+
+	result, err = tx.ExecContext(ctx, tx.Rebind(`INSERT INTO broadcast_deliveries (
+		broadcast_id,
+		user_id
+	) SELECT ?, users.id `+recipientsQuery), id, id, id)
+	if err != nil {
+		tx.Rollback()
+
+		return 0, false, fmt.Errorf("failed to create broadcast (id: %d) deliveries: %w", id, err)
+	}
+
+	recipients, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+
+		return 0, false, fmt.Errorf("failed to count broadcast (id: %d) deliveries: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit broadcast (id: %d) start: %w", id, err)
+	}
+
+	return recipients, true, nil
+}
+
+func (r *BroadcastRepository) FindPendingDeliveries(ctx context.Context, id int64, limit int) ([]storage.DeliveryDB, error) {
+	deliveries := []storage.DeliveryDB{}
+	if err := r.conn.SelectContext(ctx, &deliveries, r.conn.Rebind(`SELECT
+		users.id AS user_id,
+		users.chat_id,
+		users.lang,
+		users.broadcasts_notify,
+		users.active
+	FROM broadcast_deliveries
+	INNER JOIN users ON users.id = broadcast_deliveries.user_id
+	WHERE broadcast_deliveries.broadcast_id = ? AND broadcast_deliveries.status = ?
+	ORDER BY broadcast_deliveries.user_id
+	LIMIT ?`), id, storage.DeliveryPending, limit); err != nil {
+		return nil, fmt.Errorf("failed to query broadcast (id: %d) pending deliveries: %w", id, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *BroadcastRepository) SetDeliveryStatus(ctx context.Context, id, userID int64, status storage.DeliveryStatus, errText *string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE broadcast_deliveries
+		SET status = ?, error = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE broadcast_id = ? AND user_id = ?`), status, errText, id, userID); err != nil {
+		return fmt.Errorf("failed to update broadcast (id: %d) delivery (user id: %d) status: %w", id, userID, err)
+	}
+
+	return nil
+}
+
+func (r *BroadcastRepository) Finish(ctx context.Context, id int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE broadcasts SET status = ?, finished_at = CURRENT_TIMESTAMP WHERE id = ?"), storage.BroadcastSent, id); err != nil {
+		return fmt.Errorf("failed to finish broadcast (id: %d): %w", id, err)
+	}
+
+	return nil
+}
+
+func NewBroadcastRepository(conn *sqlx.DB) *BroadcastRepository {
+	return &BroadcastRepository{
+		conn: conn,
+	}
+}
@@ -0,0 +1,133 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type walletChatTargetDB struct {
+	SubscriptionID int64 `db:"subscription_id"`
+	storage.ChatTarget
+}
+
+type ChatTargetRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *ChatTargetRepository) Link(ctx context.Context, target *storage.ChatTargetDB) (*storage.ChatTargetDB, error) {
+	var linkedTarget storage.ChatTargetDB
+	if err := r.conn.GetContext(ctx, &linkedTarget, r.conn.Rebind(`INSERT INTO chat_targets (
+		user_id,
+		chat_id,
+		thread_id,
+		chat_type,
+		title
+	) VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT (user_id, chat_id, thread_id) DO UPDATE SET chat_type = EXCLUDED.chat_type, title = EXCLUDED.title
+	RETURNING *`),
+		target.UserID,
+		target.ChatID,
+		target.ThreadID,
+		target.ChatType,
+		target.Title,
+	); err != nil {
+		return nil, fmt.Errorf("failed to link user (id: %d) chat (id: %d, thread id: %d): %w", target.UserID, target.ChatID, target.ThreadID, err)
+	}
+
+	return &linkedTarget, nil
+}
+
+func (r *ChatTargetRepository) Find(ctx context.Context, userID, id int64) (*storage.ChatTargetDB, error) {
+	var target storage.ChatTargetDB
+	err := r.conn.GetContext(ctx, &target, r.conn.Rebind("SELECT * FROM chat_targets WHERE id = ? AND user_id = ?"), id, userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) chat target (id: %d): %w", userID, id, err)
+	}
+
+	return &target, nil
+}
+
+func (r *ChatTargetRepository) FindByUser(ctx context.Context, userID int64) ([]storage.ChatTargetDB, error) {
+	targets := []storage.ChatTargetDB{}
+	if err := r.conn.SelectContext(ctx, &targets, r.conn.Rebind("SELECT * FROM chat_targets WHERE user_id = ? ORDER BY added_at, id"), userID); err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) chat targets: %w", userID, err)
+	}
+
+	return targets, nil
+}
+
+func (r *ChatTargetRepository) Unlink(ctx context.Context, userID, id int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("DELETE FROM chat_targets WHERE id = ? AND user_id = ?"), id, userID); err != nil {
+		return fmt.Errorf("failed to unlink user (id: %d) chat target (id: %d): %w", userID, id, err)
+	}
+
+	return nil
+}
+
+func (r *ChatTargetRepository) FindWallets(ctx context.Context, userID, targetID int64) ([]storage.ChatTargetWalletDB, error) {
+	wallets := []storage.ChatTargetWalletDB{}
+	if err := r.conn.SelectContext(ctx, &wallets, r.conn.Rebind(`SELECT
+		user_wallets.id,
+		wallets.blockchain_coin,
+		wallets.wallet,
+		COALESCE(user_wallets.label, '') AS label,
+		wallet_chat_targets.target_id IS NOT NULL AS enabled
+	FROM user_wallets
+	INNER JOIN wallets ON wallets.id = user_wallets.wallet_id
+	LEFT JOIN wallet_chat_targets ON wallet_chat_targets.wallet_id = user_wallets.id AND wallet_chat_targets.target_id = ?
+	WHERE user_wallets.user_id = ?
+	ORDER BY wallets.blockchain_coin, user_wallets.added_at, user_wallets.id`), targetID, userID); err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) chat target (id: %d) wallets: %w", userID, targetID, err)
+	}
+
+	return wallets, nil
+}
+
+func (r *ChatTargetRepository) SetWallet(ctx context.Context, targetID, walletID int64, enabled bool) error {
+	var err error
+	if enabled {
+		_, err = r.conn.ExecContext(ctx, r.conn.Rebind(`INSERT INTO wallet_chat_targets (
+			wallet_id,
+			target_id
+		) VALUES (?, ?) ON CONFLICT DO NOTHING`), walletID, targetID)
+	} else {
+		_, err = r.conn.ExecContext(ctx, r.conn.Rebind("DELETE FROM wallet_chat_targets WHERE wallet_id = ? AND target_id = ?"), walletID, targetID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to set chat target (id: %d) wallet (id: %d) to %t: %w", targetID, walletID, enabled, err)
+	}
+
+	return nil
+}
+
+func (r *ChatTargetRepository) FindAllWalletTargets(ctx context.Context) (map[int64][]storage.ChatTarget, error) {
+	walletTargets := []walletChatTargetDB{}
+	if err := r.conn.SelectContext(ctx, &walletTargets, `SELECT
+		wallet_chat_targets.wallet_id AS subscription_id,
+		chat_targets.chat_id,
+		chat_targets.thread_id
+	FROM wallet_chat_targets
+	INNER JOIN chat_targets ON chat_targets.id = wallet_chat_targets.target_id`); err != nil {
+		return nil, fmt.Errorf("failed to get wallets chat targets: %w", err)
+	}
+
+	targetsMap := make(map[int64][]storage.ChatTarget)
+	for _, walletTarget := range walletTargets {
+		targetsMap[walletTarget.SubscriptionID] = append(targetsMap[walletTarget.SubscriptionID], walletTarget.ChatTarget)
+	}
+
+	return targetsMap, nil
+}
+
+func NewChatTargetRepository(conn *sqlx.DB) *ChatTargetRepository {
+	return &ChatTargetRepository{
+		conn: conn,
+	}
+}
@@ -0,0 +1,173 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type NotifyRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *NotifyRepository) FindPauses(ctx context.Context) ([]storage.NotifyPauseDB, error) {
+	pauses := []storage.NotifyPauseDB{}
+	if err := r.conn.SelectContext(ctx, &pauses, "SELECT scope, paused_by, paused_at FROM notify_pauses"); err != nil {
+		return nil, fmt.Errorf("failed to query notify pauses: %w", err)
+	}
+
+	return pauses, nil
+}
+
+func (r *NotifyRepository) AddPause(ctx context.Context, pause *storage.NotifyPauseDB) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`INSERT INTO notify_pauses (
+		scope, 
+		paused_by, 
+		paused_at
+	) VALUES (?, ?, ?) ON CONFLICT (scope) DO NOTHING`), pause.Scope, pause.PausedBy, pause.PausedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to pause notifications (scope: %s): %w", pause.Scope, err)
+	}
+
+	return nil
+}
+
+func (r *NotifyRepository) DeletePause(ctx context.Context, scope string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("DELETE FROM notify_pauses WHERE scope = ?"), scope); err != nil {
+		return fmt.Errorf("failed to resume notifications (scope: %s): %w", scope, err)
+	}
+
+	return nil
+}
+
+func (r *NotifyRepository) FindLastPayoutsCheck(ctx context.Context) (*time.Time, error) {
+	var lastExecutionTime time.Time
+	err := r.conn.GetContext(ctx, &lastExecutionTime, `SELECT 
+		executed_at FROM payouts_notifications 
+	ORDER BY executed_at DESC LIMIT 1`)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to query last payouts notification executed time: %w", err)
+	}
+
+	return &lastExecutionTime, nil
+}
+
+func (r *NotifyRepository) AddPayoutsCheck(ctx context.Context, executedAt time.Time) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("INSERT INTO payouts_notifications (executed_at) VALUES (?)"), executedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to create new payouts notification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NotifyRepository) FindSubscriptions(ctx context.Context) ([]storage.WalletSubscriptionDB, error) {
+	subscriptions := []storage.WalletSubscriptionDB{}
+	if err := r.conn.SelectContext(ctx, &subscriptions, `SELECT
+		wallets.id AS wallet_id,
+		wallets.blockchain_coin,
+		wallets.wallet,
+		user_wallets.id AS subscription_id,
+		COALESCE(user_wallets.label, '') AS label,
+		user_wallets.notify,
+		user_wallets.user_id,
+		users.chat_id,
+		users.lang,
+		users.short_wallets,
+		users.payouts_notify,
+		users.blocks_notify
+	FROM wallets
+	INNER JOIN user_wallets ON user_wallets.wallet_id = wallets.id
+	INNER JOIN users ON users.id = user_wallets.user_id
+	WHERE user_wallets.paused = false AND users.active = true`); err != nil {
+		return nil, fmt.Errorf("failed to query wallets subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *NotifyRepository) FindWorkers(ctx context.Context) ([]storage.WalletWorkerDB, error) {
+	workers := []storage.WalletWorkerDB{}
+	if err := r.conn.SelectContext(ctx, &workers, `SELECT
+		wallet_id,
+		worker,
+		region,
+		solo,
+		connected_at
+	FROM wallet_workers`); err != nil {
+		return nil, fmt.Errorf("failed to query workers: %w", err)
+	}
+
+	return workers, nil
+}
+
+func batches[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		size = len(items)
+	}
+
+	groups := [][]T{}
+	for start := 0; start < len(items); start += size {
+		groups = append(groups, items[start:min(start+size, len(items))])
+	}
+
+	return groups
+}
+
+func (r *NotifyRepository) UpdateWorkers(ctx context.Context, added, removed []storage.WalletWorkerDB, batchSize int) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction to update workers in database: %w", err)
+	}
+
+	for groupNum, addedWorkers := range batches(added, batchSize) {
+		if _, err := tx.NamedExecContext(ctx, `INSERT INTO wallet_workers (
+			wallet_id, 
+			worker, 
+			region, 
+			solo, 
+			connected_at
+		) VALUES (:wallet_id, :worker, :region, :solo, :connected_at)`, addedWorkers); err != nil {
+			tx.Rollback()
+
+			return fmt.Errorf("failed to insert added workers batch (group num: %d, batch length: %d), error: %w", groupNum, len(addedWorkers), err)
+		}
+	}
+
+	for groupNum, removedWorkers := range batches(removed, batchSize) {
+		//	Workers are identified by composite key, so each batch is deleted with one statement of OR conditions
+		conditions := make([]string, 0, len(removedWorkers))
+		args := make([]any, 0, len(removedWorkers)*2)
+		for _, worker := range removedWorkers {
+			conditions = append(conditions, "(wallet_id = ? AND worker = ?)")
+			args = append(args, worker.WalletID, worker.Worker)
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM wallet_workers WHERE "+strings.Join(conditions, " OR ")), args...); err != nil {
+			tx.Rollback()
+
+			return fmt.Errorf("failed to delete removed workers batch (group num: %d, batch length: %d), error: %w", groupNum, len(removedWorkers), err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit workers changes in database: %w", err)
+	}
+
+	return nil
+}
+
+func NewNotifyRepository(conn *sqlx.DB) *NotifyRepository {
+	return &NotifyRepository{
+		conn: conn,
+	}
+}
@@ -0,0 +1,337 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type PrivacyRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *PrivacyRepository) exportWallets(ctx context.Context, userID int64) ([]storage.ExportWallet, error) {
+	wallets := []storage.ExportWallet{}
+	if err := r.conn.SelectContext(ctx, &wallets, r.conn.Rebind(`SELECT
+		user_wallets.id,
+		wallets.blockchain_coin,
+		wallets.wallet,
+		user_wallets.label,
+		user_wallets.role,
+		user_wallets.notify,
+		user_wallets.paused,
+		user_wallets.added_at
+	FROM user_wallets
+	INNER JOIN wallets ON wallets.id = user_wallets.wallet_id
+	WHERE user_wallets.user_id = ?
+	ORDER BY user_wallets.added_at, user_wallets.id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) wallets: %w", userID, err)
+	}
+
+	workers := []storage.ExportWorker{}
+	if err := r.conn.SelectContext(ctx, &workers, r.conn.Rebind(`SELECT
+		user_wallets.id AS subscription_id,
+		wallet_workers.worker,
+		worker_aliases.alias,
+		wallet_workers.region,
+		wallet_workers.solo,
+		wallet_workers.connected_at
+	FROM wallet_workers
+	INNER JOIN user_wallets ON user_wallets.wallet_id = wallet_workers.wallet_id
+	LEFT JOIN worker_aliases ON worker_aliases.wallet_id = user_wallets.id AND worker_aliases.worker = wallet_workers.worker
+	WHERE user_wallets.user_id = ?
+	ORDER BY wallet_workers.worker`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) wallets workers: %w", userID, err)
+	}
+
+	workersMap := make(map[int64][]storage.ExportWorker)
+	for _, worker := range workers {
+		workersMap[worker.SubscriptionID] = append(workersMap[worker.SubscriptionID], worker)
+	}
+
+	for i := range wallets {
+		wallets[i].Workers = workersMap[wallets[i].ID]
+		if wallets[i].Workers == nil {
+			wallets[i].Workers = []storage.ExportWorker{}
+		}
+	}
+
+	return wallets, nil
+}
+
+func (r *PrivacyRepository) exportChatTargets(ctx context.Context, userID int64) ([]storage.ExportChatTarget, error) {
+	targets := []storage.ExportChatTarget{}
+	if err := r.conn.SelectContext(ctx, &targets, r.conn.Rebind(`SELECT
+		id,
+		chat_id,
+		thread_id,
+		chat_type,
+		title,
+		added_at
+	FROM chat_targets
+	WHERE user_id = ?
+	ORDER BY added_at, id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) chat targets: %w", userID, err)
+	}
+
+	links := []struct {
+		TargetID int64 `db:"target_id"`
+		WalletID int64 `db:"wallet_id"`
+	}{}
+	if err := r.conn.SelectContext(ctx, &links, r.conn.Rebind(`SELECT
+		wallet_chat_targets.target_id,
+		wallet_chat_targets.wallet_id
+	FROM wallet_chat_targets
+	INNER JOIN chat_targets ON chat_targets.id = wallet_chat_targets.target_id
+	WHERE chat_targets.user_id = ?
+	ORDER BY wallet_chat_targets.wallet_id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) chat targets wallets: %w", userID, err)
+	}
+
+	walletsMap := make(map[int64][]int64)
+	for _, link := range links {
+		walletsMap[link.TargetID] = append(walletsMap[link.TargetID], link.WalletID)
+	}
+
+	for i := range targets {
+		targets[i].WalletIDs = walletsMap[targets[i].ID]
+		if targets[i].WalletIDs == nil {
+			targets[i].WalletIDs = []int64{}
+		}
+	}
+
+	return targets, nil
+}
+
+func (r *PrivacyRepository) exportTickets(ctx context.Context, userID int64) ([]storage.ExportTicket, error) {
+	tickets := []storage.ExportTicket{}
+	if err := r.conn.SelectContext(ctx, &tickets, r.conn.Rebind(`SELECT
+		id,
+		status,
+		created_at,
+		updated_at,
+		closed_at
+	FROM support_tickets
+	WHERE user_id = ?
+	ORDER BY created_at, id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) support tickets: %w", userID, err)
+	}
+
+	//	Support agents ids are not personal data of user, so they are not exported
+	messages := []storage.ExportTicketMessage{}
+	if err := r.conn.SelectContext(ctx, &messages, r.conn.Rebind(`SELECT
+		support_ticket_messages.ticket_id,
+		support_ticket_messages.direction,
+		support_ticket_messages.text,
+		support_ticket_messages.attachment_type,
+		support_ticket_messages.created_at
+	FROM support_ticket_messages
+	INNER JOIN support_tickets ON support_tickets.id = support_ticket_messages.ticket_id
+	WHERE support_tickets.user_id = ?
+	ORDER BY support_ticket_messages.created_at, support_ticket_messages.id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) support tickets messages: %w", userID, err)
+	}
+
+	messagesMap := make(map[int64][]storage.ExportTicketMessage)
+	for _, message := range messages {
+		messagesMap[message.TicketID] = append(messagesMap[message.TicketID], message)
+	}
+
+	for i := range tickets {
+		tickets[i].Messages = messagesMap[tickets[i].ID]
+		if tickets[i].Messages == nil {
+			tickets[i].Messages = []storage.ExportTicketMessage{}
+		}
+	}
+
+	return tickets, nil
+}
+
+func (r *PrivacyRepository) Export(ctx context.Context, userID int64) (*storage.Export, error) {
+	var export storage.Export
+	err := r.conn.GetContext(ctx, &export.User, r.conn.Rebind(`SELECT
+		id,
+		chat_id,
+		lang,
+		username,
+		payouts_notify,
+		blocks_notify,
+		broadcasts_notify,
+		short_wallets,
+		active,
+		inactive_since
+	FROM users
+	WHERE id = ?`), userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d): %w", userID, err)
+	}
+
+	if err := r.conn.GetContext(ctx, &export.User.Admin, r.conn.Rebind("SELECT EXISTS(SELECT 1 FROM admins WHERE user_id = ?)"), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) admin role: %w", userID, err)
+	}
+
+	var action storage.ExportAction
+	err = r.conn.GetContext(ctx, &action, r.conn.Rebind("SELECT action, payload FROM user_actions WHERE user_id = ?"), userID)
+	if err == nil {
+		export.Action = &action
+	} else if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to query user (id: %d) action: %w", userID, err)
+	}
+
+	if export.Wallets, err = r.exportWallets(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if export.ChatTargets, err = r.exportChatTargets(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if export.Tickets, err = r.exportTickets(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	//	Invite tokens are secrets, so only invites metadata is exported
+	export.Invites = []storage.ExportInvite{}
+	if err := r.conn.SelectContext(ctx, &export.Invites, r.conn.Rebind(`SELECT
+		wallets.blockchain_coin,
+		wallets.wallet,
+		wallet_invites.role,
+		wallet_invites.created_at,
+		wallet_invites.expires_at
+	FROM wallet_invites
+	INNER JOIN wallets ON wallets.id = wallet_invites.wallet_id
+	WHERE wallet_invites.created_by = ?
+	ORDER BY wallet_invites.created_at`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) wallet invites: %w", userID, err)
+	}
+
+	export.Deliveries = []storage.ExportDelivery{}
+	if err := r.conn.SelectContext(ctx, &export.Deliveries, r.conn.Rebind(`SELECT
+		broadcast_id,
+		status,
+		updated_at
+	FROM broadcast_deliveries
+	WHERE user_id = ?
+	ORDER BY broadcast_id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) broadcast deliveries: %w", userID, err)
+	}
+
+	return &export, nil
+}
+
+func (r *PrivacyRepository) Delete(ctx context.Context, userID int64, reason string) (*storage.DeletionCounts, error) {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction to delete user data: %w", err)
+	}
+
+	var counts storage.DeletionCounts
+	if err := tx.GetContext(ctx, &counts, tx.Rebind(`SELECT
+		(SELECT COUNT(*) FROM chat_targets WHERE user_id = ?) AS chat_targets_count,
+		(SELECT COUNT(*) FROM support_tickets WHERE user_id = ?) AS tickets_count`), userID, userID); err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to count user (id: %d) data: %w", userID, err)
+	}
+
+	walletIDs := []int64{}
+	if err := tx.SelectContext(ctx, &walletIDs, tx.Rebind("DELETE FROM user_wallets WHERE user_id = ? RETURNING wallet_id"), userID); err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to delete user (id: %d) wallets: %w", userID, err)
+	}
+
+	//	Shared wallets are kept while they have other subscribers
+	for _, walletID := range walletIDs {
+		if err := deleteUnusedWallet(ctx, tx, walletID); err != nil {
+			tx.Rollback()
+
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM user_actions WHERE user_id = ?"), userID); err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to delete user (id: %d) action: %w", userID, err)
+	}
+
+	//	Chat targets, support tickets, invites and deliveries are removed by cascade
+	result, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM users WHERE id = ?"), userID)
+	if err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to delete user (id: %d): %w", userID, err)
+	}
+
+	if deleted, err := result.RowsAffected(); err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to get user (id: %d) deleted rows: %w", userID, err)
+	} else if deleted == 0 {
+		tx.Rollback()
+
+		return nil, nil
+	}
+
+	counts.Wallets = len(walletIDs)
+
+	//	Audit log keeps only reason and amounts of deleted data to not retain personal data
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO user_deletions (
+		reason,
+		wallets_count,
+		chat_targets_count,
+		tickets_count
+	) VALUES (?, ?, ?, ?)`), reason, counts.Wallets, counts.ChatTargets, counts.Tickets); err != nil {
+		tx.Rollback()
+
+		return nil, fmt.Errorf("failed to insert user deletion audit log: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit user (id: %d) data deletion: %w", userID, err)
+	}
+
+	return &counts, nil
+}
+
+func (r *PrivacyRepository) Deactivate(ctx context.Context, chatID int64, reason string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE users
+		SET active = false, inactive_reason = ?, inactive_since = CURRENT_TIMESTAMP
+		WHERE chat_id = ? AND active = true`), reason, chatID); err != nil {
+		return fmt.Errorf("failed to deactivate user (chat id: %d): %w", chatID, err)
+	}
+
+	return nil
+}
+
+func (r *PrivacyRepository) Reactivate(ctx context.Context, chatID int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE users
+		SET active = true, inactive_reason = NULL, inactive_since = NULL
+		WHERE chat_id = ? AND active = false`), chatID); err != nil {
+		return fmt.Errorf("failed to reactivate user (chat id: %d): %w", chatID, err)
+	}
+
+	return nil
+}
+
+func (r *PrivacyRepository) FindInactive(ctx context.Context, before time.Time) ([]int64, error) {
+	ids := []int64{}
+	if err := r.conn.SelectContext(ctx, &ids, r.conn.Rebind("SELECT id FROM users WHERE active = false AND inactive_since < ?"), before.UTC()); err != nil {
+		return nil, fmt.Errorf("failed to query inactive users: %w", err)
+	}
+
+	return ids, nil
+}
+
+func NewPrivacyRepository(conn *sqlx.DB) *PrivacyRepository {
+	return &PrivacyRepository{
+		conn: conn,
+	}
+}
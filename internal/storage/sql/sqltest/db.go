@@ -0,0 +1,116 @@
+package sqlTest
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	postgresConfig "github.com/grandminingpool/telegram-bot/configs/postgres"
+	"github.com/grandminingpool/telegram-bot/internal/migrate"
+	postgresTest "github.com/grandminingpool/telegram-bot/internal/providers/postgres/postgrestest"
+	sqliteProvider "github.com/grandminingpool/telegram-bot/internal/providers/sqlite"
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	sqlStorage "github.com/grandminingpool/telegram-bot/internal/storage/sql"
+	"github.com/grandminingpool/telegram-bot/migrations"
+	sqliteMigrations "github.com/grandminingpool/telegram-bot/migrations/sqlite"
+	"github.com/jmoiron/sqlx"
+)
+
+// DB is database of test with applied migrations
+type DB struct {
+	t    testing.TB
+	conn *sqlx.DB
+}
+
+func (db *DB) Storage() *storage.Storage {
+	return sqlStorage.New(db.conn)
+}
+
+func (db *DB) AddBlockchain(blockchain storage.BlockchainDB) {
+	db.t.Helper()
+
+	if _, err := db.conn.NamedExec(`INSERT INTO blockchains (
+		coin,
+		name,
+		ticker,
+		decimals,
+		display_precision,
+		example_wallet,
+		pool_api_url,
+		pool_api_tls_ca,
+		pool_api_tls_cert,
+		pool_api_tls_key,
+		pool_api_server_name,
+		pool_api_token,
+		pool_api_insecure
+	) VALUES (
+		:coin,
+		:name,
+		:ticker,
+		:decimals,
+		:display_precision,
+		:example_wallet,
+		:pool_api_url,
+		:pool_api_tls_ca,
+		:pool_api_tls_cert,
+		:pool_api_tls_key,
+		:pool_api_server_name,
+		:pool_api_token,
+		:pool_api_insecure
+	)`, blockchain); err != nil {
+		db.t.Fatalf("failed to add blockchain (coin: %s): %v", blockchain.Coin, err)
+	}
+}
+
+func (db *DB) AddAdmin(userID int64) {
+	db.t.Helper()
+
+	if _, err := db.conn.Exec(db.conn.Rebind("INSERT INTO admins (user_id) VALUES (?) ON CONFLICT DO NOTHING"), userID); err != nil {
+		db.t.Fatalf("failed to add admin (user id: %d): %v", userID, err)
+	}
+}
+
+func newDB(t testing.TB, conn *sqlx.DB, migrationsFS fs.FS) *DB {
+	t.Helper()
+
+	migrator, err := migrate.NewMigrator(conn, migrationsFS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return &DB{
+		t:    t,
+		conn: conn,
+	}
+}
+
+// NewSQLite creates database file in temporary directory of test
+func NewSQLite(t testing.TB) *DB {
+	t.Helper()
+
+	conn, err := sqliteProvider.NewConnection(context.Background(), &postgresConfig.Config{
+		Driver: postgresConfig.SQLITE_DRIVER,
+		Path:   filepath.Join(t.TempDir(), "bot.db"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create sqlite connection: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return newDB(t, conn, sqliteMigrations.FS)
+}
+
+// NewPostgres creates schema of test in database from postgresTest.DSN_ENV, test is skipped when it is not set
+func NewPostgres(t testing.TB) *DB {
+	t.Helper()
+
+	return newDB(t, postgresTest.Connect(t), migrations.FS)
+}
@@ -0,0 +1,25 @@
+package sqlStorage
+
+import (
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Repositories are shared by postgres and sqlite, queries are written with ? placeholders
+// and rebound to bind type of connection driver, so same parameter is passed for each placeholder
+func New(conn *sqlx.DB) *storage.Storage {
+	return &storage.Storage{
+		Users:         NewUserRepository(conn),
+		UserActions:   NewUserActionRepository(conn),
+		Wallets:       NewWalletRepository(conn),
+		WalletInvites: NewWalletInviteRepository(conn),
+		ChatTargets:   NewChatTargetRepository(conn),
+		Support:       NewSupportRepository(conn),
+		Admins:        NewAdminRepository(conn),
+		BotMetadata:   NewBotMetadataRepository(conn),
+		Privacy:       NewPrivacyRepository(conn),
+		Broadcasts:    NewBroadcastRepository(conn),
+		Blockchains:   NewBlockchainRepository(conn),
+		Notify:        NewNotifyRepository(conn),
+	}
+}
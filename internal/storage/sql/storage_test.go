@@ -0,0 +1,20 @@
+package sqlStorage_test
+
+import (
+	"testing"
+
+	sqlTest "github.com/grandminingpool/telegram-bot/internal/storage/sql/sqltest"
+	storageTest "github.com/grandminingpool/telegram-bot/internal/storage/storagetest"
+)
+
+func TestSQLiteStorage(t *testing.T) {
+	storageTest.Run(t, func(t *testing.T) storageTest.DB {
+		return sqlTest.NewSQLite(t)
+	})
+}
+
+func TestPostgresStorage(t *testing.T) {
+	storageTest.Run(t, func(t *testing.T) storageTest.DB {
+		return sqlTest.NewPostgres(t)
+	})
+}
@@ -0,0 +1,181 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type SupportRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *SupportRepository) addMessage(ctx context.Context, tx *sqlx.Tx, ticketID int64, message *storage.SupportMessage) (int64, error) {
+	var id int64
+	if err := tx.GetContext(ctx, &id, tx.Rebind(`INSERT INTO support_ticket_messages (
+		ticket_id,
+		direction,
+		author_id,
+		text,
+		attachment_type,
+		attachment_file_id,
+		user_message_id,
+		support_message_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`),
+		ticketID,
+		message.Direction,
+		message.AuthorID,
+		message.Text,
+		message.AttachmentType,
+		message.AttachmentFileID,
+		message.UserMessageID,
+		message.SupportMessageID,
+	); err != nil {
+		return 0, fmt.Errorf("failed to add support ticket (id: %d) message: %w", ticketID, err)
+	}
+
+	return id, nil
+}
+
+func (r *SupportRepository) Create(ctx context.Context, userID int64, message *storage.SupportMessage) (*storage.SupportTicketDB, int64, error) {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create transaction to create support ticket: %w", err)
+	}
+
+	var ticket storage.SupportTicketDB
+	if err := tx.GetContext(ctx, &ticket, tx.Rebind("INSERT INTO support_tickets (user_id) VALUES (?) RETURNING *"), userID); err != nil {
+		tx.Rollback()
+
+		return nil, 0, fmt.Errorf("failed to create user (id: %d) support ticket: %w", userID, err)
+	}
+
+	messageID, err := r.addMessage(ctx, tx, ticket.ID, message)
+	if err != nil {
+		tx.Rollback()
+
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, fmt.Errorf("failed to commit user (id: %d) support ticket: %w", userID, err)
+	}
+
+	return &ticket, messageID, nil
+}
+
+func (r *SupportRepository) AddMessage(
+	ctx context.Context,
+	ticketID int64,
+	message *storage.SupportMessage,
+	status storage.SupportTicketStatus,
+) (int64, error) {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create transaction to add support ticket message: %w", err)
+	}
+
+	messageID, err := r.addMessage(ctx, tx, ticketID, message)
+	if err != nil {
+		tx.Rollback()
+
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`UPDATE support_tickets
+		SET status = ?, updated_at = CURRENT_TIMESTAMP, closed_at = NULL
+		WHERE id = ?`), status, ticketID); err != nil {
+		tx.Rollback()
+
+		return 0, fmt.Errorf("failed to update support ticket (id: %d) status: %w", ticketID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit support ticket (id: %d) message: %w", ticketID, err)
+	}
+
+	return messageID, nil
+}
+
+func (r *SupportRepository) SetTicketSupportMessageID(ctx context.Context, ticketID, supportMessageID int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE support_tickets SET support_message_id = ? WHERE id = ?"), supportMessageID, ticketID); err != nil {
+		return fmt.Errorf("failed to set support ticket (id: %d) support message: %w", ticketID, err)
+	}
+
+	return nil
+}
+
+func (r *SupportRepository) SetUserMessageID(ctx context.Context, messageID, userMessageID int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE support_ticket_messages SET user_message_id = ? WHERE id = ?"), userMessageID, messageID); err != nil {
+		return fmt.Errorf("failed to set support ticket message (id: %d) user message: %w", messageID, err)
+	}
+
+	return nil
+}
+
+func (r *SupportRepository) SetSupportMessageID(ctx context.Context, messageID, supportMessageID int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE support_ticket_messages SET support_message_id = ? WHERE id = ?"), supportMessageID, messageID); err != nil {
+		return fmt.Errorf("failed to set support ticket message (id: %d) support message: %w", messageID, err)
+	}
+
+	return nil
+}
+
+func (r *SupportRepository) findTicket(ctx context.Context, query string, args ...any) (*storage.SupportTicketDB, error) {
+	var ticket storage.SupportTicketDB
+	err := r.conn.GetContext(ctx, &ticket, r.conn.Rebind(query), args...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find support ticket: %w", err)
+	}
+
+	return &ticket, nil
+}
+
+func (r *SupportRepository) FindBySupportMessage(ctx context.Context, supportMessageID int64) (*storage.SupportTicketDB, error) {
+	return r.findTicket(ctx, `SELECT * FROM support_tickets
+	WHERE support_message_id = ? OR id IN (
+		SELECT ticket_id FROM support_ticket_messages WHERE support_message_id = ?
+	) ORDER BY id LIMIT 1`, supportMessageID, supportMessageID)
+}
+
+func (r *SupportRepository) FindByUserMessage(ctx context.Context, userID, userMessageID int64) (*storage.SupportTicketDB, error) {
+	return r.findTicket(ctx, `SELECT * FROM support_tickets
+	WHERE user_id = ? AND id IN (
+		SELECT ticket_id FROM support_ticket_messages WHERE user_message_id = ?
+	) ORDER BY id LIMIT 1`, userID, userMessageID)
+}
+
+func (r *SupportRepository) FindFirstUserMessageID(ctx context.Context, ticketID int64) (*int64, error) {
+	var userMessageID *int64
+	err := r.conn.GetContext(ctx, &userMessageID, r.conn.Rebind(`SELECT user_message_id FROM support_ticket_messages
+	WHERE ticket_id = ? AND direction = ?
+	ORDER BY created_at, id LIMIT 1`), ticketID, storage.SupportMessageFromUser)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find support ticket (id: %d) first user message: %w", ticketID, err)
+	}
+
+	return userMessageID, nil
+}
+
+func (r *SupportRepository) Close(ctx context.Context, ticketID int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE support_tickets
+		SET status = ?, updated_at = CURRENT_TIMESTAMP, closed_at = CURRENT_TIMESTAMP
+		WHERE id = ?`), storage.SupportTicketClosed, ticketID); err != nil {
+		return fmt.Errorf("failed to close support ticket (id: %d): %w", ticketID, err)
+	}
+
+	return nil
+}
+
+func NewSupportRepository(conn *sqlx.DB) *SupportRepository {
+	return &SupportRepository{
+		conn: conn,
+	}
+}
@@ -0,0 +1,53 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type UserActionRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *UserActionRepository) Set(ctx context.Context, userID int64, action storage.UserAction, payload *string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`INSERT INTO user_actions (
+		user_id, 
+		action, 
+		payload
+	) VALUES (?, ?, ?)
+	ON CONFLICT (user_id) DO UPDATE SET action = EXCLUDED.action, payload = EXCLUDED.payload`), userID, action, payload); err != nil {
+		return fmt.Errorf("failed to set user (id: %d) action (name: %s, payload: %v), error: %w", userID, string(action), payload, err)
+	}
+
+	return nil
+}
+
+func (r *UserActionRepository) Get(ctx context.Context, userID int64) (*storage.UserActionDB, error) {
+	var userAction storage.UserActionDB
+	err := r.conn.GetContext(ctx, &userAction, r.conn.Rebind("SELECT * FROM user_actions WHERE user_id = ?"), userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get user (id: %d) action: %w", userID, err)
+	}
+
+	return &userAction, nil
+}
+
+func (r *UserActionRepository) Clear(ctx context.Context, userID int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`DELETE FROM user_actions WHERE user_id = ?`), userID); err != nil {
+		return fmt.Errorf("failed to clear user (id: %d) actions: %w", userID, err)
+	}
+
+	return nil
+}
+
+func NewUserActionRepository(conn *sqlx.DB) *UserActionRepository {
+	return &UserActionRepository{
+		conn: conn,
+	}
+}
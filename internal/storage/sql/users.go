@@ -0,0 +1,138 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type UserRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *UserRepository) find(ctx context.Context, query string, arg any) (*storage.UserDB, error) {
+	var user storage.UserDB
+	err := r.conn.GetContext(ctx, &user, r.conn.Rebind(query), arg)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) Find(ctx context.Context, id int64) (*storage.UserDB, error) {
+	user, err := r.find(ctx, "SELECT * FROM users WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d), error: %w", id, err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*storage.UserDB, error) {
+	user, err := r.find(ctx, "SELECT * FROM users WHERE LOWER(username) = LOWER(?)", username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user (username: %s), error: %w", username, err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *storage.UserDB) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`INSERT INTO users (
+		id, 
+		chat_id,
+		lang, 
+		payouts_notify, 
+		blocks_notify,
+		username
+	) VALUES (?, ?, ?, ?, ?, ?)`),
+		user.ID,
+		user.ChatID,
+		user.Lang,
+		user.PayoutsNotify,
+		user.BlocksNotify,
+		user.Username,
+	); err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetChatID(ctx context.Context, id, chatID int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE users SET chat_id = ? WHERE id = ?"), chatID, id); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) chat id (new value: %d), error: %w", id, chatID, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetUsername(ctx context.Context, id int64, username *string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE users SET username = ? WHERE id = ?"), username, id); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) username, error: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetLang(ctx context.Context, id int64, lang string) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE users SET lang = ? WHERE id = ?"), lang, id); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) lang: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetPayoutsNotify(ctx context.Context, id int64, value bool) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE users SET payouts_notify = ? WHERE id = ?"), value, id); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) payouts notify: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetBlocksNotify(ctx context.Context, id int64, value bool) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE users SET blocks_notify = ? WHERE id = ?"), value, id); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) blocks notify: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetBroadcastsNotify(ctx context.Context, id int64, value bool) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE users SET broadcasts_notify = ? WHERE id = ?"), value, id); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) broadcasts notify: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetShortWallets(ctx context.Context, id int64, value bool) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind("UPDATE users SET short_wallets = ? WHERE id = ?"), value, id); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) short wallets: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Reactivate(ctx context.Context, id int64) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE users
+		SET active = true, inactive_reason = NULL, inactive_since = NULL
+		WHERE id = ?`), id); err != nil {
+		return fmt.Errorf("failed to reactivate user (id: %d), error: %w", id, err)
+	}
+
+	return nil
+}
+
+func NewUserRepository(conn *sqlx.DB) *UserRepository {
+	return &UserRepository{
+		conn: conn,
+	}
+}
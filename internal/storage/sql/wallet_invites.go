@@ -0,0 +1,136 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type WalletInviteRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *WalletInviteRepository) Create(
+	ctx context.Context,
+	token string,
+	userID, subscriptionID int64,
+	role storage.WalletRole,
+	expiresAt time.Time,
+) (bool, error) {
+	result, err := r.conn.ExecContext(ctx, r.conn.Rebind(`INSERT INTO wallet_invites (
+		token,
+		wallet_id,
+		created_by,
+		role,
+		expires_at
+	) SELECT ?, wallet_id, user_id, ?, ? FROM user_wallets
+	WHERE id = ? AND user_id = ? AND role = ?`),
+		token,
+		role,
+		expiresAt.UTC(),
+		subscriptionID,
+		userID,
+		storage.WalletOwnerRole,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create user (id: %d) wallet (id: %d) invite: %w", userID, subscriptionID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check user (id: %d) wallet (id: %d) invite: %w", userID, subscriptionID, err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *WalletInviteRepository) Find(ctx context.Context, token string) (*storage.WalletInviteDB, error) {
+	var invite storage.WalletInviteDB
+	err := r.conn.GetContext(ctx, &invite, r.conn.Rebind(`SELECT
+		wallet_invites.token,
+		wallet_invites.wallet_id,
+		wallet_invites.role,
+		wallets.blockchain_coin,
+		wallets.wallet,
+		wallet_invites.created_by,
+		users.chat_id AS creator_chat_id,
+		users.lang AS creator_lang,
+		wallet_invites.expires_at
+	FROM wallet_invites
+	INNER JOIN wallets ON wallets.id = wallet_invites.wallet_id
+	INNER JOIN users ON users.id = wallet_invites.created_by
+	WHERE wallet_invites.token = ? AND wallet_invites.expires_at > CURRENT_TIMESTAMP`), token)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find wallet invite: %w", err)
+	}
+
+	return &invite, nil
+}
+
+func (r *WalletInviteRepository) Accept(ctx context.Context, userID int64, invite *storage.WalletInviteDB) (storage.WalletInviteAcceptStatus, error) {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create transaction to accept wallet invite: %w", err)
+	}
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, tx.Rebind("SELECT EXISTS(SELECT 1 FROM user_wallets WHERE user_id = ? AND wallet_id = ?)"), userID, invite.WalletID); err != nil {
+		tx.Rollback()
+
+		return "", fmt.Errorf("failed to check user (id: %d) wallet (id: %d) before invite acceptance: %w", userID, invite.WalletID, err)
+	}
+
+	if exists {
+		tx.Rollback()
+
+		return storage.WalletInviteAlreadyAdded, nil
+	}
+
+	result, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM wallet_invites WHERE token = ? AND expires_at > CURRENT_TIMESTAMP"), invite.Token)
+	if err != nil {
+		tx.Rollback()
+
+		return "", fmt.Errorf("failed to remove accepted wallet (id: %d) invite: %w", invite.WalletID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+
+		return "", fmt.Errorf("failed to check removed wallet (id: %d) invite: %w", invite.WalletID, err)
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+
+		return storage.WalletInviteUsed, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO user_wallets (
+		user_id,
+		wallet_id,
+		role
+	) VALUES (?, ?, ?)`), userID, invite.WalletID, invite.Role); err != nil {
+		tx.Rollback()
+
+		return "", fmt.Errorf("failed to accept user (id: %d) wallet (id: %d) invite: %w", userID, invite.WalletID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit wallet invite acceptance: %w", err)
+	}
+
+	return storage.WalletInviteAccepted, nil
+}
+
+func NewWalletInviteRepository(conn *sqlx.DB) *WalletInviteRepository {
+	return &WalletInviteRepository{
+		conn: conn,
+	}
+}
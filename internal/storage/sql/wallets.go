@@ -0,0 +1,323 @@
+package sqlStorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+const userWalletsColumns = `user_wallets.id,
+		wallets.blockchain_coin,
+		wallets.wallet,
+		COALESCE(user_wallets.label, '') AS label,
+		user_wallets.role,
+		user_wallets.notify,
+		user_wallets.paused,
+		user_wallets.added_at`
+
+type workerAliasDB struct {
+	SubscriptionID int64  `db:"subscription_id"`
+	Worker         string `db:"worker"`
+	Alias          string `db:"alias"`
+}
+
+type WalletRepository struct {
+	conn *sqlx.DB
+}
+
+func (r *WalletRepository) Find(ctx context.Context, userID, id int64) (*storage.UserWalletDB, error) {
+	var wallet storage.UserWalletDB
+	err := r.conn.GetContext(ctx, &wallet, r.conn.Rebind(`SELECT `+userWalletsColumns+`
+	FROM user_wallets
+	INNER JOIN wallets ON wallets.id = user_wallets.wallet_id
+	WHERE user_wallets.id = ? AND user_wallets.user_id = ?`), id, userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) wallet (id: %d): %w", userID, id, err)
+	}
+
+	return &wallet, nil
+}
+
+func (r *WalletRepository) FindAll(ctx context.Context, userID int64) ([]storage.UserWalletDB, error) {
+	wallets := []storage.UserWalletDB{}
+	//	CURRENT_TIMESTAMP has seconds precision in SQLite, so wallets added in the same second are ordered by id
+	if err := r.conn.SelectContext(ctx, &wallets, r.conn.Rebind(`SELECT `+userWalletsColumns+`
+	FROM user_wallets
+	INNER JOIN wallets ON wallets.id = user_wallets.wallet_id
+	WHERE user_wallets.user_id = ?
+	ORDER BY wallets.blockchain_coin, user_wallets.added_at, user_wallets.id`), userID); err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) wallets: %w", userID, err)
+	}
+
+	return wallets, nil
+}
+
+func (r *WalletRepository) Count(ctx context.Context, userID int64, coin string) (int, error) {
+	var count int
+	if err := r.conn.GetContext(ctx, &count, r.conn.Rebind(`SELECT COUNT(*) 
+		FROM user_wallets 
+		INNER JOIN wallets ON wallets.id = user_wallets.wallet_id 
+		WHERE user_wallets.user_id = ? AND wallets.blockchain_coin = ?`), userID, coin); err != nil {
+		return 0, fmt.Errorf("failed to count user wallets (id: %d, coin: %s), error: %w", userID, coin, err)
+	}
+
+	return count, nil
+}
+
+func (r *WalletRepository) Exists(ctx context.Context, userID int64, coin, wallet string) (bool, error) {
+	var count int
+	if err := r.conn.GetContext(ctx, &count, r.conn.Rebind(`SELECT COUNT(*) 
+		FROM user_wallets 
+		INNER JOIN wallets ON wallets.id = user_wallets.wallet_id 
+		WHERE user_wallets.user_id = ? AND wallets.blockchain_coin = ? AND wallets.wallet = ?`),
+		userID, coin, wallet); err != nil {
+		return false, fmt.Errorf("failed to count duplicate user wallets (id: %d, coin: %s, wallet: %s), error: %w", userID, coin, wallet, err)
+	}
+
+	return count > 0, nil
+}
+
+func upsertWallet(ctx context.Context, tx *sqlx.Tx, coin, wallet string) (int64, error) {
+	//	Same wallet is stored once and shared between all subscribed users
+	var walletID int64
+	if err := tx.GetContext(ctx, &walletID, tx.Rebind(`INSERT INTO wallets (blockchain_coin, wallet) VALUES (?, ?)
+		ON CONFLICT (blockchain_coin, wallet) DO UPDATE SET wallet = EXCLUDED.wallet
+		RETURNING id`), coin, wallet); err != nil {
+		return 0, fmt.Errorf("failed to add wallet (coin: %s, wallet: %s), error: %w", coin, wallet, err)
+	}
+
+	return walletID, nil
+}
+
+func deleteUnusedWallet(ctx context.Context, tx *sqlx.Tx, walletID int64) error {
+	//	Wallet without subscribers is not tracked anymore
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM wallets 
+		WHERE id = ? AND NOT EXISTS (SELECT 1 FROM user_wallets WHERE wallet_id = ?)`), walletID, walletID); err != nil {
+		return fmt.Errorf("failed to remove unused wallet (id: %d), error: %w", walletID, err)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) Add(ctx context.Context, userID int64, coin, wallet, label string) error {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction to add user wallet: %w", err)
+	}
+
+	walletID, err := upsertWallet(ctx, tx, coin, wallet)
+	if err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO user_wallets (user_id, wallet_id, role, label) VALUES (?, ?, ?, NULLIF(?, ''))`),
+		userID, walletID, storage.WalletOwnerRole, label); err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("failed to add user wallet (id: %d, coin: %s,  wallet: %s), error: %w", userID, coin, wallet, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user wallet (id: %d, coin: %s, wallet: %s), error: %w", userID, coin, wallet, err)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) Remove(ctx context.Context, id int64) error {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction to remove user wallet: %w", err)
+	}
+
+	var walletID int64
+	if err := tx.GetContext(ctx, &walletID, tx.Rebind(`DELETE FROM user_wallets WHERE id = ? RETURNING wallet_id`), id); err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("failed to remove user wallet (id: %d), error: %w", id, err)
+	}
+
+	if err := deleteUnusedWallet(ctx, tx, walletID); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user wallet (id: %d) removal, error: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) Restore(ctx context.Context, userID int64, wallet *storage.UserWalletDB, aliases map[string]string) error {
+	tx, err := r.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction to restore user wallet: %w", err)
+	}
+
+	walletID, err := upsertWallet(ctx, tx, wallet.Coin, wallet.Wallet)
+	if err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	//	Wallet could be added again before undo, then nothing is restored
+	var id int64
+	err = tx.GetContext(ctx, &id, tx.Rebind(`INSERT INTO user_wallets (
+		user_id, 
+		wallet_id, 
+		role, 
+		label, 
+		notify, 
+		paused
+	) VALUES (?, ?, ?, NULLIF(?, ''), ?, ?)
+	ON CONFLICT (user_id, wallet_id) DO NOTHING
+	RETURNING id`), userID, walletID, wallet.Role, wallet.Label, wallet.Notify, wallet.Paused)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+
+		return nil
+	} else if err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("failed to restore user wallet (id: %d, coin: %s, wallet: %s), error: %w", userID, wallet.Coin, wallet.Wallet, err)
+	}
+
+	for worker, alias := range aliases {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO worker_aliases (wallet_id, worker, alias) VALUES (?, ?, ?)`), id, worker, alias); err != nil {
+			tx.Rollback()
+
+			return fmt.Errorf("failed to restore user wallet (id: %d) worker alias: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user wallet (id: %d, coin: %s, wallet: %s) restore, error: %w", userID, wallet.Coin, wallet.Wallet, err)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) SetLabel(ctx context.Context, userID, id int64, label string) error {
+	//	Empty label removes wallet name
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE user_wallets SET label = NULLIF(?, '') 
+		WHERE id = ? AND user_id = ?`), label, id, userID); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) wallet (id: %d) label: %w", userID, id, err)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) SetNotify(ctx context.Context, userID, id int64, notify bool) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE user_wallets SET notify = ? 
+		WHERE id = ? AND user_id = ?`), notify, id, userID); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) wallet (id: %d) notify: %w", userID, id, err)
+	}
+
+	return nil
+}
+
+func (r *WalletRepository) SetPaused(ctx context.Context, userID, id int64, paused bool) error {
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`UPDATE user_wallets SET paused = ? 
+		WHERE id = ? AND user_id = ?`), paused, id, userID); err != nil {
+		return fmt.Errorf("failed to update user (id: %d) wallet (id: %d) paused: %w", userID, id, err)
+	}
+
+	return nil
+}
+
+func aliasesMap(workerAliases []workerAliasDB) map[int64]map[string]string {
+	aliasesMap := make(map[int64]map[string]string)
+	for _, workerAlias := range workerAliases {
+		if _, ok := aliasesMap[workerAlias.SubscriptionID]; !ok {
+			aliasesMap[workerAlias.SubscriptionID] = make(map[string]string)
+		}
+
+		aliasesMap[workerAlias.SubscriptionID][workerAlias.Worker] = workerAlias.Alias
+	}
+
+	return aliasesMap
+}
+
+func (r *WalletRepository) FindAliases(ctx context.Context, userID int64) (map[int64]map[string]string, error) {
+	workerAliases := []workerAliasDB{}
+	if err := r.conn.SelectContext(ctx, &workerAliases, r.conn.Rebind(`SELECT
+		worker_aliases.wallet_id AS subscription_id,
+		worker_aliases.worker,
+		worker_aliases.alias
+	FROM worker_aliases
+	INNER JOIN user_wallets ON user_wallets.id = worker_aliases.wallet_id
+	WHERE user_wallets.user_id = ?`), userID); err != nil {
+		return nil, fmt.Errorf("failed to query user (id: %d) worker aliases: %w", userID, err)
+	}
+
+	return aliasesMap(workerAliases), nil
+}
+
+func (r *WalletRepository) FindAllAliases(ctx context.Context) (map[int64]map[string]string, error) {
+	workerAliases := []workerAliasDB{}
+	if err := r.conn.SelectContext(ctx, &workerAliases, `SELECT
+		wallet_id AS subscription_id,
+		worker,
+		alias
+	FROM worker_aliases`); err != nil {
+		return nil, fmt.Errorf("failed to get worker aliases: %w", err)
+	}
+
+	return aliasesMap(workerAliases), nil
+}
+
+func (r *WalletRepository) FindWorkerAliases(ctx context.Context, userID, id int64) ([]storage.WorkerAliasDB, error) {
+	aliases := []storage.WorkerAliasDB{}
+	if err := r.conn.SelectContext(ctx, &aliases, r.conn.Rebind(`SELECT
+		wallet_workers.worker,
+		COALESCE(worker_aliases.alias, '') AS alias
+	FROM user_wallets
+	INNER JOIN wallet_workers ON wallet_workers.wallet_id = user_wallets.wallet_id
+	LEFT JOIN worker_aliases ON worker_aliases.wallet_id = user_wallets.id AND worker_aliases.worker = wallet_workers.worker
+	WHERE user_wallets.id = ? AND user_wallets.user_id = ?
+	ORDER BY wallet_workers.worker`), id, userID); err != nil {
+		return nil, fmt.Errorf("failed to find user (id: %d) wallet (id: %d) workers: %w", userID, id, err)
+	}
+
+	return aliases, nil
+}
+
+func (r *WalletRepository) SetWorkerAlias(ctx context.Context, userID, id int64, worker, alias string) error {
+	if alias == "" {
+		if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`DELETE FROM worker_aliases
+			WHERE wallet_id = ? 
+				AND worker = ? 
+				AND wallet_id IN (SELECT id FROM user_wallets WHERE user_id = ?)`), id, worker, userID); err != nil {
+			return fmt.Errorf("failed to remove user (id: %d) wallet (id: %d) worker alias: %w", userID, id, err)
+		}
+
+		return nil
+	}
+
+	if _, err := r.conn.ExecContext(ctx, r.conn.Rebind(`INSERT INTO worker_aliases (
+		wallet_id,
+		worker,
+		alias
+	) SELECT id, ?, ? FROM user_wallets WHERE id = ? AND user_id = ?
+	ON CONFLICT (wallet_id, worker) DO UPDATE SET alias = EXCLUDED.alias`), worker, alias, id, userID); err != nil {
+		return fmt.Errorf("failed to set user (id: %d) wallet (id: %d) worker alias: %w", userID, id, err)
+	}
+
+	return nil
+}
+
+func NewWalletRepository(conn *sqlx.DB) *WalletRepository {
+	return &WalletRepository{
+		conn: conn,
+	}
+}
@@ -0,0 +1,17 @@
+package storage
+
+// Storage groups repositories of all aggregates, services depend only on these interfaces
+type Storage struct {
+	Users         UserRepository
+	UserActions   UserActionRepository
+	Wallets       WalletRepository
+	WalletInvites WalletInviteRepository
+	ChatTargets   ChatTargetRepository
+	Support       SupportRepository
+	Admins        AdminRepository
+	BotMetadata   BotMetadataRepository
+	Privacy       PrivacyRepository
+	Broadcasts    BroadcastRepository
+	Blockchains   BlockchainRepository
+	Notify        NotifyRepository
+}
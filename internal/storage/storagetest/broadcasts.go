@@ -0,0 +1,171 @@
+package storageTest
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+func testBroadcasts(s *suite) {
+	admin := s.createUser(1)
+	miner := s.createUser(2)
+	optedOut := s.createUser(3)
+	inactive := s.createUser(4)
+	s.db.AddAdmin(admin.ID)
+
+	s.addWallet(miner.ID, OTHER_TEST_COIN, "miner", "")
+	s.addWallet(optedOut.ID, OTHER_TEST_COIN, "opted-out", "")
+	s.noError(s.store.Users.SetBroadcastsNotify(s.ctx, optedOut.ID, false), "opt out from broadcasts")
+	s.noError(s.store.Privacy.Deactivate(s.ctx, inactive.ChatID, "blocked"), "deactivate user")
+
+	if draft, err := s.store.Broadcasts.FindDraft(s.ctx, admin.ID); err != nil || draft != nil {
+		s.t.Fatalf("expected no draft, got %+v and error %v", draft, err)
+	}
+
+	cancelled, err := s.store.Broadcasts.CreateDraft(s.ctx, admin.ID)
+	s.noError(err, "create draft")
+
+	//	New draft cancels previous one
+	draft, err := s.store.Broadcasts.CreateDraft(s.ctx, admin.ID)
+	s.noError(err, "create draft")
+	if draft.ID == cancelled.ID || draft.CreatedBy != admin.ID || draft.Status != storage.BroadcastDraft ||
+		draft.DefaultLang != nil || draft.StartedAt != nil || len(draft.Messages) != 0 || len(draft.Coins) != 0 {
+		s.t.Fatalf("expected empty draft, got %+v", draft)
+	}
+
+	found, err := s.store.Broadcasts.Find(s.ctx, cancelled.ID)
+	s.noError(err, "find broadcast")
+	if found == nil || found.Status != storage.BroadcastCancelled {
+		s.t.Fatalf("expected previous draft to be cancelled, got %+v", found)
+	}
+
+	found, err = s.store.Broadcasts.FindDraft(s.ctx, admin.ID)
+	s.noError(err, "find draft")
+	if found == nil || found.ID != draft.ID {
+		s.t.Fatalf("expected draft %d, got %+v", draft.ID, found)
+	}
+
+	//	First message language is default one
+	s.noError(s.store.Broadcasts.SetMessage(s.ctx, draft.ID, "en", "Hello"), "set message")
+	s.noError(s.store.Broadcasts.SetMessage(s.ctx, draft.ID, "ru", "Привет"), "set message")
+	s.noError(s.store.Broadcasts.SetMessage(s.ctx, draft.ID, "en", "Hello miners"), "replace message")
+	s.noError(s.store.Broadcasts.SetCoins(s.ctx, draft.ID, []string{TEST_COIN}), "set coins")
+
+	found, err = s.store.Broadcasts.Find(s.ctx, draft.ID)
+	s.noError(err, "find broadcast")
+	if found.DefaultLang == nil || *found.DefaultLang != "en" ||
+		!maps.Equal(found.Messages, map[string]string{"en": "Hello miners", "ru": "Привет"}) ||
+		!slices.Equal(found.Coins, []string{TEST_COIN}) {
+		s.t.Fatalf("expected draft with messages and coins, got %+v", found)
+	}
+
+	//	Only users with wallets on broadcast coins receive it
+	count, err := s.store.Broadcasts.CountRecipients(s.ctx, draft.ID)
+	s.noError(err, "count recipients")
+	if count != 0 {
+		s.t.Fatalf("expected no recipients of %s broadcast, got %d", TEST_COIN, count)
+	}
+
+	s.noError(s.store.Broadcasts.SetCoins(s.ctx, draft.ID, []string{OTHER_TEST_COIN, TEST_COIN}), "set coins")
+
+	found, err = s.store.Broadcasts.Find(s.ctx, draft.ID)
+	s.noError(err, "find broadcast")
+	if !slices.Equal(found.Coins, []string{TEST_COIN, OTHER_TEST_COIN}) {
+		s.t.Fatalf("expected coins to be replaced and ordered, got %v", found.Coins)
+	}
+
+	count, err = s.store.Broadcasts.CountRecipients(s.ctx, draft.ID)
+	s.noError(err, "count recipients")
+	if count != 1 {
+		s.t.Fatalf("expected 1 recipient of coins broadcast, got %d", count)
+	}
+
+	s.noError(s.store.Broadcasts.SetCoins(s.ctx, draft.ID, nil), "clear coins")
+
+	count, err = s.store.Broadcasts.CountRecipients(s.ctx, draft.ID)
+	s.noError(err, "count recipients")
+	if count != 2 {
+		s.t.Fatalf("expected 2 recipients of broadcast to everyone, got %d", count)
+	}
+
+	recipients, started, err := s.store.Broadcasts.Start(s.ctx, draft.ID)
+	s.noError(err, "start broadcast")
+	if !started || recipients != 2 {
+		s.t.Fatalf("expected broadcast to be started for 2 recipients, got %t and %d", started, recipients)
+	}
+
+	if _, started, err := s.store.Broadcasts.Start(s.ctx, draft.ID); err != nil || started {
+		s.t.Fatalf("expected broadcast to not be started twice, got %t and error %v", started, err)
+	}
+
+	if _, started, err := s.store.Broadcasts.Start(s.ctx, cancelled.ID); err != nil || started {
+		s.t.Fatalf("expected cancelled broadcast to not be started, got %t and error %v", started, err)
+	}
+
+	ids, err := s.store.Broadcasts.FindSendingIDs(s.ctx)
+	s.noError(err, "find sending broadcasts")
+	if !slices.Equal(ids, []int64{draft.ID}) {
+		s.t.Fatalf("expected sending broadcast %d, got %v", draft.ID, ids)
+	}
+
+	if found, err := s.store.Broadcasts.FindDraft(s.ctx, admin.ID); err != nil || found != nil {
+		s.t.Fatalf("expected no draft after start, got %+v and error %v", found, err)
+	}
+
+	found, err = s.store.Broadcasts.FindLatest(s.ctx, admin.ID)
+	s.noError(err, "find latest broadcast")
+	if found == nil || found.ID != draft.ID || found.Status != storage.BroadcastSending || found.StartedAt == nil {
+		s.t.Fatalf("expected latest broadcast to be sending, got %+v", found)
+	}
+
+	//	Deliveries are paginated by user id
+	deliveries, err := s.store.Broadcasts.FindPendingDeliveries(s.ctx, draft.ID, 1)
+	s.noError(err, "find pending deliveries")
+	if !slices.Equal(deliveries, []storage.DeliveryDB{{
+		UserID:           admin.ID,
+		ChatID:           admin.ChatID,
+		Lang:             admin.Lang,
+		BroadcastsNotify: true,
+		Active:           true,
+	}}) {
+		s.t.Fatalf("expected pending delivery of admin, got %+v", deliveries)
+	}
+
+	errText := "bot was blocked by the user"
+	s.noError(s.store.Broadcasts.SetDeliveryStatus(s.ctx, draft.ID, admin.ID, storage.DeliverySent, nil), "set delivery status")
+	s.noError(s.store.Broadcasts.SetDeliveryStatus(s.ctx, draft.ID, miner.ID, storage.DeliveryFailed, &errText), "set delivery status")
+
+	deliveries, err = s.store.Broadcasts.FindPendingDeliveries(s.ctx, draft.ID, 10)
+	s.noError(err, "find pending deliveries")
+	if len(deliveries) != 0 {
+		s.t.Fatalf("expected no pending deliveries, got %+v", deliveries)
+	}
+
+	stats, err := s.store.Broadcasts.Stats(s.ctx, draft.ID)
+	s.noError(err, "get delivery stats")
+	if *stats != (storage.DeliveryStats{Sent: 1, Failed: 1}) {
+		s.t.Fatalf("expected one sent and one failed delivery, got %+v", stats)
+	}
+
+	s.noError(s.store.Broadcasts.Finish(s.ctx, draft.ID), "finish broadcast")
+
+	found, err = s.store.Broadcasts.Find(s.ctx, draft.ID)
+	s.noError(err, "find broadcast")
+	if found.Status != storage.BroadcastSent || found.FinishedAt == nil {
+		s.t.Fatalf("expected broadcast to be sent, got %+v", found)
+	}
+
+	//	Only drafts are cancelled
+	s.noError(s.store.Broadcasts.Cancel(s.ctx, draft.ID), "cancel broadcast")
+
+	found, err = s.store.Broadcasts.Find(s.ctx, draft.ID)
+	s.noError(err, "find broadcast")
+	if found.Status != storage.BroadcastSent {
+		s.t.Fatalf("expected sent broadcast to not be cancelled, got %+v", found)
+	}
+
+	if ids, err := s.store.Broadcasts.FindSendingIDs(s.ctx); err != nil || len(ids) != 0 {
+		s.t.Fatalf("expected no sending broadcasts, got %v and error %v", ids, err)
+	}
+}
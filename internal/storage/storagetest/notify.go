@@ -0,0 +1,136 @@
+package storageTest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+func testNotify(s *suite) {
+	pauses, err := s.store.Notify.FindPauses(s.ctx)
+	s.noError(err, "find pauses")
+	if len(pauses) != 0 {
+		s.t.Fatalf("expected no pauses, got %+v", pauses)
+	}
+
+	pausedAt := time.Now()
+	s.noError(s.store.Notify.AddPause(s.ctx, &storage.NotifyPauseDB{Scope: "all", PausedBy: 1, PausedAt: pausedAt}), "add pause")
+	s.noError(s.store.Notify.AddPause(s.ctx, &storage.NotifyPauseDB{Scope: TEST_COIN, PausedBy: 1, PausedAt: pausedAt}), "add pause")
+
+	//	Existing pause is kept with its author
+	s.noError(s.store.Notify.AddPause(s.ctx, &storage.NotifyPauseDB{Scope: "all", PausedBy: 2, PausedAt: pausedAt.Add(time.Hour)}), "add pause again")
+	s.noError(s.store.Notify.DeletePause(s.ctx, TEST_COIN), "delete pause")
+
+	pauses, err = s.store.Notify.FindPauses(s.ctx)
+	s.noError(err, "find pauses")
+	if len(pauses) != 1 || pauses[0].Scope != "all" || pauses[0].PausedBy != 1 || !sameTime(pauses[0].PausedAt, pausedAt) {
+		s.t.Fatalf("expected only first pause of all notifications, got %+v", pauses)
+	}
+
+	if check, err := s.store.Notify.FindLastPayoutsCheck(s.ctx); err != nil || check != nil {
+		s.t.Fatalf("expected no payouts check, got %v and error %v", check, err)
+	}
+
+	lastCheck := time.Now().Add(-time.Minute)
+	s.noError(s.store.Notify.AddPayoutsCheck(s.ctx, lastCheck), "add payouts check")
+	s.noError(s.store.Notify.AddPayoutsCheck(s.ctx, lastCheck.Add(-time.Hour)), "add payouts check")
+
+	check, err := s.store.Notify.FindLastPayoutsCheck(s.ctx)
+	s.noError(err, "find last payouts check")
+	if check == nil || !sameTime(*check, lastCheck) {
+		s.t.Fatalf("expected last payouts check at %v, got %v", lastCheck, check)
+	}
+
+	user := s.createUser(1)
+	other := s.createUser(2)
+	inactive := s.createUser(3)
+
+	wallet := s.addWallet(user.ID, TEST_COIN, "shared", "Rig farm")
+	otherWallet := s.addWallet(other.ID, TEST_COIN, "shared", "")
+	paused := s.addWallet(other.ID, OTHER_TEST_COIN, "paused", "")
+	s.addWallet(inactive.ID, TEST_COIN, "inactive", "")
+
+	s.noError(s.store.Wallets.SetPaused(s.ctx, other.ID, paused.ID, true), "pause wallet")
+	s.noError(s.store.Wallets.SetNotify(s.ctx, other.ID, otherWallet.ID, false), "disable wallet notify")
+	s.noError(s.store.Users.SetShortWallets(s.ctx, user.ID, true), "set short wallets")
+	s.noError(s.store.Privacy.Deactivate(s.ctx, inactive.ChatID, "blocked"), "deactivate user")
+
+	//	Subscriptions of paused wallets and inactive users are skipped
+	subscriptions, err := s.store.Notify.FindSubscriptions(s.ctx)
+	s.noError(err, "find subscriptions")
+
+	slices.SortFunc(subscriptions, func(a, b storage.WalletSubscriptionDB) int {
+		return int(a.SubscriptionID - b.SubscriptionID)
+	})
+
+	if len(subscriptions) != 2 {
+		s.t.Fatalf("expected 2 subscriptions, got %+v", subscriptions)
+	}
+
+	expected := storage.WalletSubscriptionDB{
+		WalletID:       subscriptions[0].WalletID,
+		Coin:           TEST_COIN,
+		Wallet:         "shared",
+		SubscriptionID: wallet.ID,
+		Label:          "Rig farm",
+		Notify:         true,
+		UserID:         user.ID,
+		ChatID:         user.ChatID,
+		Lang:           user.Lang,
+		ShortWallets:   true,
+		PayoutsNotify:  true,
+		BlocksNotify:   true,
+	}
+	if subscriptions[0] != expected {
+		s.t.Fatalf("expected subscription %+v, got %+v", expected, subscriptions[0])
+	}
+
+	if subscriptions[1].SubscriptionID != otherWallet.ID || subscriptions[1].WalletID != expected.WalletID || subscriptions[1].Notify {
+		s.t.Fatalf("expected subscription of same wallet without notify, got %+v", subscriptions[1])
+	}
+}
+
+func testNotifyWorkers(s *suite) {
+	user := s.createUser(1)
+	walletID := s.walletID(s.addWallet(user.ID, TEST_COIN, "first", "").ID)
+	otherWalletID := s.walletID(s.addWallet(user.ID, OTHER_TEST_COIN, "second", "").ID)
+
+	connectedAt := time.Now().Add(-time.Hour)
+	rig1 := storage.WalletWorkerDB{WalletID: walletID, Worker: "rig1", Region: "eu", ConnectedAt: connectedAt}
+	rig2 := storage.WalletWorkerDB{WalletID: walletID, Worker: "rig2", Region: "us", Solo: true, ConnectedAt: connectedAt}
+	rig3 := storage.WalletWorkerDB{WalletID: otherWalletID, Worker: "rig1", Region: "asia", ConnectedAt: connectedAt}
+
+	s.noError(s.store.Notify.UpdateWorkers(s.ctx, nil, nil, 2), "update workers without changes")
+	s.noError(s.store.Notify.UpdateWorkers(s.ctx, []storage.WalletWorkerDB{rig1, rig2, rig3}, nil, 2), "add workers")
+
+	//	Changes are applied atomically, so duplicate worker leaves workers as they were
+	rig4 := storage.WalletWorkerDB{WalletID: walletID, Worker: "rig4", Region: "eu", ConnectedAt: connectedAt}
+	if err := s.store.Notify.UpdateWorkers(s.ctx, []storage.WalletWorkerDB{rig4, rig1}, nil, 1); err == nil {
+		s.t.Fatalf("expected error on adding same worker twice")
+	}
+
+	s.noError(s.store.Notify.UpdateWorkers(s.ctx, nil, []storage.WalletWorkerDB{rig1, rig3}, 1), "remove workers")
+
+	workers, err := s.store.Notify.FindWorkers(s.ctx)
+	s.noError(err, "find workers")
+	if len(workers) != 1 {
+		s.t.Fatalf("expected only one worker to be left, got %+v", workers)
+	}
+
+	if workers[0].WalletID != rig2.WalletID || workers[0].Worker != rig2.Worker || workers[0].Region != rig2.Region ||
+		!workers[0].Solo || !sameTime(workers[0].ConnectedAt, connectedAt) {
+		s.t.Fatalf("expected worker %+v, got %+v", rig2, workers[0])
+	}
+
+	//	Workers are removed with wallet when last subscriber leaves
+	wallets, err := s.store.Wallets.FindAll(s.ctx, user.ID)
+	s.noError(err, "find wallets")
+	s.noError(s.store.Wallets.Remove(s.ctx, wallets[0].ID), "remove wallet")
+
+	workers, err = s.store.Notify.FindWorkers(s.ctx)
+	s.noError(err, "find workers")
+	if len(workers) != 0 {
+		s.t.Fatalf("expected workers of removed wallet to be removed, got %+v", workers)
+	}
+}
@@ -0,0 +1,255 @@
+package storageTest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+func testPrivacyExport(s *suite) {
+	if export, err := s.store.Privacy.Export(s.ctx, 1); err != nil || export != nil {
+		s.t.Fatalf("expected no export of missing user, got %+v and error %v", export, err)
+	}
+
+	user := s.createUser(1)
+	other := s.createUser(2)
+	s.db.AddAdmin(user.ID)
+
+	payload := "btc"
+	s.noError(s.store.UserActions.Set(s.ctx, user.ID, storage.UserAddWalletAction, &payload), "set user action")
+
+	wallet := s.addWallet(user.ID, TEST_COIN, "shared", "Rig farm")
+	viewer := s.addWallet(user.ID, OTHER_TEST_COIN, "viewer", "")
+	s.addWallet(other.ID, TEST_COIN, "other", "")
+
+	connectedAt := time.Now().Add(-time.Hour)
+	s.noError(s.store.Notify.UpdateWorkers(s.ctx, []storage.WalletWorkerDB{
+		{WalletID: s.walletID(wallet.ID), Worker: "rig2", Region: "us", ConnectedAt: connectedAt},
+		{WalletID: s.walletID(wallet.ID), Worker: "rig1", Region: "eu", Solo: true, ConnectedAt: connectedAt},
+	}, nil, 10), "add workers")
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, user.ID, wallet.ID, "rig1", "Basement"), "set worker alias")
+
+	target, err := s.store.ChatTargets.Link(s.ctx, &storage.ChatTargetDB{UserID: user.ID, ChatID: -100, ChatType: "group", Title: "Miners"})
+	s.noError(err, "link chat target")
+	s.noError(s.store.ChatTargets.SetWallet(s.ctx, target.ID, viewer.ID, true), "enable chat target wallet")
+	s.noError(s.store.ChatTargets.SetWallet(s.ctx, target.ID, wallet.ID, true), "enable chat target wallet")
+
+	ticket, _, err := s.store.Support.Create(s.ctx, user.ID, &storage.SupportMessage{
+		Direction: storage.SupportMessageFromUser,
+		AuthorID:  user.ID,
+		Text:      "Payout is missing",
+	})
+	s.noError(err, "create ticket")
+
+	attachmentType := "photo"
+	_, err = s.store.Support.AddMessage(s.ctx, ticket.ID, &storage.SupportMessage{
+		Direction:      storage.SupportMessageFromSupport,
+		AuthorID:       99,
+		Text:           "Payout is sent",
+		AttachmentType: &attachmentType,
+	}, storage.SupportTicketAnswered)
+	s.noError(err, "add support message")
+
+	expiresAt := time.Now().Add(time.Hour)
+	_, err = s.store.WalletInvites.Create(s.ctx, "token", user.ID, wallet.ID, storage.WalletViewerRole, expiresAt)
+	s.noError(err, "create invite")
+
+	broadcast, err := s.store.Broadcasts.CreateDraft(s.ctx, user.ID)
+	s.noError(err, "create draft")
+	_, _, err = s.store.Broadcasts.Start(s.ctx, broadcast.ID)
+	s.noError(err, "start broadcast")
+	s.noError(s.store.Broadcasts.SetDeliveryStatus(s.ctx, broadcast.ID, user.ID, storage.DeliverySent, nil), "set delivery status")
+
+	export, err := s.store.Privacy.Export(s.ctx, user.ID)
+	s.noError(err, "export user data")
+	if export == nil {
+		s.t.Fatalf("user data is not exported")
+	}
+
+	if export.User != (storage.ExportUser{
+		ID:               user.ID,
+		ChatID:           user.ChatID,
+		Lang:             user.Lang,
+		Username:         export.User.Username,
+		PayoutsNotify:    true,
+		BlocksNotify:     true,
+		BroadcastsNotify: true,
+		Active:           true,
+		Admin:            true,
+	}) || export.User.Username == nil || *export.User.Username != *user.Username {
+		s.t.Fatalf("expected exported user %+v, got %+v", user, export.User)
+	}
+
+	if export.Action == nil || export.Action.Action != string(storage.UserAddWalletAction) || export.Action.Payload == nil || *export.Action.Payload != payload {
+		s.t.Fatalf("expected exported user action, got %+v", export.Action)
+	}
+
+	//	Wallets are exported in order they were added
+	if len(export.Wallets) != 2 || export.Wallets[0].ID != wallet.ID || export.Wallets[1].ID != viewer.ID {
+		s.t.Fatalf("expected 2 exported wallets, got %+v", export.Wallets)
+	}
+
+	exported := export.Wallets[0]
+	if exported.Coin != TEST_COIN || exported.Wallet != "shared" || exported.Label == nil || *exported.Label != "Rig farm" ||
+		exported.Role != string(storage.WalletOwnerRole) || !exported.Notify || exported.Paused || len(exported.Workers) != 2 {
+		s.t.Fatalf("expected exported wallet %+v, got %+v", wallet, exported)
+	}
+
+	if export.Wallets[1].Label != nil || len(export.Wallets[1].Workers) != 0 {
+		s.t.Fatalf("expected exported wallet without label and workers, got %+v", export.Wallets[1])
+	}
+
+	rig1, rig2 := exported.Workers[0], exported.Workers[1]
+	if rig1.Worker != "rig1" || rig1.Alias == nil || *rig1.Alias != "Basement" || rig1.Region != "eu" || !rig1.Solo || !sameTime(rig1.ConnectedAt, connectedAt) ||
+		rig2.Worker != "rig2" || rig2.Alias != nil {
+		s.t.Fatalf("expected exported workers ordered by name with aliases, got %+v", exported.Workers)
+	}
+
+	if len(export.ChatTargets) != 1 || export.ChatTargets[0].ID != target.ID || export.ChatTargets[0].Title != "Miners" ||
+		!slices.Equal(export.ChatTargets[0].WalletIDs, sortedIDs([]int64{wallet.ID, viewer.ID})) {
+		s.t.Fatalf("expected exported chat target with wallets, got %+v", export.ChatTargets)
+	}
+
+	if len(export.Tickets) != 1 || export.Tickets[0].ID != ticket.ID || export.Tickets[0].Status != string(storage.SupportTicketAnswered) {
+		s.t.Fatalf("expected exported ticket, got %+v", export.Tickets)
+	}
+
+	messages := export.Tickets[0].Messages
+	if len(messages) != 2 || messages[0].Direction != string(storage.SupportMessageFromUser) || messages[0].Text != "Payout is missing" ||
+		messages[1].Direction != string(storage.SupportMessageFromSupport) || messages[1].AttachmentType == nil || *messages[1].AttachmentType != attachmentType {
+		s.t.Fatalf("expected exported ticket messages in order they were sent, got %+v", messages)
+	}
+
+	if len(export.Invites) != 1 || export.Invites[0].Coin != TEST_COIN || export.Invites[0].Wallet != "shared" ||
+		export.Invites[0].Role != string(storage.WalletViewerRole) || !sameTime(export.Invites[0].ExpiresAt, expiresAt) {
+		s.t.Fatalf("expected exported invite, got %+v", export.Invites)
+	}
+
+	if len(export.Deliveries) != 1 || export.Deliveries[0].BroadcastID != broadcast.ID || export.Deliveries[0].Status != string(storage.DeliverySent) {
+		s.t.Fatalf("expected exported delivery, got %+v", export.Deliveries)
+	}
+
+	//	Data of other users is not exported
+	export, err = s.store.Privacy.Export(s.ctx, other.ID)
+	s.noError(err, "export user data")
+	if export.User.Admin || export.Action != nil || len(export.Wallets) != 1 || len(export.ChatTargets) != 0 ||
+		len(export.Tickets) != 0 || len(export.Invites) != 0 || len(export.Deliveries) != 1 {
+		s.t.Fatalf("expected export of other user own data, got %+v", export)
+	}
+}
+
+func testPrivacyDelete(s *suite) {
+	if counts, err := s.store.Privacy.Delete(s.ctx, 1, "user_request"); err != nil || counts != nil {
+		s.t.Fatalf("expected no counts of missing user, got %+v and error %v", counts, err)
+	}
+
+	user := s.createUser(1)
+	other := s.createUser(2)
+	s.db.AddAdmin(user.ID)
+
+	wallet := s.addWallet(user.ID, TEST_COIN, "shared", "")
+	s.addWallet(user.ID, OTHER_TEST_COIN, "own", "")
+	otherWallet := s.addWallet(other.ID, TEST_COIN, "shared", "")
+
+	s.noError(s.store.UserActions.Set(s.ctx, user.ID, storage.ReportBugAction, nil), "set user action")
+	_, err := s.store.WalletInvites.Create(s.ctx, "token", user.ID, wallet.ID, storage.WalletViewerRole, time.Now().Add(time.Hour))
+	s.noError(err, "create invite")
+
+	for _, chatID := range []int64{-100, -200} {
+		_, err := s.store.ChatTargets.Link(s.ctx, &storage.ChatTargetDB{UserID: user.ID, ChatID: chatID, ChatType: "group", Title: "Miners"})
+		s.noError(err, "link chat target")
+	}
+
+	_, _, err = s.store.Support.Create(s.ctx, user.ID, &storage.SupportMessage{
+		Direction: storage.SupportMessageFromUser,
+		AuthorID:  user.ID,
+		Text:      "Delete my data",
+	})
+	s.noError(err, "create ticket")
+
+	counts, err := s.store.Privacy.Delete(s.ctx, user.ID, "user_request")
+	s.noError(err, "delete user data")
+	if counts == nil || *counts != (storage.DeletionCounts{Wallets: 2, ChatTargets: 2, Tickets: 1}) {
+		s.t.Fatalf("expected counts of deleted data, got %+v", counts)
+	}
+
+	if found, err := s.store.Users.Find(s.ctx, user.ID); err != nil || found != nil {
+		s.t.Fatalf("expected user to be deleted, got %+v and error %v", found, err)
+	}
+
+	if isAdmin, err := s.store.Admins.IsAdmin(s.ctx, user.ID); err != nil || isAdmin {
+		s.t.Fatalf("expected admin role to be deleted, got %t and error %v", isAdmin, err)
+	}
+
+	if action, err := s.store.UserActions.Get(s.ctx, user.ID); err != nil || action != nil {
+		s.t.Fatalf("expected user action to be deleted, got %+v and error %v", action, err)
+	}
+
+	if invite, err := s.store.WalletInvites.Find(s.ctx, "token"); err != nil || invite != nil {
+		s.t.Fatalf("expected invite to be deleted, got %+v and error %v", invite, err)
+	}
+
+	//	Shared wallet is kept for other subscriber, own wallet is removed
+	subscriptions, err := s.store.Notify.FindSubscriptions(s.ctx)
+	s.noError(err, "find subscriptions")
+	if len(subscriptions) != 1 || subscriptions[0].SubscriptionID != otherWallet.ID {
+		s.t.Fatalf("expected only subscription of other user, got %+v", subscriptions)
+	}
+
+	wallets, err := s.store.Admins.CountWallets(s.ctx)
+	s.noError(err, "count wallets")
+	if !slices.Equal(wallets, []storage.CoinCountDB{{Coin: TEST_COIN, Count: 1}}) {
+		s.t.Fatalf("expected only shared wallet to be kept, got %+v", wallets)
+	}
+
+	if export, err := s.store.Privacy.Export(s.ctx, user.ID); err != nil || export != nil {
+		s.t.Fatalf("expected no export of deleted user, got %+v and error %v", export, err)
+	}
+}
+
+func testPrivacyActivity(s *suite) {
+	user := s.createUser(1)
+	other := s.createUser(2)
+
+	s.noError(s.store.Privacy.Deactivate(s.ctx, user.ChatID, "blocked"), "deactivate user")
+
+	found, err := s.store.Users.Find(s.ctx, user.ID)
+	s.noError(err, "find user")
+	if found.Active || found.InactiveReason == nil || *found.InactiveReason != "blocked" ||
+		found.InactiveSince == nil || !sameTime(*found.InactiveSince, time.Now()) {
+		s.t.Fatalf("expected user to be deactivated, got %+v", found)
+	}
+
+	//	Inactive user keeps time it was deactivated first
+	s.noError(s.store.Privacy.Deactivate(s.ctx, user.ChatID, "chat_not_found"), "deactivate user again")
+
+	again, err := s.store.Users.Find(s.ctx, user.ID)
+	s.noError(err, "find user")
+	if *again.InactiveReason != "blocked" || !again.InactiveSince.Equal(*found.InactiveSince) {
+		s.t.Fatalf("expected user to keep first deactivation, got %+v", again)
+	}
+
+	ids, err := s.store.Privacy.FindInactive(s.ctx, time.Now().Add(time.Minute))
+	s.noError(err, "find inactive users")
+	if !slices.Equal(ids, []int64{user.ID}) {
+		s.t.Fatalf("expected inactive user %d, got %v", user.ID, ids)
+	}
+
+	if ids, err := s.store.Privacy.FindInactive(s.ctx, time.Now().Add(-time.Hour)); err != nil || len(ids) != 0 {
+		s.t.Fatalf("expected no users inactive for an hour, got %v and error %v", ids, err)
+	}
+
+	s.noError(s.store.Privacy.Reactivate(s.ctx, other.ChatID), "reactivate active user")
+	s.noError(s.store.Privacy.Reactivate(s.ctx, user.ChatID), "reactivate user")
+
+	found, err = s.store.Users.Find(s.ctx, user.ID)
+	s.noError(err, "find user")
+	if !found.Active || found.InactiveReason != nil || found.InactiveSince != nil {
+		s.t.Fatalf("expected user to be reactivated, got %+v", found)
+	}
+
+	if ids, err := s.store.Privacy.FindInactive(s.ctx, time.Now().Add(time.Minute)); err != nil || len(ids) != 0 {
+		s.t.Fatalf("expected no inactive users, got %v and error %v", ids, err)
+	}
+}
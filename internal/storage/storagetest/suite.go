@@ -0,0 +1,171 @@
+package storageTest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+const (
+	TEST_COIN       = "btc"
+	OTHER_TEST_COIN = "ltc"
+	//	Storages keep timestamps with different precision, so times are compared within it
+	TIME_PRECISION = time.Second
+)
+
+// DB is storage under test, blockchains and admins are not written by bot,
+// so they are added by storage itself
+type DB interface {
+	Storage() *storage.Storage
+	AddBlockchain(blockchain storage.BlockchainDB)
+	AddAdmin(userID int64)
+}
+
+// NewDB creates empty storage for each test
+type NewDB func(t *testing.T) DB
+
+type suite struct {
+	t     *testing.T
+	ctx   context.Context
+	db    DB
+	store *storage.Storage
+}
+
+func Blockchain(coin string) storage.BlockchainDB {
+	return storage.BlockchainDB{
+		Coin:          coin,
+		Name:          strings.ToUpper(coin) + " chain",
+		Ticker:        strings.ToUpper(coin),
+		Decimals:      8,
+		ExampleWallet: coin + "examplewallet",
+		PoolAPIDB: storage.PoolAPIDB{
+			URL:      "passthrough:///" + coin,
+			Insecure: true,
+		},
+	}
+}
+
+func (s *suite) noError(err error, action string) {
+	s.t.Helper()
+
+	if err != nil {
+		s.t.Fatalf("failed to %s: %v", action, err)
+	}
+}
+
+func (s *suite) createUser(id int64) *storage.UserDB {
+	s.t.Helper()
+
+	username := fmt.Sprintf("Miner%d", id)
+	user := &storage.UserDB{
+		ID:            id,
+		ChatID:        id * 100,
+		Lang:          "en",
+		PayoutsNotify: true,
+		BlocksNotify:  true,
+		Username:      &username,
+	}
+	s.noError(s.store.Users.Create(s.ctx, user), "create user")
+
+	return user
+}
+
+// addWallet adds wallet to user and returns its subscription
+func (s *suite) addWallet(userID int64, coin, wallet, label string) storage.UserWalletDB {
+	s.t.Helper()
+
+	s.noError(s.store.Wallets.Add(s.ctx, userID, coin, wallet, label), "add wallet")
+
+	return s.findWallet(userID, coin, wallet)
+}
+
+func (s *suite) findWallet(userID int64, coin, wallet string) storage.UserWalletDB {
+	s.t.Helper()
+
+	wallets, err := s.store.Wallets.FindAll(s.ctx, userID)
+	s.noError(err, "find wallets")
+
+	i := slices.IndexFunc(wallets, func(userWallet storage.UserWalletDB) bool {
+		return userWallet.Coin == coin && userWallet.Wallet == wallet
+	})
+	if i == -1 {
+		s.t.Fatalf("wallet %s %s is not found in user %d wallets", coin, wallet, userID)
+	}
+
+	return wallets[i]
+}
+
+// walletID returns id of wallet shared by subscriptions, it's known only from notify subscriptions
+func (s *suite) walletID(subscriptionID int64) int64 {
+	s.t.Helper()
+
+	subscriptions, err := s.store.Notify.FindSubscriptions(s.ctx)
+	s.noError(err, "find subscriptions")
+
+	for _, subscription := range subscriptions {
+		if subscription.SubscriptionID == subscriptionID {
+			return subscription.WalletID
+		}
+	}
+
+	s.t.Fatalf("subscription %d is not found", subscriptionID)
+
+	return 0
+}
+
+func sameTime(a, b time.Time) bool {
+	return a.Sub(b).Abs() < TIME_PRECISION
+}
+
+func sortedIDs(ids []int64) []int64 {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+
+	return ids
+}
+
+// Run checks that storage behaves like other storages, so bot works the same with any of them
+func Run(t *testing.T, newDB NewDB) {
+	tests := []struct {
+		name string
+		run  func(s *suite)
+	}{
+		{"Users", testUsers},
+		{"UserActions", testUserActions},
+		{"Blockchains", testBlockchains},
+		{"BotMetadata", testBotMetadata},
+		{"Admins", testAdmins},
+		{"Wallets", testWallets},
+		{"WorkerAliases", testWorkerAliases},
+		{"WalletInvites", testWalletInvites},
+		{"ChatTargets", testChatTargets},
+		{"Notify", testNotify},
+		{"NotifyWorkers", testNotifyWorkers},
+		{"Support", testSupport},
+		{"Broadcasts", testBroadcasts},
+		{"PrivacyExport", testPrivacyExport},
+		{"PrivacyDelete", testPrivacyDelete},
+		{"PrivacyActivity", testPrivacyActivity},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			db := newDB(t)
+			db.AddBlockchain(Blockchain(TEST_COIN))
+			db.AddBlockchain(Blockchain(OTHER_TEST_COIN))
+
+			tt.run(&suite{
+				t:     t,
+				ctx:   context.Background(),
+				db:    db,
+				store: db.Storage(),
+			})
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package storageTest
+
+import (
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func testSupport(s *suite) {
+	user := s.createUser(1)
+	other := s.createUser(2)
+
+	ticket, messageID, err := s.store.Support.Create(s.ctx, user.ID, &storage.SupportMessage{
+		Direction:     storage.SupportMessageFromUser,
+		AuthorID:      user.ID,
+		Text:          "Payout is missing",
+		UserMessageID: int64Ptr(10),
+	})
+	s.noError(err, "create ticket")
+	if ticket.ID == 0 || messageID == 0 || ticket.UserID != user.ID || ticket.Status != storage.SupportTicketOpen || ticket.ClosedAt != nil {
+		s.t.Fatalf("expected open ticket of user %d, got %+v and message %d", user.ID, ticket, messageID)
+	}
+
+	otherTicket, _, err := s.store.Support.Create(s.ctx, other.ID, &storage.SupportMessage{
+		Direction:     storage.SupportMessageFromUser,
+		AuthorID:      other.ID,
+		Text:          "Worker is offline",
+		UserMessageID: int64Ptr(10),
+	})
+	s.noError(err, "create ticket")
+
+	s.noError(s.store.Support.SetSupportMessageID(s.ctx, messageID, 100), "set support message id")
+	s.noError(s.store.Support.SetTicketSupportMessageID(s.ctx, ticket.ID, 200), "set ticket support message id")
+
+	//	Ticket is found both by its header and by forwarded messages in support chat
+	for _, supportMessageID := range []int64{100, 200} {
+		found, err := s.store.Support.FindBySupportMessage(s.ctx, supportMessageID)
+		s.noError(err, "find ticket by support message")
+		if found == nil || found.ID != ticket.ID || found.SupportMessageID == nil || *found.SupportMessageID != 200 {
+			s.t.Fatalf("expected ticket %d to be found by support message %d, got %+v", ticket.ID, supportMessageID, found)
+		}
+	}
+
+	if found, err := s.store.Support.FindBySupportMessage(s.ctx, 300); err != nil || found != nil {
+		s.t.Fatalf("expected no ticket of unknown support message, got %+v and error %v", found, err)
+	}
+
+	//	Users have own message ids, so same id points to ticket of each user
+	found, err := s.store.Support.FindByUserMessage(s.ctx, other.ID, 10)
+	s.noError(err, "find ticket by user message")
+	if found == nil || found.ID != otherTicket.ID {
+		s.t.Fatalf("expected ticket %d to be found by user message, got %+v", otherTicket.ID, found)
+	}
+
+	replyID, err := s.store.Support.AddMessage(s.ctx, ticket.ID, &storage.SupportMessage{
+		Direction:        storage.SupportMessageFromSupport,
+		AuthorID:         99,
+		Text:             "Payout is sent",
+		SupportMessageID: int64Ptr(101),
+	}, storage.SupportTicketAnswered)
+	s.noError(err, "add support message")
+	s.noError(s.store.Support.SetUserMessageID(s.ctx, replyID, 11), "set user message id")
+
+	found, err = s.store.Support.FindByUserMessage(s.ctx, user.ID, 11)
+	s.noError(err, "find ticket by user message")
+	if found == nil || found.ID != ticket.ID || found.Status != storage.SupportTicketAnswered {
+		s.t.Fatalf("expected answered ticket %d to be found by reply, got %+v", ticket.ID, found)
+	}
+
+	if found, err := s.store.Support.FindByUserMessage(s.ctx, other.ID, 11); err != nil || found != nil {
+		s.t.Fatalf("expected ticket of other user to not be found, got %+v and error %v", found, err)
+	}
+
+	firstID, err := s.store.Support.FindFirstUserMessageID(s.ctx, ticket.ID)
+	s.noError(err, "find first user message id")
+	if firstID == nil || *firstID != 10 {
+		s.t.Fatalf("expected first user message id 10, got %v", firstID)
+	}
+
+	s.noError(s.store.Support.Close(s.ctx, ticket.ID), "close ticket")
+
+	found, err = s.store.Support.FindBySupportMessage(s.ctx, 101)
+	s.noError(err, "find ticket by support message")
+	if found == nil || found.Status != storage.SupportTicketClosed || found.ClosedAt == nil {
+		s.t.Fatalf("expected ticket to be closed, got %+v", found)
+	}
+
+	//	New message of user reopens closed ticket
+	_, err = s.store.Support.AddMessage(s.ctx, ticket.ID, &storage.SupportMessage{
+		Direction:     storage.SupportMessageFromUser,
+		AuthorID:      user.ID,
+		Text:          "Thanks",
+		UserMessageID: int64Ptr(12),
+	}, storage.SupportTicketOpen)
+	s.noError(err, "add user message")
+
+	found, err = s.store.Support.FindByUserMessage(s.ctx, user.ID, 12)
+	s.noError(err, "find ticket by user message")
+	if found == nil || found.Status != storage.SupportTicketOpen || found.ClosedAt != nil {
+		s.t.Fatalf("expected ticket to be reopened, got %+v", found)
+	}
+
+	firstID, err = s.store.Support.FindFirstUserMessageID(s.ctx, ticket.ID)
+	s.noError(err, "find first user message id")
+	if firstID == nil || *firstID != 10 {
+		s.t.Fatalf("expected first user message id to stay 10, got %v", firstID)
+	}
+}
@@ -0,0 +1,210 @@
+package storageTest
+
+import (
+	"slices"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+func testUsers(s *suite) {
+	created := s.createUser(1)
+
+	user, err := s.store.Users.Find(s.ctx, created.ID)
+	s.noError(err, "find user")
+	if user == nil {
+		s.t.Fatalf("user %d is not found", created.ID)
+	}
+
+	if user.ChatID != created.ChatID || user.Lang != created.Lang || !user.PayoutsNotify || !user.BlocksNotify {
+		s.t.Fatalf("expected user %+v, got %+v", created, user)
+	}
+
+	if !user.BroadcastsNotify || !user.Active || user.ShortWallets || user.InactiveReason != nil || user.InactiveSince != nil {
+		s.t.Fatalf("expected new user to be active with default settings, got %+v", user)
+	}
+
+	if err := s.store.Users.Create(s.ctx, created); err == nil {
+		s.t.Fatalf("expected error on creating user %d twice", created.ID)
+	}
+
+	if user, err := s.store.Users.Find(s.ctx, 2); err != nil || user != nil {
+		s.t.Fatalf("expected missing user to be nil, got %+v and error %v", user, err)
+	}
+
+	//	Usernames are compared case insensitive like in telegram
+	user, err = s.store.Users.FindByUsername(s.ctx, "miner1")
+	s.noError(err, "find user by username")
+	if user == nil || user.ID != created.ID {
+		s.t.Fatalf("expected user %d to be found by username, got %+v", created.ID, user)
+	}
+
+	other := s.createUser(2)
+	if err := s.store.Users.SetChatID(s.ctx, created.ID, other.ChatID); err == nil {
+		s.t.Fatalf("expected error on setting chat id of other user")
+	}
+
+	s.noError(s.store.Users.SetChatID(s.ctx, created.ID, 1000), "set chat id")
+	s.noError(s.store.Users.SetUsername(s.ctx, created.ID, nil), "set username")
+	s.noError(s.store.Users.SetLang(s.ctx, created.ID, "ru"), "set lang")
+	s.noError(s.store.Users.SetPayoutsNotify(s.ctx, created.ID, false), "set payouts notify")
+	s.noError(s.store.Users.SetBlocksNotify(s.ctx, created.ID, false), "set blocks notify")
+	s.noError(s.store.Users.SetBroadcastsNotify(s.ctx, created.ID, false), "set broadcasts notify")
+	s.noError(s.store.Users.SetShortWallets(s.ctx, created.ID, true), "set short wallets")
+
+	user, err = s.store.Users.Find(s.ctx, created.ID)
+	s.noError(err, "find user")
+	if user.ChatID != 1000 || user.Username != nil || user.Lang != "ru" ||
+		user.PayoutsNotify || user.BlocksNotify || user.BroadcastsNotify || !user.ShortWallets {
+		s.t.Fatalf("expected user settings to be updated, got %+v", user)
+	}
+
+	if user, err := s.store.Users.FindByUsername(s.ctx, "miner1"); err != nil || user != nil {
+		s.t.Fatalf("expected user without username to not be found, got %+v and error %v", user, err)
+	}
+
+	s.noError(s.store.Privacy.Deactivate(s.ctx, 1000, "blocked"), "deactivate user")
+	s.noError(s.store.Users.Reactivate(s.ctx, created.ID), "reactivate user")
+
+	user, err = s.store.Users.Find(s.ctx, created.ID)
+	s.noError(err, "find user")
+	if !user.Active || user.InactiveReason != nil || user.InactiveSince != nil {
+		s.t.Fatalf("expected user to be reactivated, got %+v", user)
+	}
+}
+
+func testUserActions(s *suite) {
+	user := s.createUser(1)
+
+	if action, err := s.store.UserActions.Get(s.ctx, user.ID); err != nil || action != nil {
+		s.t.Fatalf("expected no user action, got %+v and error %v", action, err)
+	}
+
+	payload := "btc"
+	s.noError(s.store.UserActions.Set(s.ctx, user.ID, storage.UserAddWalletAction, &payload), "set user action")
+	s.noError(s.store.UserActions.Set(s.ctx, user.ID, storage.ReportBugAction, nil), "replace user action")
+
+	action, err := s.store.UserActions.Get(s.ctx, user.ID)
+	s.noError(err, "get user action")
+	if action == nil || action.UserID != user.ID || action.Action != storage.ReportBugAction || action.Payload != nil {
+		s.t.Fatalf("expected user action to be replaced, got %+v", action)
+	}
+
+	s.noError(s.store.UserActions.Set(s.ctx, user.ID, storage.UserAddWalletAction, &payload), "set user action")
+
+	action, err = s.store.UserActions.Get(s.ctx, user.ID)
+	s.noError(err, "get user action")
+	if action == nil || action.Action != storage.UserAddWalletAction || action.Payload == nil || *action.Payload != payload {
+		s.t.Fatalf("expected user action with payload %q, got %+v", payload, action)
+	}
+
+	s.noError(s.store.UserActions.Clear(s.ctx, user.ID), "clear user action")
+
+	if action, err := s.store.UserActions.Get(s.ctx, user.ID); err != nil || action != nil {
+		s.t.Fatalf("expected user action to be cleared, got %+v and error %v", action, err)
+	}
+}
+
+func testBlockchains(s *suite) {
+	blockchains, err := s.store.Blockchains.FindAll(s.ctx)
+	s.noError(err, "find blockchains")
+
+	//	Blockchains are ordered by coin
+	expected := []storage.BlockchainDB{Blockchain(TEST_COIN), Blockchain(OTHER_TEST_COIN)}
+
+	if len(blockchains) != len(expected) {
+		s.t.Fatalf("expected %d blockchains, got %+v", len(expected), blockchains)
+	}
+
+	for i, blockchain := range blockchains {
+		if blockchain.Coin != expected[i].Coin ||
+			blockchain.Name != expected[i].Name ||
+			blockchain.Ticker != expected[i].Ticker ||
+			blockchain.Decimals != expected[i].Decimals ||
+			blockchain.DisplayPrecision != nil ||
+			blockchain.ExampleWallet != expected[i].ExampleWallet ||
+			blockchain.URL != expected[i].URL ||
+			blockchain.Insecure != expected[i].Insecure {
+			s.t.Fatalf("expected blockchain %+v, got %+v", expected[i], blockchain)
+		}
+	}
+}
+
+func testBotMetadata(s *suite) {
+	hashes, err := s.store.BotMetadata.FindHashes(s.ctx)
+	s.noError(err, "find hashes")
+	if len(hashes) != 0 {
+		s.t.Fatalf("expected no hashes, got %v", hashes)
+	}
+
+	s.noError(s.store.BotMetadata.SaveHash(s.ctx, "commands", "a"), "save hash")
+	s.noError(s.store.BotMetadata.SaveHash(s.ctx, "description", "b"), "save hash")
+	s.noError(s.store.BotMetadata.SaveHash(s.ctx, "commands", "c"), "replace hash")
+	s.noError(s.store.BotMetadata.DeleteHash(s.ctx, "description"), "delete hash")
+
+	hashes, err = s.store.BotMetadata.FindHashes(s.ctx)
+	s.noError(err, "find hashes")
+	if len(hashes) != 1 || hashes["commands"] != "c" {
+		s.t.Fatalf("expected only replaced commands hash, got %v", hashes)
+	}
+}
+
+func testAdmins(s *suite) {
+	admin := s.createUser(1)
+	user := s.createUser(2)
+	s.db.AddAdmin(admin.ID)
+
+	if isAdmin, err := s.store.Admins.IsAdmin(s.ctx, admin.ID); err != nil || !isAdmin {
+		s.t.Fatalf("expected user %d to be admin, got %t and error %v", admin.ID, isAdmin, err)
+	}
+
+	if isAdmin, err := s.store.Admins.IsAdmin(s.ctx, user.ID); err != nil || isAdmin {
+		s.t.Fatalf("expected user %d to not be admin, got %t and error %v", user.ID, isAdmin, err)
+	}
+
+	ids, err := s.store.Admins.FindIDs(s.ctx)
+	s.noError(err, "find admin ids")
+	if !slices.Equal(ids, []int64{admin.ID}) {
+		s.t.Fatalf("expected admin ids [%d], got %v", admin.ID, ids)
+	}
+
+	count, err := s.store.Admins.CountUsers(s.ctx)
+	s.noError(err, "count users")
+	if count != 2 {
+		s.t.Fatalf("expected 2 users, got %d", count)
+	}
+
+	//	Wallet shared by both users is counted once
+	shared := s.addWallet(admin.ID, TEST_COIN, "shared", "")
+	s.addWallet(user.ID, TEST_COIN, "shared", "")
+	s.addWallet(user.ID, TEST_COIN, "own", "")
+	s.addWallet(admin.ID, OTHER_TEST_COIN, "other", "")
+
+	s.noError(s.store.Notify.UpdateWorkers(s.ctx, []storage.WalletWorkerDB{
+		{WalletID: s.walletID(shared.ID), Worker: "rig1", Region: "eu"},
+		{WalletID: s.walletID(shared.ID), Worker: "rig2", Region: "eu"},
+	}, nil, 10), "add workers")
+
+	wallets, err := s.store.Admins.CountWallets(s.ctx)
+	s.noError(err, "count wallets")
+	if !slices.Equal(wallets, []storage.CoinCountDB{{Coin: TEST_COIN, Count: 2}, {Coin: OTHER_TEST_COIN, Count: 1}}) {
+		s.t.Fatalf("expected wallets counts ordered by coin, got %+v", wallets)
+	}
+
+	workers, err := s.store.Admins.CountWorkers(s.ctx)
+	s.noError(err, "count workers")
+	if !slices.Equal(workers, []storage.CoinCountDB{{Coin: TEST_COIN, Count: 2}}) {
+		s.t.Fatalf("expected workers counts of coins with workers, got %+v", workers)
+	}
+
+	userWallets, err := s.store.Admins.FindUserWallets(s.ctx, admin.ID)
+	s.noError(err, "find user wallets")
+	if len(userWallets) != 2 {
+		s.t.Fatalf("expected 2 user wallets, got %+v", userWallets)
+	}
+
+	if userWallets[0].Coin != TEST_COIN || userWallets[0].Wallet != "shared" ||
+		userWallets[0].Role != string(storage.WalletOwnerRole) || userWallets[0].WorkersCount != 2 ||
+		userWallets[1].Coin != OTHER_TEST_COIN || userWallets[1].Wallet != "other" || userWallets[1].WorkersCount != 0 {
+		s.t.Fatalf("expected user wallets ordered by coin with workers counts, got %+v", userWallets)
+	}
+}
@@ -0,0 +1,378 @@
+package storageTest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/grandminingpool/telegram-bot/internal/storage"
+)
+
+func testWallets(s *suite) {
+	user := s.createUser(1)
+	other := s.createUser(2)
+
+	first := s.addWallet(user.ID, OTHER_TEST_COIN, "first", "Rig farm")
+	second := s.addWallet(user.ID, TEST_COIN, "second", "")
+	third := s.addWallet(user.ID, OTHER_TEST_COIN, "third", "")
+
+	if first.Label != "Rig farm" || first.Role != storage.WalletOwnerRole || !first.Notify || first.Paused {
+		s.t.Fatalf("expected notified owner wallet with label, got %+v", first)
+	}
+
+	//	Wallets are ordered by coin, then by time they were added
+	wallets, err := s.store.Wallets.FindAll(s.ctx, user.ID)
+	s.noError(err, "find wallets")
+	if len(wallets) != 3 || wallets[0].ID != second.ID || wallets[1].ID != first.ID || wallets[2].ID != third.ID {
+		s.t.Fatalf("expected wallets ordered by coin and time they were added, got %+v", wallets)
+	}
+
+	if err := s.store.Wallets.Add(s.ctx, user.ID, TEST_COIN, "second", ""); err == nil {
+		s.t.Fatalf("expected error on adding same wallet twice")
+	}
+
+	wallet, err := s.store.Wallets.Find(s.ctx, user.ID, first.ID)
+	s.noError(err, "find wallet")
+	if wallet == nil || wallet.Wallet != "first" || wallet.Coin != OTHER_TEST_COIN || !sameTime(wallet.AddedAt, first.AddedAt) {
+		s.t.Fatalf("expected wallet %+v, got %+v", first, wallet)
+	}
+
+	if wallet, err := s.store.Wallets.Find(s.ctx, other.ID, first.ID); err != nil || wallet != nil {
+		s.t.Fatalf("expected wallet of other user to not be found, got %+v and error %v", wallet, err)
+	}
+
+	count, err := s.store.Wallets.Count(s.ctx, user.ID, OTHER_TEST_COIN)
+	s.noError(err, "count wallets")
+	if count != 2 {
+		s.t.Fatalf("expected 2 %s wallets, got %d", OTHER_TEST_COIN, count)
+	}
+
+	if exists, err := s.store.Wallets.Exists(s.ctx, user.ID, TEST_COIN, "second"); err != nil || !exists {
+		s.t.Fatalf("expected wallet to exist, got %t and error %v", exists, err)
+	}
+
+	if exists, err := s.store.Wallets.Exists(s.ctx, other.ID, TEST_COIN, "second"); err != nil || exists {
+		s.t.Fatalf("expected wallet of other user to not exist, got %t and error %v", exists, err)
+	}
+
+	//	Changes of other user are ignored
+	s.noError(s.store.Wallets.SetLabel(s.ctx, other.ID, first.ID, "Stolen"), "set label")
+	s.noError(s.store.Wallets.SetLabel(s.ctx, user.ID, second.ID, "Garage"), "set label")
+	s.noError(s.store.Wallets.SetLabel(s.ctx, user.ID, first.ID, ""), "remove label")
+	s.noError(s.store.Wallets.SetNotify(s.ctx, user.ID, second.ID, false), "set notify")
+	s.noError(s.store.Wallets.SetPaused(s.ctx, user.ID, second.ID, true), "set paused")
+
+	if wallet := s.findWallet(user.ID, OTHER_TEST_COIN, "first"); wallet.Label != "" {
+		s.t.Fatalf("expected wallet label to be removed, got %+v", wallet)
+	}
+
+	second = s.findWallet(user.ID, TEST_COIN, "second")
+	if second.Label != "Garage" || second.Notify || !second.Paused {
+		s.t.Fatalf("expected wallet settings to be updated, got %+v", second)
+	}
+
+	s.noError(s.store.Wallets.Remove(s.ctx, second.ID), "remove wallet")
+
+	if err := s.store.Wallets.Remove(s.ctx, second.ID); err == nil {
+		s.t.Fatalf("expected error on removing wallet twice")
+	}
+
+	if exists, err := s.store.Wallets.Exists(s.ctx, user.ID, TEST_COIN, "second"); err != nil || exists {
+		s.t.Fatalf("expected removed wallet to not exist, got %t and error %v", exists, err)
+	}
+
+	//	Removed wallet is restored with its settings and aliases
+	s.noError(s.store.Wallets.Restore(s.ctx, user.ID, &second, map[string]string{"rig1": "Basement"}), "restore wallet")
+
+	restored := s.findWallet(user.ID, TEST_COIN, "second")
+	if restored.ID == second.ID || restored.Label != "Garage" || restored.Role != storage.WalletOwnerRole || restored.Notify || !restored.Paused {
+		s.t.Fatalf("expected wallet %+v to be restored with new id, got %+v", second, restored)
+	}
+
+	aliases, err := s.store.Wallets.FindAliases(s.ctx, user.ID)
+	s.noError(err, "find aliases")
+	if len(aliases) != 1 || aliases[restored.ID]["rig1"] != "Basement" {
+		s.t.Fatalf("expected restored wallet aliases, got %v", aliases)
+	}
+
+	//	Wallet added again before undo is kept as is
+	s.noError(s.store.Wallets.Restore(s.ctx, user.ID, &second, map[string]string{"rig2": "Attic"}), "restore wallet")
+
+	wallets, err = s.store.Wallets.FindAll(s.ctx, user.ID)
+	s.noError(err, "find wallets")
+	if len(wallets) != 3 {
+		s.t.Fatalf("expected wallet to not be restored twice, got %+v", wallets)
+	}
+
+	aliases, err = s.store.Wallets.FindAliases(s.ctx, user.ID)
+	s.noError(err, "find aliases")
+	if len(aliases[restored.ID]) != 1 {
+		s.t.Fatalf("expected aliases to not be restored twice, got %v", aliases)
+	}
+}
+
+func testWorkerAliases(s *suite) {
+	user := s.createUser(1)
+	other := s.createUser(2)
+
+	wallet := s.addWallet(user.ID, TEST_COIN, "shared", "")
+	otherWallet := s.addWallet(other.ID, TEST_COIN, "shared", "")
+	walletID := s.walletID(wallet.ID)
+
+	s.noError(s.store.Notify.UpdateWorkers(s.ctx, []storage.WalletWorkerDB{
+		{WalletID: walletID, Worker: "rig2", Region: "eu"},
+		{WalletID: walletID, Worker: "rig1", Region: "eu"},
+	}, nil, 10), "add workers")
+
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, user.ID, wallet.ID, "rig1", "Basement"), "set worker alias")
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, user.ID, wallet.ID, "rig2", "Attic"), "set worker alias")
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, user.ID, wallet.ID, "rig2", "Garage"), "replace worker alias")
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, other.ID, otherWallet.ID, "rig1", "Office"), "set worker alias")
+
+	//	Aliases are set only for own subscriptions
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, other.ID, wallet.ID, "rig1", "Stolen"), "set worker alias")
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, other.ID, wallet.ID, "rig2", ""), "remove worker alias")
+
+	aliases, err := s.store.Wallets.FindWorkerAliases(s.ctx, user.ID, wallet.ID)
+	s.noError(err, "find worker aliases")
+	if !slices.Equal(aliases, []storage.WorkerAliasDB{{Worker: "rig1", Alias: "Basement"}, {Worker: "rig2", Alias: "Garage"}}) {
+		s.t.Fatalf("expected worker aliases ordered by worker, got %+v", aliases)
+	}
+
+	if aliases, err := s.store.Wallets.FindWorkerAliases(s.ctx, other.ID, wallet.ID); err != nil || len(aliases) != 0 {
+		s.t.Fatalf("expected no worker aliases of other user wallet, got %+v and error %v", aliases, err)
+	}
+
+	s.noError(s.store.Wallets.SetWorkerAlias(s.ctx, user.ID, wallet.ID, "rig2", ""), "remove worker alias")
+
+	aliases, err = s.store.Wallets.FindWorkerAliases(s.ctx, user.ID, wallet.ID)
+	s.noError(err, "find worker aliases")
+	if !slices.Equal(aliases, []storage.WorkerAliasDB{{Worker: "rig1", Alias: "Basement"}, {Worker: "rig2"}}) {
+		s.t.Fatalf("expected worker alias to be removed, got %+v", aliases)
+	}
+
+	userAliases, err := s.store.Wallets.FindAliases(s.ctx, user.ID)
+	s.noError(err, "find aliases")
+	if len(userAliases) != 1 || len(userAliases[wallet.ID]) != 1 || userAliases[wallet.ID]["rig1"] != "Basement" {
+		s.t.Fatalf("expected only user aliases, got %v", userAliases)
+	}
+
+	allAliases, err := s.store.Wallets.FindAllAliases(s.ctx)
+	s.noError(err, "find all aliases")
+	if len(allAliases) != 2 || allAliases[wallet.ID]["rig1"] != "Basement" || allAliases[otherWallet.ID]["rig1"] != "Office" {
+		s.t.Fatalf("expected aliases of all subscriptions, got %v", allAliases)
+	}
+
+	//	Aliases are removed with subscription
+	s.noError(s.store.Wallets.Remove(s.ctx, wallet.ID), "remove wallet")
+
+	allAliases, err = s.store.Wallets.FindAllAliases(s.ctx)
+	s.noError(err, "find all aliases")
+	if len(allAliases) != 1 || allAliases[otherWallet.ID]["rig1"] != "Office" {
+		s.t.Fatalf("expected aliases of removed wallet to be removed, got %v", allAliases)
+	}
+}
+
+func testWalletInvites(s *suite) {
+	owner := s.createUser(1)
+	viewer := s.createUser(2)
+
+	wallet := s.addWallet(owner.ID, TEST_COIN, "shared", "")
+	expiresAt := time.Now().Add(time.Hour)
+
+	if created, err := s.store.WalletInvites.Create(s.ctx, "token", viewer.ID, wallet.ID, storage.WalletViewerRole, expiresAt); err != nil || created {
+		s.t.Fatalf("expected invite to not be created for wallet of other user, got %t and error %v", created, err)
+	}
+
+	created, err := s.store.WalletInvites.Create(s.ctx, "token", owner.ID, wallet.ID, storage.WalletViewerRole, expiresAt)
+	s.noError(err, "create invite")
+	if !created {
+		s.t.Fatalf("expected invite to be created for owned wallet")
+	}
+
+	if _, err := s.store.WalletInvites.Create(s.ctx, "token", owner.ID, wallet.ID, storage.WalletViewerRole, expiresAt); err == nil {
+		s.t.Fatalf("expected error on creating invite with same token")
+	}
+
+	created, err = s.store.WalletInvites.Create(s.ctx, "expired", owner.ID, wallet.ID, storage.WalletViewerRole, time.Now().Add(-time.Hour))
+	s.noError(err, "create expired invite")
+	if !created {
+		s.t.Fatalf("expected expired invite to be created")
+	}
+
+	if invite, err := s.store.WalletInvites.Find(s.ctx, "expired"); err != nil || invite != nil {
+		s.t.Fatalf("expected expired invite to not be found, got %+v and error %v", invite, err)
+	}
+
+	if invite, err := s.store.WalletInvites.Find(s.ctx, "unknown"); err != nil || invite != nil {
+		s.t.Fatalf("expected unknown invite to not be found, got %+v and error %v", invite, err)
+	}
+
+	invite, err := s.store.WalletInvites.Find(s.ctx, "token")
+	s.noError(err, "find invite")
+	if invite == nil {
+		s.t.Fatalf("invite is not found")
+	}
+
+	if invite.Token != "token" || invite.WalletID != s.walletID(wallet.ID) || invite.Role != storage.WalletViewerRole ||
+		invite.Coin != TEST_COIN || invite.Wallet != "shared" || invite.CreatedBy != owner.ID ||
+		invite.CreatorChatID != owner.ChatID || invite.CreatorLang != owner.Lang || !sameTime(invite.ExpiresAt, expiresAt) {
+		s.t.Fatalf("expected invite of wallet %+v, got %+v", wallet, invite)
+	}
+
+	//	Owner opening own link doesn't use invite
+	if status, err := s.store.WalletInvites.Accept(s.ctx, owner.ID, invite); err != nil || status != storage.WalletInviteAlreadyAdded {
+		s.t.Fatalf("expected owner to already have wallet, got %q and error %v", status, err)
+	}
+
+	status, err := s.store.WalletInvites.Accept(s.ctx, viewer.ID, invite)
+	s.noError(err, "accept invite")
+	if status != storage.WalletInviteAccepted {
+		s.t.Fatalf("expected invite to be accepted, got %q", status)
+	}
+
+	if status, err := s.store.WalletInvites.Accept(s.ctx, viewer.ID, invite); err != nil || status != storage.WalletInviteAlreadyAdded {
+		s.t.Fatalf("expected invite to not be accepted twice, got %q and error %v", status, err)
+	}
+
+	//	Invite is single use
+	other := s.createUser(3)
+	if status, err := s.store.WalletInvites.Accept(s.ctx, other.ID, invite); err != nil || status != storage.WalletInviteUsed {
+		s.t.Fatalf("expected used invite to not be accepted by other user, got %q and error %v", status, err)
+	}
+
+	if invite, err := s.store.WalletInvites.Find(s.ctx, "token"); err != nil || invite != nil {
+		s.t.Fatalf("expected used invite to not be found, got %+v and error %v", invite, err)
+	}
+
+	shared := s.findWallet(viewer.ID, TEST_COIN, "shared")
+	if shared.Role != storage.WalletViewerRole || shared.Label != "" || !shared.Notify || shared.Paused {
+		s.t.Fatalf("expected viewer wallet, got %+v", shared)
+	}
+
+	//	Viewers can't invite other users
+	if created, err := s.store.WalletInvites.Create(s.ctx, "viewer", viewer.ID, shared.ID, storage.WalletViewerRole, expiresAt); err != nil || created {
+		s.t.Fatalf("expected invite to not be created by viewer, got %t and error %v", created, err)
+	}
+
+	//	Invites are removed with wallet when last subscriber leaves
+	created, err = s.store.WalletInvites.Create(s.ctx, "next", owner.ID, wallet.ID, storage.WalletViewerRole, expiresAt)
+	s.noError(err, "create invite")
+	if !created {
+		s.t.Fatalf("expected next invite to be created for owned wallet")
+	}
+
+	s.noError(s.store.Wallets.Remove(s.ctx, shared.ID), "remove wallet")
+	if invite, err := s.store.WalletInvites.Find(s.ctx, "next"); err != nil || invite == nil {
+		s.t.Fatalf("expected invite to be kept while wallet has subscribers, got %+v and error %v", invite, err)
+	}
+
+	s.noError(s.store.Wallets.Remove(s.ctx, wallet.ID), "remove wallet")
+	if invite, err := s.store.WalletInvites.Find(s.ctx, "next"); err != nil || invite != nil {
+		s.t.Fatalf("expected invite of removed wallet to not be found, got %+v and error %v", invite, err)
+	}
+}
+
+func testChatTargets(s *suite) {
+	user := s.createUser(1)
+	other := s.createUser(2)
+
+	first := s.addWallet(user.ID, TEST_COIN, "first", "Rig farm")
+	second := s.addWallet(user.ID, OTHER_TEST_COIN, "second", "")
+
+	group, err := s.store.ChatTargets.Link(s.ctx, &storage.ChatTargetDB{
+		UserID:   user.ID,
+		ChatID:   -100,
+		ChatType: "group",
+		Title:    "Miners",
+	})
+	s.noError(err, "link chat target")
+	if group.ID == 0 || group.UserID != user.ID || group.ChatID != -100 || group.ThreadID != 0 || group.Title != "Miners" {
+		s.t.Fatalf("expected linked chat target, got %+v", group)
+	}
+
+	topic, err := s.store.ChatTargets.Link(s.ctx, &storage.ChatTargetDB{
+		UserID:   user.ID,
+		ChatID:   -100,
+		ThreadID: 7,
+		ChatType: "supergroup",
+		Title:    "Payouts",
+	})
+	s.noError(err, "link chat target")
+	if topic.ID == group.ID {
+		s.t.Fatalf("expected topic to be linked as new target, got %+v", topic)
+	}
+
+	//	Linking same chat again updates it
+	relinked, err := s.store.ChatTargets.Link(s.ctx, &storage.ChatTargetDB{
+		UserID:   user.ID,
+		ChatID:   -100,
+		ChatType: "supergroup",
+		Title:    "Miners club",
+	})
+	s.noError(err, "link chat target again")
+	if relinked.ID != group.ID || relinked.ChatType != "supergroup" || relinked.Title != "Miners club" || !sameTime(relinked.AddedAt, group.AddedAt) {
+		s.t.Fatalf("expected chat target %+v to be updated, got %+v", group, relinked)
+	}
+
+	if target, err := s.store.ChatTargets.Find(s.ctx, other.ID, group.ID); err != nil || target != nil {
+		s.t.Fatalf("expected chat target of other user to not be found, got %+v and error %v", target, err)
+	}
+
+	target, err := s.store.ChatTargets.Find(s.ctx, user.ID, topic.ID)
+	s.noError(err, "find chat target")
+	if target == nil || target.ThreadID != 7 || target.Title != "Payouts" {
+		s.t.Fatalf("expected chat target %+v, got %+v", topic, target)
+	}
+
+	targets, err := s.store.ChatTargets.FindByUser(s.ctx, user.ID)
+	s.noError(err, "find chat targets")
+	if len(targets) != 2 || targets[0].ID != group.ID || targets[1].ID != topic.ID {
+		s.t.Fatalf("expected chat targets ordered by time they were added, got %+v", targets)
+	}
+
+	s.noError(s.store.ChatTargets.SetWallet(s.ctx, group.ID, first.ID, true), "enable chat target wallet")
+	s.noError(s.store.ChatTargets.SetWallet(s.ctx, group.ID, first.ID, true), "enable chat target wallet again")
+	s.noError(s.store.ChatTargets.SetWallet(s.ctx, topic.ID, first.ID, true), "enable chat target wallet")
+	s.noError(s.store.ChatTargets.SetWallet(s.ctx, group.ID, second.ID, true), "enable chat target wallet")
+	s.noError(s.store.ChatTargets.SetWallet(s.ctx, group.ID, second.ID, false), "disable chat target wallet")
+
+	wallets, err := s.store.ChatTargets.FindWallets(s.ctx, user.ID, group.ID)
+	s.noError(err, "find chat target wallets")
+	if !slices.Equal(wallets, []storage.ChatTargetWalletDB{
+		{ID: first.ID, Coin: TEST_COIN, Wallet: "first", Label: "Rig farm", Enabled: true},
+		{ID: second.ID, Coin: OTHER_TEST_COIN, Wallet: "second"},
+	}) {
+		s.t.Fatalf("expected all user wallets with enabled state, got %+v", wallets)
+	}
+
+	walletTargets, err := s.store.ChatTargets.FindAllWalletTargets(s.ctx)
+	s.noError(err, "find wallet targets")
+
+	firstTargets := slices.Clone(walletTargets[first.ID])
+	slices.SortFunc(firstTargets, func(a, b storage.ChatTarget) int {
+		return int(a.ThreadID - b.ThreadID)
+	})
+
+	if len(walletTargets) != 1 || !slices.Equal(firstTargets, []storage.ChatTarget{{ChatID: -100}, {ChatID: -100, ThreadID: 7}}) {
+		s.t.Fatalf("expected targets of enabled wallets, got %+v", walletTargets)
+	}
+
+	//	Targets are unlinked only by their user
+	s.noError(s.store.ChatTargets.Unlink(s.ctx, other.ID, group.ID), "unlink chat target")
+	s.noError(s.store.ChatTargets.Unlink(s.ctx, user.ID, topic.ID), "unlink chat target")
+
+	targets, err = s.store.ChatTargets.FindByUser(s.ctx, user.ID)
+	s.noError(err, "find chat targets")
+	if len(targets) != 1 || targets[0].ID != group.ID {
+		s.t.Fatalf("expected only unlinked target to be removed, got %+v", targets)
+	}
+
+	//	Targets of removed wallet are removed with it
+	s.noError(s.store.Wallets.Remove(s.ctx, first.ID), "remove wallet")
+
+	walletTargets, err = s.store.ChatTargets.FindAllWalletTargets(s.ctx)
+	s.noError(err, "find wallet targets")
+	if len(walletTargets) != 0 {
+		s.t.Fatalf("expected no wallet targets, got %+v", walletTargets)
+	}
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type SupportTicketStatus string
+
+const (
+	SupportTicketOpen     SupportTicketStatus = "open"
+	SupportTicketAnswered SupportTicketStatus = "answered"
+	SupportTicketClosed   SupportTicketStatus = "closed"
+)
+
+type SupportMessageDirection string
+
+const (
+	SupportMessageFromUser    SupportMessageDirection = "user"
+	SupportMessageFromSupport SupportMessageDirection = "support"
+)
+
+type SupportTicketDB struct {
+	ID               int64               `db:"id"`
+	UserID           int64               `db:"user_id"`
+	Status           SupportTicketStatus `db:"status"`
+	SupportMessageID *int64              `db:"support_message_id"`
+	CreatedAt        time.Time           `db:"created_at"`
+	UpdatedAt        time.Time           `db:"updated_at"`
+	ClosedAt         *time.Time          `db:"closed_at"`
+}
+
+type SupportMessage struct {
+	Direction        SupportMessageDirection
+	AuthorID         int64
+	Text             string
+	AttachmentType   *string
+	AttachmentFileID *string
+	UserMessageID    *int64
+	SupportMessageID *int64
+}
+
+type SupportRepository interface {
+	Create(ctx context.Context, userID int64, message *SupportMessage) (*SupportTicketDB, int64, error)
+	//	Adding message sets ticket status and reopens closed ticket
+	AddMessage(ctx context.Context, ticketID int64, message *SupportMessage, status SupportTicketStatus) (int64, error)
+	SetTicketSupportMessageID(ctx context.Context, ticketID, supportMessageID int64) error
+	SetUserMessageID(ctx context.Context, messageID, userMessageID int64) error
+	SetSupportMessageID(ctx context.Context, messageID, supportMessageID int64) error
+	FindBySupportMessage(ctx context.Context, supportMessageID int64) (*SupportTicketDB, error)
+	FindByUserMessage(ctx context.Context, userID, userMessageID int64) (*SupportTicketDB, error)
+	FindFirstUserMessageID(ctx context.Context, ticketID int64) (*int64, error)
+	Close(ctx context.Context, ticketID int64) error
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+)
+
+type UserAction string
+
+const (
+	UserAddWalletAction   UserAction = "add_wallet"
+	ReportBugAction       UserAction = "report_bug"
+	BroadcastTextAction   UserAction = "broadcast_text"
+	BroadcastTargetAction UserAction = "broadcast_target"
+	RenameWalletAction    UserAction = "rename_wallet"
+	AliasWorkerAction     UserAction = "alias_worker"
+	ImportWalletsAction   UserAction = "import_wallets"
+)
+
+func (ua *UserAction) Scan(val any) error {
+	var str string
+	switch v := val.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return fmt.Errorf("unsupported user action type: %T", v)
+	}
+
+	switch action := UserAction(str); action {
+	case UserAddWalletAction,
+		ReportBugAction,
+		BroadcastTextAction,
+		BroadcastTargetAction,
+		RenameWalletAction,
+		AliasWorkerAction,
+		ImportWalletsAction:
+		*ua = action
+	default:
+		return fmt.Errorf("invalid user action value: %s", str)
+	}
+
+	return nil
+}
+
+func (ua UserAction) Value() (driver.Value, error) {
+	return string(ua), nil
+}
+
+type UserActionDB struct {
+	UserID  int64      `db:"user_id"`
+	Action  UserAction `db:"action"`
+	Payload *string    `db:"payload"`
+}
+
+type UserActionRepository interface {
+	Set(ctx context.Context, userID int64, action UserAction, payload *string) error
+	Get(ctx context.Context, userID int64) (*UserActionDB, error)
+	Clear(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type UserDB struct {
+	ID               int64      `db:"id"`
+	ChatID           int64      `db:"chat_id"`
+	Lang             string     `db:"lang"`
+	PayoutsNotify    bool       `db:"payouts_notify"`
+	BlocksNotify     bool       `db:"blocks_notify"`
+	Username         *string    `db:"username"`
+	BroadcastsNotify bool       `db:"broadcasts_notify"`
+	ShortWallets     bool       `db:"short_wallets"`
+	Active           bool       `db:"active"`
+	InactiveReason   *string    `db:"inactive_reason"`
+	InactiveSince    *time.Time `db:"inactive_since"`
+}
+
+type UserRepository interface {
+	Find(ctx context.Context, id int64) (*UserDB, error)
+	FindByUsername(ctx context.Context, username string) (*UserDB, error)
+	Create(ctx context.Context, user *UserDB) error
+	SetChatID(ctx context.Context, id, chatID int64) error
+	SetUsername(ctx context.Context, id int64, username *string) error
+	SetLang(ctx context.Context, id int64, lang string) error
+	SetPayoutsNotify(ctx context.Context, id int64, value bool) error
+	SetBlocksNotify(ctx context.Context, id int64, value bool) error
+	SetBroadcastsNotify(ctx context.Context, id int64, value bool) error
+	SetShortWallets(ctx context.Context, id int64, value bool) error
+	Reactivate(ctx context.Context, id int64) error
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type WalletInviteDB struct {
+	Token         string     `db:"token"`
+	WalletID      int64      `db:"wallet_id"`
+	Role          WalletRole `db:"role"`
+	Coin          string     `db:"blockchain_coin"`
+	Wallet        string     `db:"wallet"`
+	CreatedBy     int64      `db:"created_by"`
+	CreatorChatID int64      `db:"creator_chat_id"`
+	CreatorLang   string     `db:"creator_lang"`
+	ExpiresAt     time.Time  `db:"expires_at"`
+}
+
+type WalletInviteAcceptStatus string
+
+const (
+	WalletInviteAccepted WalletInviteAcceptStatus = "accepted"
+	//	User already has wallet, so invite is kept for other users
+	WalletInviteAlreadyAdded WalletInviteAcceptStatus = "already_added"
+	//	Invite is single use, it has been accepted by other user or expired
+	WalletInviteUsed WalletInviteAcceptStatus = "used"
+)
+
+type WalletInviteRepository interface {
+	//	Invite is created only for wallet owned by user, otherwise false is returned
+	Create(ctx context.Context, token string, userID, subscriptionID int64, role WalletRole, expiresAt time.Time) (bool, error)
+	Find(ctx context.Context, token string) (*WalletInviteDB, error)
+	//	Accepted invite is removed, so link can't be used again
+	Accept(ctx context.Context, userID int64, invite *WalletInviteDB) (WalletInviteAcceptStatus, error)
+}
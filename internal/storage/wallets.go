@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+type WalletRole string
+
+const (
+	WalletOwnerRole  WalletRole = "owner"
+	WalletViewerRole WalletRole = "viewer"
+)
+
+type UserWalletDB struct {
+	ID      int64      `db:"id"`
+	Coin    string     `db:"blockchain_coin"`
+	Wallet  string     `db:"wallet"`
+	Label   string     `db:"label"`
+	Role    WalletRole `db:"role"`
+	Notify  bool       `db:"notify"`
+	Paused  bool       `db:"paused"`
+	AddedAt time.Time  `db:"added_at"`
+}
+
+type WorkerAliasDB struct {
+	Worker string `db:"worker"`
+	Alias  string `db:"alias"`
+}
+
+type WalletRepository interface {
+	Find(ctx context.Context, userID, id int64) (*UserWalletDB, error)
+	//	Wallets are ordered by coin and time they were added
+	FindAll(ctx context.Context, userID int64) ([]UserWalletDB, error)
+	Count(ctx context.Context, userID int64, coin string) (int, error)
+	Exists(ctx context.Context, userID int64, coin, wallet string) (bool, error)
+	Add(ctx context.Context, userID int64, coin, wallet, label string) error
+	Remove(ctx context.Context, id int64) error
+	Restore(ctx context.Context, userID int64, wallet *UserWalletDB, aliases map[string]string) error
+	SetLabel(ctx context.Context, userID, id int64, label string) error
+	SetNotify(ctx context.Context, userID, id int64, notify bool) error
+	SetPaused(ctx context.Context, userID, id int64, paused bool) error
+	FindAliases(ctx context.Context, userID int64) (map[int64]map[string]string, error)
+	FindAllAliases(ctx context.Context) (map[int64]map[string]string, error)
+	FindWorkerAliases(ctx context.Context, userID, id int64) ([]WorkerAliasDB, error)
+	SetWorkerAlias(ctx context.Context, userID, id int64, worker, alias string) error
+}
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type DBHooks struct {
+	system string
+}
+
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "UNKNOWN"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+func (h *DBHooks) Before(ctx context.Context, query string) context.Context {
+	operation := queryOperation(query)
+	ctx, _ = Tracer().Start(ctx, "db."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(h.system),
+			semconv.DBOperation(operation),
+			semconv.DBStatement(query),
+		),
+	)
+
+	return ctx
+}
+
+func (h *DBHooks) After(ctx context.Context, query string, err error) {
+	End(trace.SpanFromContext(ctx), err)
+}
+
+func NewDBHooks(system string) *DBHooks {
+	return &DBHooks{system: system}
+}
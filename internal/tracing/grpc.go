@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type metadataCarrier struct {
+	md metadata.MD
+}
+
+func (c *metadataCarrier) Get(key string) string {
+	values := c.md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c *metadataCarrier) Set(key, value string) {
+	c.md.Set(key, value)
+}
+
+func (c *metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.md))
+	for key := range c.md {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func UnaryClientInterceptor(coin string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		service, rpcMethod, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+		ctx, span := Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(service),
+				semconv.RPCMethod(rpcMethod),
+				attribute.String("pool.coin", coin),
+			),
+		)
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+
+		otel.GetTextMapPropagator().Inject(ctx, &metadataCarrier{md: md})
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err))))
+		End(span, err)
+
+		return err
+	}
+}
@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	tracingConfig "github.com/grandminingpool/telegram-bot/configs/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TRACER_NAME = "github.com/grandminingpool/telegram-bot"
+
+type Provider struct {
+	tracerProvider *sdkTrace.TracerProvider
+	file           io.Closer
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(TRACER_NAME)
+}
+
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.tracerProvider == nil {
+		return nil
+	}
+
+	err := p.tracerProvider.Shutdown(ctx)
+	if p.file != nil {
+		err = errors.Join(err, p.file.Close())
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+	}
+
+	return nil
+}
+
+func createExporter(ctx context.Context, config *tracingConfig.Config) (sdkTrace.SpanExporter, io.Closer, error) {
+	switch config.Exporter {
+	case tracingConfig.EXPORTER_OTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.OTLP.Endpoint)}
+		if config.OTLP.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, options...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+	case tracingConfig.EXPORTER_FILE:
+		file, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open traces file (path: %s): %w", config.FilePath, err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+
+			return nil, nil, fmt.Errorf("failed to create file trace exporter: %w", err)
+		}
+
+		return exporter, file, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+func Setup(ctx context.Context, config *tracingConfig.Config) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, file, err := createExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	} else if exporter == nil {
+		return &Provider{}, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	tracerProvider := sdkTrace.NewTracerProvider(
+		sdkTrace.WithBatcher(exporter),
+		sdkTrace.WithResource(res),
+		sdkTrace.WithSampler(sdkTrace.ParentBased(sdkTrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return &Provider{
+		tracerProvider: tracerProvider,
+		file:           file,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package deeplinkUtils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	//	Telegram limit of /start parameter, raw wallet of 3 letter coin fits with up to 55 characters
+	//	or 46 with signature, so monero addresses can't be passed by link
+	MAX_PAYLOAD_LENGTH = 64
+	SIGNATURE_SIZE     = 6
+	PAYLOAD_SEPARATOR  = "_"
+	RAW_WALLET         = 'r'
+	ENCODED_WALLET     = 'b'
+)
+
+var (
+	ErrInvalidPayload   = errors.New("invalid deep link payload")
+	ErrInvalidSignature = errors.New("invalid deep link signature")
+	ErrPayloadTooLong   = errors.New("deep link payload is too long")
+)
+
+type WalletPayload struct {
+	Coin   string
+	Wallet string
+}
+
+func sign(secret, coin, wallet string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(coin))
+	mac.Write([]byte{0})
+	mac.Write([]byte(wallet))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:SIGNATURE_SIZE])
+}
+
+func isRawWallet(wallet string) bool {
+	for _, r := range wallet {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func encodeWallet(wallet string) string {
+	//	Most addresses are alphanumeric and kept as is to fit into payload length
+	if isRawWallet(wallet) {
+		return string(RAW_WALLET) + wallet
+	}
+
+	return string(ENCODED_WALLET) + base64.RawURLEncoding.EncodeToString([]byte(wallet))
+}
+
+func decodeWallet(encoded string) (string, error) {
+	if len(encoded) < 2 {
+		return "", ErrInvalidPayload
+	}
+
+	switch encoded[0] {
+	case RAW_WALLET:
+		if !isRawWallet(encoded[1:]) {
+			return "", ErrInvalidPayload
+		}
+
+		return encoded[1:], nil
+	case ENCODED_WALLET:
+		wallet, err := base64.RawURLEncoding.DecodeString(encoded[1:])
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+		} else if len(wallet) == 0 {
+			return "", ErrInvalidPayload
+		}
+
+		return string(wallet), nil
+	default:
+		return "", ErrInvalidPayload
+	}
+}
+
+func EncodeWallet(prefix, coin, wallet, secret string) (string, error) {
+	//	Payload is <prefix><coin>_<r wallet | b base64url wallet>[_<base64url signature>]
+	if coin == "" || wallet == "" || strings.Contains(coin, PAYLOAD_SEPARATOR) {
+		return "", ErrInvalidPayload
+	}
+
+	payload := prefix + coin + PAYLOAD_SEPARATOR + encodeWallet(wallet)
+	if secret != "" {
+		payload += PAYLOAD_SEPARATOR + sign(secret, coin, wallet)
+	}
+
+	//	Telegram limits /start parameter length
+	if len(payload) > MAX_PAYLOAD_LENGTH {
+		return "", ErrPayloadTooLong
+	}
+
+	return payload, nil
+}
+
+func DecodeWallet(value, secret string) (*WalletPayload, error) {
+	coin, encoded, ok := strings.Cut(value, PAYLOAD_SEPARATOR)
+	if !ok || coin == "" {
+		return nil, ErrInvalidPayload
+	}
+
+	var signature string
+	if secret != "" {
+		//	Base64url can contain separator too, so signature is cut by its fixed length
+		signatureLength := base64.RawURLEncoding.EncodedLen(SIGNATURE_SIZE)
+		separatorIdx := len(encoded) - signatureLength - 1
+		if separatorIdx <= 0 || encoded[separatorIdx:separatorIdx+1] != PAYLOAD_SEPARATOR {
+			return nil, ErrInvalidSignature
+		}
+
+		encoded, signature = encoded[:separatorIdx], encoded[separatorIdx+1:]
+	}
+
+	wallet, err := decodeWallet(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret != "" && !hmac.Equal([]byte(signature), []byte(sign(secret, coin, wallet))) {
+		return nil, ErrInvalidSignature
+	}
+
+	return &WalletPayload{
+		Coin:   coin,
+		Wallet: wallet,
+	}, nil
+}
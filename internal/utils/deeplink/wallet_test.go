@@ -0,0 +1,277 @@
+package deeplinkUtils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	TEST_PREFIX = "add_"
+	TEST_SECRET = "deep-link-secret"
+)
+
+func encodeTestWallet(t *testing.T, coin, wallet, secret string) string {
+	t.Helper()
+
+	payload, err := EncodeWallet(TEST_PREFIX, coin, wallet, secret)
+	if err != nil {
+		t.Fatalf("failed to encode wallet %q: %v", wallet, err)
+	}
+
+	//	Deep link prefix is cut by default handler before payload is decoded
+	value, ok := strings.CutPrefix(payload, TEST_PREFIX)
+	if !ok {
+		t.Fatalf("payload %q has no prefix %q", payload, TEST_PREFIX)
+	}
+
+	return value
+}
+
+func TestWalletRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		coin    string
+		wallet  string
+		secret  string
+		encoded string
+	}{
+		{
+			name:    "raw wallet",
+			coin:    "btc",
+			wallet:  "bc1qexamplewallet",
+			encoded: "btc_rbc1qexamplewallet",
+		},
+		{
+			name:   "raw wallet with signature",
+			coin:   "btc",
+			wallet: "bc1qexamplewallet",
+			secret: TEST_SECRET,
+		},
+		{
+			name:    "base64 wallet",
+			coin:    "xmr",
+			wallet:  "wallet.with.dots",
+			encoded: "xmr_bd2FsbGV0LndpdGguZG90cw",
+		},
+		{
+			name:    "base64 wallet with separator",
+			coin:    "ltc",
+			wallet:  "ltc:M?abc",
+			encoded: "ltc_bbHRjOk0_YWJj",
+		},
+		{
+			name:   "base64 wallet with separator and signature",
+			coin:   "ltc",
+			wallet: "ltc:M?abc",
+			secret: TEST_SECRET,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			value := encodeTestWallet(t, tt.coin, tt.wallet, tt.secret)
+			if tt.encoded != "" && value != tt.encoded {
+				t.Fatalf("expected payload %q, got %q", tt.encoded, value)
+			}
+
+			payload, err := DecodeWallet(value, tt.secret)
+			if err != nil {
+				t.Fatalf("failed to decode payload %q: %v", value, err)
+			}
+
+			if payload.Coin != tt.coin || payload.Wallet != tt.wallet {
+				t.Fatalf("expected %s wallet %q, got %s wallet %q", tt.coin, tt.wallet, payload.Coin, payload.Wallet)
+			}
+		})
+	}
+}
+
+func TestDecodeWalletErrors(t *testing.T) {
+	signed := encodeTestWallet(t, "btc", "bc1qexamplewallet", TEST_SECRET)
+	tamperedSignature := signed[:len(signed)-1] + "A"
+	if tamperedSignature == signed {
+		tamperedSignature = signed[:len(signed)-1] + "B"
+	}
+
+	tests := []struct {
+		name   string
+		value  string
+		secret string
+		err    error
+	}{
+		{
+			name:   "tampered signature",
+			value:  tamperedSignature,
+			secret: TEST_SECRET,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "tampered wallet",
+			value:  strings.Replace(signed, "example", "attacker", 1),
+			secret: TEST_SECRET,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "signature of other secret",
+			value:  signed,
+			secret: "other-secret",
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "missing signature",
+			value:  encodeTestWallet(t, "btc", "bc1qexamplewallet", ""),
+			secret: TEST_SECRET,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:  "missing separator",
+			value: "btc",
+			err:   ErrInvalidPayload,
+		},
+		{
+			name:  "empty coin",
+			value: "_rbc1qexamplewallet",
+			err:   ErrInvalidPayload,
+		},
+		{
+			name:  "unknown wallet encoding",
+			value: "btc_xbc1qexamplewallet",
+			err:   ErrInvalidPayload,
+		},
+		{
+			name:  "invalid raw wallet",
+			value: "btc_rbc1q.wallet",
+			err:   ErrInvalidPayload,
+		},
+		{
+			name:  "invalid base64 wallet",
+			value: "btc_b!!!",
+			err:   ErrInvalidPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := DecodeWallet(tt.value, tt.secret)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got payload %+v and error %v", tt.err, payload, err)
+			}
+		})
+	}
+}
+
+func TestEncodeWalletErrors(t *testing.T) {
+	//	Prefix, coin, separator and raw wallet marker take 9 characters
+	maxWallet := strings.Repeat("a", MAX_PAYLOAD_LENGTH-len(TEST_PREFIX+"btc_r"))
+
+	if _, err := EncodeWallet(TEST_PREFIX, "btc", maxWallet, ""); err != nil {
+		t.Fatalf("expected wallet of max length to be encoded, got error %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		coin   string
+		wallet string
+		secret string
+		err    error
+	}{
+		{
+			name:   "coin with separator",
+			coin:   "usdt_trc20",
+			wallet: "TExampleWallet",
+			err:    ErrInvalidPayload,
+		},
+		{
+			name:   "empty coin",
+			wallet: "TExampleWallet",
+			err:    ErrInvalidPayload,
+		},
+		{
+			name: "empty wallet",
+			coin: "btc",
+			err:  ErrInvalidPayload,
+		},
+		{
+			name:   "payload too long",
+			coin:   "btc",
+			wallet: maxWallet + "a",
+			err:    ErrPayloadTooLong,
+		},
+		{
+			name:   "payload with signature too long",
+			coin:   "btc",
+			wallet: maxWallet,
+			secret: TEST_SECRET,
+			err:    ErrPayloadTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := EncodeWallet(TEST_PREFIX, tt.coin, tt.wallet, tt.secret)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got payload %q and error %v", tt.err, payload, err)
+			}
+		})
+	}
+}
+
+func TestWalletLengthLimit(t *testing.T) {
+	//	Lengths of real addresses, only short ones fit into telegram payload limit
+	tests := []struct {
+		name   string
+		coin   string
+		length int
+		secret string
+		fits   bool
+	}{
+		{
+			name:   "bitcoin segwit with signature",
+			coin:   "btc",
+			length: 42,
+			secret: TEST_SECRET,
+			fits:   true,
+		},
+		{
+			name:   "ethereum classic with signature",
+			coin:   "etc",
+			length: 42,
+			secret: TEST_SECRET,
+			fits:   true,
+		},
+		{
+			name:   "bitcoin taproot",
+			coin:   "btc",
+			length: 62,
+			fits:   false,
+		},
+		{
+			name:   "monero",
+			coin:   "xmr",
+			length: 95,
+			fits:   false,
+		},
+		{
+			name:   "monero integrated",
+			coin:   "xmr",
+			length: 106,
+			fits:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := EncodeWallet(TEST_PREFIX, tt.coin, strings.Repeat("a", tt.length), tt.secret)
+			if tt.fits && err != nil {
+				t.Fatalf("expected wallet of %d characters to fit, got error %v", tt.length, err)
+			} else if !tt.fits && !errors.Is(err, ErrPayloadTooLong) {
+				t.Fatalf("expected wallet of %d characters to be too long, got payload %q and error %v", tt.length, payload, err)
+			}
+		})
+	}
+}
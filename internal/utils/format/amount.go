@@ -0,0 +1,42 @@
+package formatUtils
+
+import (
+	"math/big"
+	"strings"
+)
+
+var bigTen = big.NewInt(10)
+
+func pow10(exp uint8) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(exp)), nil)
+}
+
+func AmountParts(amount *big.Int, decimals, precision uint8) (*big.Int, string) {
+	//	Amount in atomic units is split into integer part and fraction digits without trailing zeros,
+	//	fraction is rounded half up to precision digits
+	if precision > decimals {
+		precision = decimals
+	}
+
+	scaled := new(big.Int).Set(amount)
+	if precision < decimals {
+		divider := pow10(decimals - precision)
+		half := new(big.Int).Rsh(divider, 1)
+		scaled.Add(scaled, half).Quo(scaled, divider)
+
+		//	Small amounts are shown with all decimals instead of rounding them to zero
+		if scaled.Sign() == 0 && amount.Sign() != 0 {
+			return AmountParts(amount, decimals, decimals)
+		}
+	}
+
+	integer, fraction := new(big.Int).QuoRem(scaled, pow10(precision), new(big.Int))
+	if precision == 0 {
+		return integer, ""
+	}
+
+	digits := fraction.String()
+	digits = strings.Repeat("0", int(precision)-len(digits)) + digits
+
+	return integer, strings.TrimRight(digits, "0")
+}
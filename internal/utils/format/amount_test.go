@@ -0,0 +1,179 @@
+package formatUtils
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func bigInt(t *testing.T, value string) *big.Int {
+	t.Helper()
+
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		t.Fatalf("invalid big int %q", value)
+	}
+
+	return n
+}
+
+func TestAmountParts(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    string
+		decimals  uint8
+		precision uint8
+		integer   string
+		fraction  string
+	}{
+		{
+			name:      "zero",
+			amount:    "0",
+			decimals:  8,
+			precision: 4,
+			integer:   "0",
+			fraction:  "",
+		},
+		{
+			name:      "rounded half up",
+			amount:    "123456789",
+			decimals:  8,
+			precision: 4,
+			integer:   "1",
+			fraction:  "2346",
+		},
+		{
+			name:      "rounded down",
+			amount:    "123444444",
+			decimals:  8,
+			precision: 4,
+			integer:   "1",
+			fraction:  "2344",
+		},
+		{
+			name:      "fraction carried into integer",
+			amount:    "999",
+			decimals:  3,
+			precision: 2,
+			integer:   "1",
+			fraction:  "",
+		},
+		{
+			name:      "small amount shown with all decimals",
+			amount:    "42",
+			decimals:  8,
+			precision: 4,
+			integer:   "0",
+			fraction:  "00000042",
+		},
+		{
+			name:      "small amount rounded up to precision",
+			amount:    "5000",
+			decimals:  8,
+			precision: 4,
+			integer:   "0",
+			fraction:  "0001",
+		},
+		{
+			name:      "precision above decimals clamped",
+			amount:    "1234",
+			decimals:  2,
+			precision: 8,
+			integer:   "12",
+			fraction:  "34",
+		},
+		{
+			name:      "no decimals",
+			amount:    "1234",
+			decimals:  0,
+			precision: 4,
+			integer:   "1234",
+			fraction:  "",
+		},
+		{
+			name:      "zero precision",
+			amount:    "1500000000",
+			decimals:  9,
+			precision: 0,
+			integer:   "2",
+			fraction:  "",
+		},
+		{
+			name:      "trailing zeros trimmed",
+			amount:    "150000000",
+			decimals:  8,
+			precision: 4,
+			integer:   "1",
+			fraction:  "5",
+		},
+		{
+			name:      "leading fraction zeros kept",
+			amount:    "100500000",
+			decimals:  8,
+			precision: 4,
+			integer:   "1",
+			fraction:  "005",
+		},
+		{
+			name:      "integer above max int64",
+			amount:    "123456789012345678901234567",
+			decimals:  6,
+			precision: 2,
+			integer:   "123456789012345678901",
+			fraction:  "23",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			integer, fraction := AmountParts(bigInt(t, tt.amount), tt.decimals, tt.precision)
+			if integer.String() != tt.integer || fraction != tt.fraction {
+				t.Fatalf("expected %s.%s, got %s.%s", tt.integer, tt.fraction, integer, fraction)
+			}
+		})
+	}
+}
+
+func TestBigNumber(t *testing.T) {
+	f := &Formatter{
+		printer:          message.NewPrinter(language.English),
+		decimalSeparator: ".",
+	}
+	maxInt64 := new(big.Int).SetInt64(math.MaxInt64)
+
+	tests := []struct {
+		name     string
+		value    *big.Int
+		expected string
+	}{
+		{
+			name:     "grouped",
+			value:    big.NewInt(1234567),
+			expected: "1,234,567",
+		},
+		{
+			name:     "max int64 grouped",
+			value:    maxInt64,
+			expected: "9,223,372,036,854,775,807",
+		},
+		{
+			//	Values above int64 range are printed without grouping
+			name:     "above max int64",
+			value:    new(big.Int).Add(maxInt64, big.NewInt(1)),
+			expected: "9223372036854775808",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if value := f.BigNumber(tt.value); value != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, value)
+			}
+		})
+	}
+}
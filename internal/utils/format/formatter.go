@@ -0,0 +1,175 @@
+package formatUtils
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+const (
+	LANGUAGE_MESSAGE_ID = "Language"
+	TIME_LAYOUT         = "15:04 MST"
+)
+
+var monthMessageIDs = [12]string{
+	"MonthJanuary",
+	"MonthFebruary",
+	"MonthMarch",
+	"MonthApril",
+	"MonthMay",
+	"MonthJune",
+	"MonthJuly",
+	"MonthAugust",
+	"MonthSeptember",
+	"MonthOctober",
+	"MonthNovember",
+	"MonthDecember",
+}
+
+var formatters sync.Map
+
+type Formatter struct {
+	localizer        *i18n.Localizer
+	printer          *message.Printer
+	decimalSeparator string
+}
+
+func (f *Formatter) Number(value int64) string {
+	return f.printer.Sprint(number.Decimal(value))
+}
+
+func (f *Formatter) Decimal(value float64, digits int) string {
+	return f.printer.Sprint(number.Decimal(value, number.MinFractionDigits(digits), number.MaxFractionDigits(digits)))
+}
+
+func (f *Formatter) BigNumber(value *big.Int) string {
+	if value.IsInt64() {
+		return f.Number(value.Int64())
+	}
+
+	return value.String()
+}
+
+func (f *Formatter) Amount(amount uint64, decimals, precision uint8) string {
+	integer, fraction := AmountParts(new(big.Int).SetUint64(amount), decimals, precision)
+	if fraction == "" {
+		return f.BigNumber(integer)
+	}
+
+	return f.BigNumber(integer) + f.decimalSeparator + fraction
+}
+
+func (f *Formatter) Hashrate(hashrate *big.Int) string {
+	divider := big.NewInt(HashrateUnitStep)
+	unitStep := big.NewInt(HashrateUnitStep)
+	i := 0
+
+	for i < len(HashrateUnits)-1 {
+		next := new(big.Int).Mul(divider, unitStep)
+		if hashrate.Cmp(next) == -1 {
+			break
+		}
+
+		divider = next
+		i++
+	}
+
+	h, _ := new(big.Float).Quo(new(big.Float).SetInt(hashrate), new(big.Float).SetInt(divider)).Float64()
+
+	return f.Decimal(h, 2) + " " + HashrateUnits[i]
+}
+
+func (f *Formatter) Count(messageID string, count int64) string {
+	return f.localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: messageID,
+		TemplateData: map[string]string{
+			"Count": f.Number(count),
+		},
+		PluralCount: count,
+	})
+}
+
+func (f *Formatter) Minutes(minutes int) string {
+	return f.Count("Minute", int64(minutes))
+}
+
+func (f *Formatter) Days(days int) string {
+	return f.Count("Day", int64(days))
+}
+
+func (f *Formatter) Duration(d time.Duration) string {
+	items := []string{}
+	days := int64(d / (24 * time.Hour))
+	hours := int64(d/time.Hour) % 24
+	minutes := int64(d/time.Minute) % 60
+
+	if days > 0 {
+		items = append(items, f.Count("Day", days))
+	}
+
+	if hours > 0 {
+		items = append(items, f.Count("Hour", hours))
+	}
+
+	if minutes > 0 || len(items) == 0 {
+		items = append(items, f.Count("Minute", minutes))
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func (f *Formatter) Uptime(t time.Time) string {
+	return f.Duration(time.Since(t))
+}
+
+func (f *Formatter) Time(t time.Time) string {
+	return t.UTC().Format(TIME_LAYOUT)
+}
+
+func (f *Formatter) DateTime(t time.Time) string {
+	t = t.UTC()
+
+	return f.localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "DateTimeFormat",
+		TemplateData: map[string]string{
+			"Day": strconv.Itoa(t.Day()),
+			"Month": f.localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: monthMessageIDs[t.Month()-1],
+			}),
+			"Year": strconv.Itoa(t.Year()),
+			"Time": f.Time(t),
+		},
+	})
+}
+
+func NewFormatter(l *i18n.Localizer) *Formatter {
+	//	Localizers are created once per loaded locale, so formatters are cached for process lifetime
+	if f, ok := formatters.Load(l); ok {
+		return f.(*Formatter)
+	}
+
+	//	Localizer doesn't expose its language, so it's taken from message every locale defines
+	_, tag, err := l.LocalizeWithTag(&i18n.LocalizeConfig{
+		MessageID: LANGUAGE_MESSAGE_ID,
+	})
+	if err != nil {
+		tag = language.English
+	}
+
+	printer := message.NewPrinter(tag)
+	f, _ := formatters.LoadOrStore(l, &Formatter{
+		localizer: l,
+		printer:   printer,
+		//	Fraction of exact amounts is built manually, so only locale separator is taken from printer
+		decimalSeparator: strings.Trim(printer.Sprint(number.Decimal(1.5)), "15"),
+	})
+
+	return f.(*Formatter)
+}
@@ -1,36 +1,38 @@
 package formatUtils
 
-import (
-	"fmt"
-	"math/big"
-)
+import "fmt"
+
+const SHORT_WALLET_PART_LENGTH = 6
 
 var (
 	HashrateUnits          = []string{"kH/s", "MH/s", "GH/s", "TH/s", "PH/s", "EH/s"}
 	HashrateUnitStep int64 = 1000
 )
 
-func WalletBalance(balance uint64, atomicUnit uint16) string {
-	balanceFormatted := float64(balance) / float64(atomicUnit)
+func ShortWallet(wallet string) string {
+	if len(wallet) <= 2*SHORT_WALLET_PART_LENGTH+3 {
+		return wallet
+	}
 
-	return fmt.Sprintf("%.2f", balanceFormatted)
+	return wallet[:SHORT_WALLET_PART_LENGTH] + "..." + wallet[len(wallet)-SHORT_WALLET_PART_LENGTH:]
 }
 
-func Hashrate(hashrate *big.Int) string {
-	c := big.NewInt(1)
-	i := 0
-
-	for idx := range HashrateUnits {
-		c.Mul(c, big.NewInt(HashrateUnitStep))
+func WalletName(wallet, label string, short bool) string {
+	if short {
+		wallet = ShortWallet(wallet)
+	}
 
-		if hashrate.Cmp(c) == -1 {
-			i = idx
-		}
+	if label == "" {
+		return wallet
 	}
 
-	h := *hashrate
-	h.Div(hashrate, c)
-	hf, _ := h.Float64()
+	return fmt.Sprintf("%s (%s)", label, wallet)
+}
+
+func WorkerName(worker, alias string) string {
+	if alias == "" {
+		return worker
+	}
 
-	return fmt.Sprintf("%.2f %s", hf, HashrateUnits[i])
+	return fmt.Sprintf("%s (%s)", alias, worker)
 }
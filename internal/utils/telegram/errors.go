@@ -0,0 +1,60 @@
+package telegramUtils
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+)
+
+type SendErrorKind string
+
+const (
+	SendErrorBlocked      SendErrorKind = "blocked"
+	SendErrorChatNotFound SendErrorKind = "chat_not_found"
+	SendErrorRateLimited  SendErrorKind = "rate_limited"
+	SendErrorTransient    SendErrorKind = "transient"
+	SendErrorRejected     SendErrorKind = "rejected"
+)
+
+const (
+	CHAT_NOT_FOUND_DESCRIPTION = "chat not found"
+	TRANSIENT_RETRY_DELAY      = time.Second
+)
+
+func ClassifySendError(err error) SendErrorKind {
+	var tooManyRequestsErr *bot.TooManyRequestsError
+
+	switch {
+	case errors.As(err, &tooManyRequestsErr):
+		return SendErrorRateLimited
+	//	Bot was blocked by the user, user is deactivated or bot was kicked from the group
+	case errors.Is(err, bot.ErrorForbidden):
+		return SendErrorBlocked
+	case errors.Is(err, bot.ErrorBadRequest) && strings.Contains(err.Error(), CHAT_NOT_FOUND_DESCRIPTION):
+		return SendErrorChatNotFound
+	case errors.Is(err, bot.ErrorBadRequest), errors.Is(err, bot.ErrorNotFound):
+		return SendErrorRejected
+	default:
+		return SendErrorTransient
+	}
+}
+
+func (k SendErrorKind) Unreachable() bool {
+	//	Such errors are repeated for every next message until user starts bot again
+	return k == SendErrorBlocked || k == SendErrorChatNotFound
+}
+
+func (k SendErrorKind) Retryable() bool {
+	return k == SendErrorRateLimited || k == SendErrorTransient
+}
+
+func RetryDelay(err error, attempt int) time.Duration {
+	var tooManyRequestsErr *bot.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsErr) {
+		return time.Duration(tooManyRequestsErr.RetryAfter) * time.Second
+	}
+
+	return time.Duration(attempt) * TRANSIENT_RETRY_DELAY
+}
@@ -0,0 +1,4 @@
+ALTER TABLE blockchains DROP COLUMN IF EXISTS pool_api_tls_cert;
+ALTER TABLE blockchains DROP COLUMN IF EXISTS pool_api_tls_key;
+ALTER TABLE blockchains DROP COLUMN IF EXISTS pool_api_token;
+ALTER TABLE blockchains DROP COLUMN IF EXISTS pool_api_insecure;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE blockchains ADD COLUMN pool_api_tls_cert VARCHAR(64);
+ALTER TABLE blockchains ADD COLUMN pool_api_tls_key VARCHAR(64);
+ALTER TABLE blockchains ADD COLUMN pool_api_token VARCHAR(512);
+ALTER TABLE blockchains ADD COLUMN pool_api_insecure BOOLEAN NOT NULL DEFAULT false;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS admins;
+DROP TABLE IF EXISTS notify_pauses;
+DROP INDEX IF EXISTS users_username_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS username;
\ No newline at end of file
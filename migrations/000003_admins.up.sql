@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS admins (
+    user_id BIGINT NOT NULL PRIMARY KEY,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE admins ADD CONSTRAINT admins_user_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS notify_pauses (
+    scope VARCHAR(32) NOT NULL PRIMARY KEY,
+    paused_by BIGINT NOT NULL,
+    paused_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE users ADD COLUMN username VARCHAR(32);
+
+CREATE INDEX users_username_idx ON users USING BTREE(LOWER(username));
\ No newline at end of file
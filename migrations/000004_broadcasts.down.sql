@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS broadcast_deliveries;
+DROP TABLE IF EXISTS broadcast_coins;
+DROP TABLE IF EXISTS broadcast_messages;
+DROP TABLE IF EXISTS broadcasts;
+DROP SEQUENCE IF EXISTS broadcasts_id_seq;
+ALTER TABLE users DROP COLUMN IF EXISTS broadcasts_notify;
\ No newline at end of file
@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS broadcasts (
+    id BIGINT NOT NULL PRIMARY KEY,
+    created_by BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'draft',
+    default_lang VARCHAR(16),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE SEQUENCE broadcasts_id_seq
+    AS BIGINT
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE broadcasts_id_seq OWNED BY broadcasts.id;
+ALTER TABLE ONLY broadcasts ALTER COLUMN id SET DEFAULT nextval('broadcasts_id_seq');
+SELECT setval('broadcasts_id_seq', 1);
+
+CREATE INDEX broadcasts_created_by_status_idx ON broadcasts USING BTREE(created_by, status);
+
+CREATE TABLE IF NOT EXISTS broadcast_messages (
+    broadcast_id BIGINT NOT NULL,
+    lang VARCHAR(16) NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY(broadcast_id, lang)
+);
+
+ALTER TABLE broadcast_messages ADD CONSTRAINT broadcast_messages_broadcast_fkey FOREIGN KEY (broadcast_id) REFERENCES broadcasts(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS broadcast_coins (
+    broadcast_id BIGINT NOT NULL,
+    blockchain_coin VARCHAR(32) NOT NULL,
+    PRIMARY KEY(broadcast_id, blockchain_coin)
+);
+
+ALTER TABLE broadcast_coins ADD CONSTRAINT broadcast_coins_broadcast_fkey FOREIGN KEY (broadcast_id) REFERENCES broadcasts(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE broadcast_coins ADD CONSTRAINT broadcast_coins_blockchain_fkey FOREIGN KEY (blockchain_coin) REFERENCES blockchains(coin) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS broadcast_deliveries (
+    broadcast_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    error TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(broadcast_id, user_id)
+);
+
+ALTER TABLE broadcast_deliveries ADD CONSTRAINT broadcast_deliveries_broadcast_fkey FOREIGN KEY (broadcast_id) REFERENCES broadcasts(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE broadcast_deliveries ADD CONSTRAINT broadcast_deliveries_user_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE INDEX broadcast_deliveries_status_idx ON broadcast_deliveries USING BTREE(broadcast_id, status);
+
+ALTER TABLE users ADD COLUMN broadcasts_notify BOOLEAN NOT NULL DEFAULT true;
\ No newline at end of file
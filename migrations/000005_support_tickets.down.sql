@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS user_feedback (
+    user_id BIGINT NOT NULL,
+    first_name VARCHAR(255),
+    last_name VARCHAR(255),
+    username VARCHAR(32),
+    report_message TEXT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO user_feedback (user_id, username, report_message, added_at)
+    SELECT support_tickets.user_id, users.username, support_ticket_messages.text, support_ticket_messages.created_at
+    FROM support_ticket_messages
+    INNER JOIN support_tickets ON support_tickets.id = support_ticket_messages.ticket_id
+    INNER JOIN users ON users.id = support_tickets.user_id
+    WHERE support_ticket_messages.direction = 'user';
+
+DROP TABLE IF EXISTS support_ticket_messages;
+DROP TABLE IF EXISTS support_tickets;
+DROP SEQUENCE IF EXISTS support_ticket_messages_id_seq;
+DROP SEQUENCE IF EXISTS support_tickets_id_seq;
\ No newline at end of file
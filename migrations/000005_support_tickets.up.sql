@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS support_tickets (
+    id BIGINT NOT NULL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    support_message_id BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP
+);
+
+ALTER TABLE support_tickets ADD CONSTRAINT support_tickets_user_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE SEQUENCE support_tickets_id_seq
+    AS BIGINT
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+    
+ALTER SEQUENCE support_tickets_id_seq OWNED BY support_tickets.id;
+ALTER TABLE ONLY support_tickets ALTER COLUMN id SET DEFAULT nextval('support_tickets_id_seq');
+SELECT setval('support_tickets_id_seq', 1);
+
+CREATE INDEX support_tickets_user_status_idx ON support_tickets USING BTREE(user_id, status);
+CREATE INDEX support_tickets_support_message_idx ON support_tickets USING BTREE(support_message_id);
+
+CREATE TABLE IF NOT EXISTS support_ticket_messages (
+    id BIGINT NOT NULL PRIMARY KEY,
+    ticket_id BIGINT NOT NULL,
+    direction VARCHAR(16) NOT NULL,
+    author_id BIGINT NOT NULL,
+    text TEXT NOT NULL DEFAULT '',
+    attachment_type VARCHAR(16),
+    attachment_file_id VARCHAR(256),
+    user_message_id BIGINT,
+    support_message_id BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE support_ticket_messages ADD CONSTRAINT support_ticket_messages_ticket_fkey FOREIGN KEY (ticket_id) REFERENCES support_tickets(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE SEQUENCE support_ticket_messages_id_seq
+    AS BIGINT
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+    
+ALTER SEQUENCE support_ticket_messages_id_seq OWNED BY support_ticket_messages.id;
+ALTER TABLE ONLY support_ticket_messages ALTER COLUMN id SET DEFAULT nextval('support_ticket_messages_id_seq');
+SELECT setval('support_ticket_messages_id_seq', 1);
+
+CREATE INDEX support_ticket_messages_ticket_idx ON support_ticket_messages USING BTREE(ticket_id);
+CREATE INDEX support_ticket_messages_user_message_idx ON support_ticket_messages USING BTREE(user_message_id);
+CREATE INDEX support_ticket_messages_support_message_idx ON support_ticket_messages USING BTREE(support_message_id);
+
+DO $$
+DECLARE
+    feedback RECORD;
+    new_ticket_id BIGINT;
+BEGIN
+    FOR feedback IN SELECT * FROM user_feedback WHERE user_id IN (SELECT id FROM users) ORDER BY added_at LOOP
+        INSERT INTO support_tickets (user_id, created_at, updated_at)
+            VALUES (feedback.user_id, feedback.added_at, feedback.added_at)
+            RETURNING id INTO new_ticket_id;
+        INSERT INTO support_ticket_messages (ticket_id, direction, author_id, text, created_at)
+            VALUES (new_ticket_id, 'user', feedback.user_id, feedback.report_message, feedback.added_at);
+    END LOOP;
+END $$;
+
+DROP TABLE IF EXISTS user_feedback;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wallet_chat_targets;
+DROP TABLE IF EXISTS chat_targets;
+DROP SEQUENCE IF EXISTS chat_targets_id_seq;
\ No newline at end of file
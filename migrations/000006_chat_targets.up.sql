@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS chat_targets (
+    id BIGINT NOT NULL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    thread_id BIGINT NOT NULL DEFAULT 0,
+    chat_type VARCHAR(16) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    added_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE chat_targets ADD CONSTRAINT chat_targets_user_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE chat_targets ADD CONSTRAINT chat_targets_unique_chat UNIQUE (user_id, chat_id, thread_id);
+
+CREATE SEQUENCE chat_targets_id_seq
+    AS BIGINT
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+    
+ALTER SEQUENCE chat_targets_id_seq OWNED BY chat_targets.id;
+ALTER TABLE ONLY chat_targets ALTER COLUMN id SET DEFAULT nextval('chat_targets_id_seq');
+SELECT setval('chat_targets_id_seq', 1);
+
+CREATE INDEX chat_targets_chat_idx ON chat_targets USING BTREE(chat_id);
+
+CREATE TABLE IF NOT EXISTS wallet_chat_targets (
+    wallet_id BIGINT NOT NULL,
+    target_id BIGINT NOT NULL,
+    PRIMARY KEY(wallet_id, target_id)
+);
+
+ALTER TABLE wallet_chat_targets ADD CONSTRAINT wallet_chat_targets_wallet_fkey FOREIGN KEY (wallet_id) REFERENCES user_wallets(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE wallet_chat_targets ADD CONSTRAINT wallet_chat_targets_target_fkey FOREIGN KEY (target_id) REFERENCES chat_targets(id) ON UPDATE CASCADE ON DELETE CASCADE;
\ No newline at end of file
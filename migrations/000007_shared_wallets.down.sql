@@ -0,0 +1,46 @@
+DROP TABLE IF EXISTS wallet_invites;
+
+ALTER TABLE user_wallets ADD COLUMN blockchain_coin VARCHAR(32);
+ALTER TABLE user_wallets ADD COLUMN wallet VARCHAR(256);
+
+UPDATE user_wallets SET blockchain_coin = wallets.blockchain_coin, wallet = wallets.wallet
+FROM wallets
+WHERE wallets.id = user_wallets.wallet_id;
+
+ALTER TABLE user_wallets ALTER COLUMN blockchain_coin SET NOT NULL;
+ALTER TABLE user_wallets ALTER COLUMN wallet SET NOT NULL;
+ALTER TABLE user_wallets ADD CONSTRAINT user_wallets_blockchain_fkey FOREIGN KEY (blockchain_coin) REFERENCES blockchains(coin) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS user_wallet_workers (
+    wallet_id BIGINT NOT NULL,
+    worker VARCHAR(64) NOT NULL,
+    region VARCHAR(32) NOT NULL,
+    solo BOOLEAN NOT NULL,
+    connected_at TIMESTAMP NOT NULL,
+    PRIMARY KEY(wallet_id, worker)
+);
+
+INSERT INTO user_wallet_workers (wallet_id, worker, region, solo, connected_at)
+SELECT
+    user_wallets.id,
+    wallet_workers.worker,
+    wallet_workers.region,
+    wallet_workers.solo,
+    wallet_workers.connected_at
+FROM wallet_workers
+INNER JOIN user_wallets ON user_wallets.wallet_id = wallet_workers.wallet_id;
+
+DROP TABLE IF EXISTS wallet_workers;
+ALTER TABLE user_wallet_workers RENAME TO wallet_workers;
+ALTER TABLE wallet_workers RENAME CONSTRAINT user_wallet_workers_pkey TO wallet_workers_pkey;
+ALTER TABLE wallet_workers ADD CONSTRAINT wallet_workers_wallet_fkey FOREIGN KEY (wallet_id) REFERENCES user_wallets(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE wallet_workers ADD CONSTRAINT wallet_workers_unique_worker UNIQUE (wallet_id, worker);
+
+DROP INDEX IF EXISTS user_wallets_wallet_idx;
+ALTER TABLE user_wallets DROP CONSTRAINT IF EXISTS user_wallets_unique_wallet;
+ALTER TABLE user_wallets ADD CONSTRAINT user_wallets_unique_wallet UNIQUE (user_id, blockchain_coin, wallet);
+ALTER TABLE user_wallets DROP COLUMN IF EXISTS role;
+ALTER TABLE user_wallets DROP COLUMN IF EXISTS wallet_id;
+
+DROP TABLE IF EXISTS wallets;
+DROP SEQUENCE IF EXISTS wallets_id_seq;
\ No newline at end of file
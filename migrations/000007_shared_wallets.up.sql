@@ -0,0 +1,84 @@
+CREATE TABLE IF NOT EXISTS wallets (
+    id BIGINT NOT NULL PRIMARY KEY,
+    blockchain_coin VARCHAR(32) NOT NULL,
+    wallet VARCHAR(256) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE wallets ADD CONSTRAINT wallets_blockchain_fkey FOREIGN KEY (blockchain_coin) REFERENCES blockchains(coin) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE wallets ADD CONSTRAINT wallets_unique_wallet UNIQUE (blockchain_coin, wallet);
+
+CREATE SEQUENCE wallets_id_seq
+    AS BIGINT
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+    
+ALTER SEQUENCE wallets_id_seq OWNED BY wallets.id;
+ALTER TABLE ONLY wallets ALTER COLUMN id SET DEFAULT nextval('wallets_id_seq');
+SELECT setval('wallets_id_seq', 1);
+
+INSERT INTO wallets (blockchain_coin, wallet, created_at)
+SELECT blockchain_coin, wallet, MIN(added_at) FROM user_wallets GROUP BY blockchain_coin, wallet;
+
+ALTER TABLE user_wallets ADD COLUMN wallet_id BIGINT;
+ALTER TABLE user_wallets ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'owner';
+
+UPDATE user_wallets SET wallet_id = wallets.id
+FROM wallets
+WHERE wallets.blockchain_coin = user_wallets.blockchain_coin AND wallets.wallet = user_wallets.wallet;
+
+ALTER TABLE user_wallets ALTER COLUMN wallet_id SET NOT NULL;
+ALTER TABLE user_wallets ADD CONSTRAINT user_wallets_wallet_fkey FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE user_wallets ADD CONSTRAINT user_wallets_role_check CHECK (role IN ('owner', 'viewer'));
+ALTER TABLE user_wallets DROP CONSTRAINT user_wallets_unique_wallet;
+ALTER TABLE user_wallets ADD CONSTRAINT user_wallets_unique_wallet UNIQUE (user_id, wallet_id);
+
+CREATE INDEX user_wallets_wallet_idx ON user_wallets USING BTREE(wallet_id);
+
+CREATE TABLE IF NOT EXISTS shared_wallet_workers (
+    wallet_id BIGINT NOT NULL,
+    worker VARCHAR(64) NOT NULL,
+    region VARCHAR(32) NOT NULL,
+    solo BOOLEAN NOT NULL,
+    connected_at TIMESTAMP NOT NULL,
+    PRIMARY KEY(wallet_id, worker)
+);
+
+INSERT INTO shared_wallet_workers (wallet_id, worker, region, solo, connected_at)
+SELECT DISTINCT ON (user_wallets.wallet_id, wallet_workers.worker)
+    user_wallets.wallet_id,
+    wallet_workers.worker,
+    wallet_workers.region,
+    wallet_workers.solo,
+    wallet_workers.connected_at
+FROM wallet_workers
+INNER JOIN user_wallets ON user_wallets.id = wallet_workers.wallet_id
+ORDER BY user_wallets.wallet_id, wallet_workers.worker, wallet_workers.connected_at DESC;
+
+DROP TABLE wallet_workers;
+ALTER TABLE shared_wallet_workers RENAME TO wallet_workers;
+ALTER TABLE wallet_workers RENAME CONSTRAINT shared_wallet_workers_pkey TO wallet_workers_pkey;
+ALTER TABLE wallet_workers ADD CONSTRAINT wallet_workers_wallet_fkey FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE user_wallets DROP COLUMN blockchain_coin;
+ALTER TABLE user_wallets DROP COLUMN wallet;
+
+SELECT setval('wallets_id_seq', COALESCE((SELECT MAX(id) FROM wallets), 1));
+
+CREATE TABLE IF NOT EXISTS wallet_invites (
+    token VARCHAR(64) NOT NULL PRIMARY KEY,
+    wallet_id BIGINT NOT NULL,
+    created_by BIGINT NOT NULL,
+    role VARCHAR(16) NOT NULL DEFAULT 'viewer',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE wallet_invites ADD CONSTRAINT wallet_invites_wallet_fkey FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE wallet_invites ADD CONSTRAINT wallet_invites_user_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE wallet_invites ADD CONSTRAINT wallet_invites_role_check CHECK (role IN ('owner', 'viewer'));
+
+CREATE INDEX wallet_invites_expires_time_idx ON wallet_invites USING BTREE(expires_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS worker_aliases;
+ALTER TABLE users DROP COLUMN IF EXISTS short_wallets;
+ALTER TABLE user_wallets DROP COLUMN IF EXISTS label;
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE user_wallets ADD COLUMN label VARCHAR(64);
+ALTER TABLE users ADD COLUMN short_wallets BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS worker_aliases (
+    wallet_id BIGINT NOT NULL,
+    worker VARCHAR(64) NOT NULL,
+    alias VARCHAR(64) NOT NULL,
+    PRIMARY KEY(wallet_id, worker)
+);
+
+ALTER TABLE worker_aliases ADD CONSTRAINT worker_aliases_wallet_fkey FOREIGN KEY (wallet_id) REFERENCES user_wallets(id) ON UPDATE CASCADE ON DELETE CASCADE;
\ No newline at end of file
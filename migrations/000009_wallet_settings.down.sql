@@ -0,0 +1,2 @@
+ALTER TABLE user_wallets DROP COLUMN IF EXISTS paused;
+ALTER TABLE user_wallets DROP COLUMN IF EXISTS notify;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE user_wallets ADD COLUMN notify BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE user_wallets ADD COLUMN paused BOOLEAN NOT NULL DEFAULT false;
\ No newline at end of file
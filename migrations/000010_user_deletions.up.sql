@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS user_deletions (
+    id BIGINT NOT NULL PRIMARY KEY,
+    reason VARCHAR(16) NOT NULL,
+    wallets_count INTEGER NOT NULL DEFAULT 0,
+    chat_targets_count INTEGER NOT NULL DEFAULT 0,
+    tickets_count INTEGER NOT NULL DEFAULT 0,
+    deleted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE user_deletions ADD CONSTRAINT user_deletions_reason_check CHECK (reason IN ('user_request', 'bot_blocked'));
+
+CREATE SEQUENCE user_deletions_id_seq
+    AS BIGINT
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+    
+ALTER SEQUENCE user_deletions_id_seq OWNED BY user_deletions.id;
+ALTER TABLE ONLY user_deletions ALTER COLUMN id SET DEFAULT nextval('user_deletions_id_seq');
+SELECT setval('user_deletions_id_seq', 1);
+
+CREATE INDEX user_deletions_deleted_time_idx ON user_deletions USING BTREE(deleted_at);
\ No newline at end of file
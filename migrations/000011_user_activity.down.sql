@@ -0,0 +1,10 @@
+ALTER TABLE user_deletions DROP CONSTRAINT IF EXISTS user_deletions_reason_check;
+UPDATE user_deletions SET reason = 'bot_blocked' WHERE reason = 'inactive';
+ALTER TABLE user_deletions ADD CONSTRAINT user_deletions_reason_check CHECK (reason IN ('user_request', 'bot_blocked'));
+
+DROP INDEX IF EXISTS users_inactive_since_idx;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_inactive_reason_check;
+ALTER TABLE users DROP COLUMN IF EXISTS inactive_since;
+ALTER TABLE users DROP COLUMN IF EXISTS inactive_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS active;
\ No newline at end of file
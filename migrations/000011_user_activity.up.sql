@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN active BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN inactive_reason VARCHAR(16);
+ALTER TABLE users ADD COLUMN inactive_since TIMESTAMP;
+
+ALTER TABLE users ADD CONSTRAINT users_inactive_reason_check CHECK (inactive_reason IN ('blocked', 'chat_not_found'));
+
+CREATE INDEX users_inactive_since_idx ON users USING BTREE(inactive_since) WHERE active = false;
+
+ALTER TABLE user_deletions DROP CONSTRAINT user_deletions_reason_check;
+UPDATE user_deletions SET reason = 'inactive' WHERE reason = 'bot_blocked';
+ALTER TABLE user_deletions ADD CONSTRAINT user_deletions_reason_check CHECK (reason IN ('user_request', 'inactive'));
\ No newline at end of file
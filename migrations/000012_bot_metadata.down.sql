@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bot_metadata;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS bot_metadata (
+    key VARCHAR(128) NOT NULL PRIMARY KEY,
+    hash VARCHAR(64) NOT NULL,
+    synced_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
\ No newline at end of file
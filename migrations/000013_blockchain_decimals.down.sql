@@ -0,0 +1,10 @@
+ALTER TABLE blockchains ADD COLUMN atomic_unit SMALLINT;
+
+UPDATE blockchains SET atomic_unit = CAST(POWER(10, LEAST(decimals, 4)) AS SMALLINT);
+
+ALTER TABLE blockchains ALTER COLUMN atomic_unit SET NOT NULL;
+
+ALTER TABLE blockchains DROP CONSTRAINT IF EXISTS blockchains_display_precision_check;
+ALTER TABLE blockchains DROP CONSTRAINT IF EXISTS blockchains_decimals_check;
+ALTER TABLE blockchains DROP COLUMN IF EXISTS display_precision;
+ALTER TABLE blockchains DROP COLUMN IF EXISTS decimals;
\ No newline at end of file
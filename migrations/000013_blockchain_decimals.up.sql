@@ -0,0 +1,10 @@
+ALTER TABLE blockchains ADD COLUMN decimals SMALLINT;
+ALTER TABLE blockchains ADD COLUMN display_precision SMALLINT;
+
+UPDATE blockchains SET decimals = CAST(ROUND(LOG(10, GREATEST(atomic_unit, 1))) AS SMALLINT);
+
+ALTER TABLE blockchains ALTER COLUMN decimals SET NOT NULL;
+ALTER TABLE blockchains ADD CONSTRAINT blockchains_decimals_check CHECK (decimals BETWEEN 0 AND 36);
+ALTER TABLE blockchains ADD CONSTRAINT blockchains_display_precision_check CHECK (display_precision BETWEEN 0 AND decimals);
+
+ALTER TABLE blockchains DROP COLUMN IF EXISTS atomic_unit;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users RENAME COLUMN blocks_notify TO block_notify;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users RENAME COLUMN block_notify TO blocks_notify;
\ No newline at end of file
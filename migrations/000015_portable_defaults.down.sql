@@ -0,0 +1,14 @@
+ALTER TABLE user_wallets ALTER COLUMN added_at SET DEFAULT NOW();
+ALTER TABLE payouts_notifications ALTER COLUMN executed_at SET DEFAULT NOW();
+ALTER TABLE admins ALTER COLUMN added_at SET DEFAULT NOW();
+ALTER TABLE notify_pauses ALTER COLUMN paused_at SET DEFAULT NOW();
+ALTER TABLE broadcasts ALTER COLUMN created_at SET DEFAULT NOW();
+ALTER TABLE broadcast_deliveries ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE support_tickets ALTER COLUMN created_at SET DEFAULT NOW();
+ALTER TABLE support_tickets ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE support_ticket_messages ALTER COLUMN created_at SET DEFAULT NOW();
+ALTER TABLE chat_targets ALTER COLUMN added_at SET DEFAULT NOW();
+ALTER TABLE wallets ALTER COLUMN created_at SET DEFAULT NOW();
+ALTER TABLE wallet_invites ALTER COLUMN created_at SET DEFAULT NOW();
+ALTER TABLE user_deletions ALTER COLUMN deleted_at SET DEFAULT NOW();
+ALTER TABLE bot_metadata ALTER COLUMN synced_at SET DEFAULT NOW();
\ No newline at end of file
@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS bot_metadata;
+DROP TABLE IF EXISTS user_deletions;
+DROP TABLE IF EXISTS wallet_chat_targets;
+DROP TABLE IF EXISTS chat_targets;
+DROP TABLE IF EXISTS support_ticket_messages;
+DROP TABLE IF EXISTS support_tickets;
+DROP TABLE IF EXISTS broadcast_deliveries;
+DROP TABLE IF EXISTS broadcast_coins;
+DROP TABLE IF EXISTS broadcast_messages;
+DROP TABLE IF EXISTS broadcasts;
+DROP TABLE IF EXISTS notify_pauses;
+DROP TABLE IF EXISTS admins;
+DROP TABLE IF EXISTS payouts_notifications;
+DROP TABLE IF EXISTS wallet_invites;
+DROP TABLE IF EXISTS worker_aliases;
+DROP TABLE IF EXISTS wallet_workers;
+DROP TABLE IF EXISTS user_wallets;
+DROP TABLE IF EXISTS wallets;
+DROP TABLE IF EXISTS user_actions;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS blockchains;
\ No newline at end of file
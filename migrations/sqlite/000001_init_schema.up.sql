@@ -0,0 +1,207 @@
+CREATE TABLE IF NOT EXISTS blockchains (
+    coin VARCHAR(32) NOT NULL PRIMARY KEY,
+    name VARCHAR(128) NOT NULL UNIQUE,
+    ticker VARCHAR(16) NOT NULL UNIQUE,
+    example_wallet VARCHAR(256) NOT NULL,
+    pool_api_url VARCHAR(64) NOT NULL UNIQUE,
+    pool_api_tls_ca VARCHAR(64) NOT NULL,
+    pool_api_server_name VARCHAR(128) NOT NULL,
+    pool_api_tls_cert VARCHAR(64),
+    pool_api_tls_key VARCHAR(64),
+    pool_api_token VARCHAR(512),
+    pool_api_insecure BOOLEAN NOT NULL DEFAULT false,
+    decimals SMALLINT NOT NULL CHECK (decimals BETWEEN 0 AND 36),
+    display_precision SMALLINT CHECK (display_precision BETWEEN 0 AND decimals)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER NOT NULL PRIMARY KEY,
+    chat_id BIGINT NOT NULL UNIQUE,
+    lang VARCHAR(16) NOT NULL,
+    payouts_notify BOOLEAN NOT NULL DEFAULT true,
+    blocks_notify BOOLEAN NOT NULL DEFAULT true,
+    username VARCHAR(32),
+    broadcasts_notify BOOLEAN NOT NULL DEFAULT true,
+    short_wallets BOOLEAN NOT NULL DEFAULT false,
+    active BOOLEAN NOT NULL DEFAULT true,
+    inactive_reason VARCHAR(16) CHECK (inactive_reason IN ('blocked', 'chat_not_found')),
+    inactive_since TIMESTAMP
+);
+
+CREATE INDEX users_username_idx ON users (LOWER(username));
+CREATE INDEX users_inactive_since_idx ON users (inactive_since) WHERE active = false;
+
+CREATE TABLE IF NOT EXISTS user_actions (
+    user_id INTEGER NOT NULL PRIMARY KEY,
+    action VARCHAR(128) NOT NULL,
+    payload TEXT
+);
+
+CREATE TABLE IF NOT EXISTS wallets (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    blockchain_coin VARCHAR(32) NOT NULL REFERENCES blockchains(coin) ON UPDATE CASCADE ON DELETE CASCADE,
+    wallet VARCHAR(256) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (blockchain_coin, wallet)
+);
+
+CREATE TABLE IF NOT EXISTS user_wallets (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL DEFAULT 'owner' CHECK (role IN ('owner', 'viewer')),
+    label VARCHAR(64),
+    notify BOOLEAN NOT NULL DEFAULT true,
+    paused BOOLEAN NOT NULL DEFAULT false,
+    UNIQUE (user_id, wallet_id)
+);
+
+CREATE INDEX user_wallets_wallet_idx ON user_wallets (wallet_id);
+
+CREATE TABLE IF NOT EXISTS wallet_workers (
+    wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    worker VARCHAR(64) NOT NULL,
+    region VARCHAR(32) NOT NULL,
+    solo BOOLEAN NOT NULL,
+    connected_at TIMESTAMP NOT NULL,
+    PRIMARY KEY(wallet_id, worker)
+);
+
+CREATE TABLE IF NOT EXISTS worker_aliases (
+    wallet_id BIGINT NOT NULL REFERENCES user_wallets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    worker VARCHAR(64) NOT NULL,
+    alias VARCHAR(64) NOT NULL,
+    PRIMARY KEY(wallet_id, worker)
+);
+
+CREATE TABLE IF NOT EXISTS wallet_invites (
+    token VARCHAR(64) NOT NULL PRIMARY KEY,
+    wallet_id BIGINT NOT NULL REFERENCES wallets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL DEFAULT 'viewer' CHECK (role IN ('owner', 'viewer')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX wallet_invites_expires_time_idx ON wallet_invites (expires_at);
+
+CREATE TABLE IF NOT EXISTS payouts_notifications (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    executed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX payouts_notifications_executed_time_idx ON payouts_notifications (executed_at);
+
+CREATE TABLE IF NOT EXISTS admins (
+    user_id INTEGER NOT NULL PRIMARY KEY REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS notify_pauses (
+    scope VARCHAR(32) NOT NULL PRIMARY KEY,
+    paused_by BIGINT NOT NULL,
+    paused_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS broadcasts (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_by BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'draft',
+    default_lang VARCHAR(16),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX broadcasts_created_by_status_idx ON broadcasts (created_by, status);
+
+CREATE TABLE IF NOT EXISTS broadcast_messages (
+    broadcast_id BIGINT NOT NULL REFERENCES broadcasts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    lang VARCHAR(16) NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY(broadcast_id, lang)
+);
+
+CREATE TABLE IF NOT EXISTS broadcast_coins (
+    broadcast_id BIGINT NOT NULL REFERENCES broadcasts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    blockchain_coin VARCHAR(32) NOT NULL REFERENCES blockchains(coin) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY(broadcast_id, blockchain_coin)
+);
+
+CREATE TABLE IF NOT EXISTS broadcast_deliveries (
+    broadcast_id BIGINT NOT NULL REFERENCES broadcasts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    error TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(broadcast_id, user_id)
+);
+
+CREATE INDEX broadcast_deliveries_status_idx ON broadcast_deliveries (broadcast_id, status);
+
+CREATE TABLE IF NOT EXISTS support_tickets (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    support_message_id BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX support_tickets_user_status_idx ON support_tickets (user_id, status);
+CREATE INDEX support_tickets_support_message_idx ON support_tickets (support_message_id);
+
+CREATE TABLE IF NOT EXISTS support_ticket_messages (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    ticket_id BIGINT NOT NULL REFERENCES support_tickets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    direction VARCHAR(16) NOT NULL,
+    author_id BIGINT NOT NULL,
+    text TEXT NOT NULL DEFAULT '',
+    attachment_type VARCHAR(16),
+    attachment_file_id VARCHAR(256),
+    user_message_id BIGINT,
+    support_message_id BIGINT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX support_ticket_messages_ticket_idx ON support_ticket_messages (ticket_id);
+CREATE INDEX support_ticket_messages_user_message_idx ON support_ticket_messages (user_message_id);
+CREATE INDEX support_ticket_messages_support_message_idx ON support_ticket_messages (support_message_id);
+
+CREATE TABLE IF NOT EXISTS chat_targets (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL,
+    thread_id BIGINT NOT NULL DEFAULT 0,
+    chat_type VARCHAR(16) NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, chat_id, thread_id)
+);
+
+CREATE INDEX chat_targets_chat_idx ON chat_targets (chat_id);
+
+CREATE TABLE IF NOT EXISTS wallet_chat_targets (
+    wallet_id BIGINT NOT NULL REFERENCES user_wallets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    target_id BIGINT NOT NULL REFERENCES chat_targets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY(wallet_id, target_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_deletions (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    reason VARCHAR(16) NOT NULL CHECK (reason IN ('user_request', 'inactive')),
+    wallets_count INTEGER NOT NULL DEFAULT 0,
+    chat_targets_count INTEGER NOT NULL DEFAULT 0,
+    tickets_count INTEGER NOT NULL DEFAULT 0,
+    deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX user_deletions_deleted_time_idx ON user_deletions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS bot_metadata (
+    key VARCHAR(128) NOT NULL PRIMARY KEY,
+    hash VARCHAR(64) NOT NULL,
+    synced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1,6 @@
+package sqliteMigrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS